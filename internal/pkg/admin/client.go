@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Client lists the registrations of a registry serving the admin service, turns its maintenance on or off and makes
+// it hand off its listeners
+type Client struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewClient creates Client calling the admin service through cc
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{cc: cc}
+}
+
+// ListNetworkServices returns all the network services with their metadata
+func (c *Client) ListNetworkServices(ctx context.Context) ([]*structpb.Struct, error) {
+	return c.list(ctx, &serviceDesc.Streams[0], new(emptypb.Empty))
+}
+
+// ListNetworkServiceEndpoints returns all the endpoints with their metadata
+func (c *Client) ListNetworkServiceEndpoints(ctx context.Context) ([]*structpb.Struct, error) {
+	return c.list(ctx, &serviceDesc.Streams[1], new(emptypb.Empty))
+}
+
+// ListNetworkServiceEndpointHistory returns the changes of the endpoint with the name from the oldest one, the changes
+// of all the endpoints if the name is empty
+func (c *Client) ListNetworkServiceEndpointHistory(ctx context.Context, name string) ([]*structpb.Struct, error) {
+	in := &structpb.Struct{Fields: make(map[string]*structpb.Value)}
+	if name != "" {
+		in.Fields["name"] = structpb.NewStringValue(name)
+	}
+	return c.list(ctx, &serviceDesc.Streams[2], in)
+}
+
+// SetMaintenance turns the maintenance of the registry on or off and returns whether it is enabled
+func (c *Client) SetMaintenance(ctx context.Context, enabled bool) (bool, error) {
+	method := "/" + serviceName + "/" + serviceDesc.Methods[0].MethodName
+	in := &structpb.Struct{Fields: map[string]*structpb.Value{"enabled": structpb.NewBoolValue(enabled)}}
+	out := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, method, in, out); err != nil {
+		return false, errors.Wrapf(err, "failed to call %s", method)
+	}
+	return out.GetFields()["enabled"].GetBoolValue(), nil
+}
+
+// Handoff makes the registry hand off its listeners to a replacement process and returns the pid of the replacement
+func (c *Client) Handoff(ctx context.Context) (int, error) {
+	method := "/" + serviceName + "/" + serviceDesc.Methods[1].MethodName
+	out := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, method, new(emptypb.Empty), out); err != nil {
+		return 0, errors.Wrapf(err, "failed to call %s", method)
+	}
+	return int(out.GetFields()["pid"].GetNumberValue()), nil
+}
+
+func (c *Client) list(ctx context.Context, desc *grpc.StreamDesc, in interface{}) ([]*structpb.Struct, error) {
+	method := "/" + serviceName + "/" + desc.StreamName
+	stream, err := c.cc.NewStream(ctx, desc, method)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to call %s", method)
+	}
+	if err = stream.SendMsg(in); err != nil {
+		return nil, errors.Wrapf(err, "failed to call %s", method)
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, errors.Wrapf(err, "failed to call %s", method)
+	}
+	var entries []*structpb.Struct
+	for {
+		entry := new(structpb.Struct)
+		if err = stream.RecvMsg(entry); errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to receive from %s", method)
+		}
+		entries = append(entries, entry)
+	}
+}
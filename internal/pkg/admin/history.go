@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/tombstone"
+)
+
+// The actions of the changes of the endpoints besides the deletion reasons of the tombstone package
+const (
+	registeredAction = "registered"
+	updatedAction    = "updated"
+)
+
+// change is a change of the state of an endpoint, the refreshes not changing the endpoint are not changes
+type change struct {
+	time     time.Time
+	name     string
+	action   string
+	identity string
+	fields   []string
+}
+
+// history is the ring buffer of the latest changes of the endpoints written through this replica
+type history struct {
+	mu      sync.Mutex
+	changes []change
+	next    int
+	last    map[string]*registry.NetworkServiceEndpoint
+}
+
+func newHistory(size int) *history {
+	return &history{
+		changes: make([]change, 0, size),
+		last:    make(map[string]*registry.NetworkServiceEndpoint),
+	}
+}
+
+// registered records the registration of the endpoint if it is new or differs from its previous registration
+func (h *history) registered(nse *registry.NetworkServiceEndpoint, identity string) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	previous, ok := h.last[nse.GetName()]
+	h.last[nse.GetName()] = nse.Clone()
+	if !ok {
+		h.add(change{time: time.Now(), name: nse.GetName(), action: registeredAction, identity: identity})
+		return
+	}
+	if fields := changedFields(previous, nse); len(fields) > 0 {
+		h.add(change{time: time.Now(), name: nse.GetName(), action: updatedAction, identity: identity, fields: fields})
+	}
+}
+
+// unregistered records the unregistration of the endpoint with the reason from the context
+func (h *history) unregistered(ctx context.Context, nse *registry.NetworkServiceEndpoint, identity string) {
+	if h == nil {
+		return
+	}
+	reason, ok := tombstone.FromContext(ctx)
+	if !ok {
+		reason = tombstone.Unregistered
+		if expirationTime := nse.GetExpirationTime(); expirationTime != nil && !expirationTime.AsTime().After(time.Now()) {
+			reason = tombstone.Expired
+		}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.last, nse.GetName())
+	h.add(change{time: time.Now(), name: nse.GetName(), action: string(reason), identity: identity})
+}
+
+// add adds the change overwriting the oldest one once the buffer is full, h.mu is expected to be locked
+func (h *history) add(c change) {
+	if cap(h.changes) == 0 {
+		return
+	}
+	if len(h.changes) < cap(h.changes) {
+		h.changes = append(h.changes, c)
+		return
+	}
+	h.changes[h.next] = c
+	h.next = (h.next + 1) % len(h.changes)
+}
+
+// list returns the changes of the endpoint with the name from the oldest one, all the changes if the name is empty
+func (h *history) list(name string) []change {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var result []change
+	for i := range h.changes {
+		c := h.changes[(h.next+i)%len(h.changes)]
+		if name == "" || c.name == name {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// changedFields returns the names of the fields of the endpoint changed by the registration, the expiration and the
+// registration times change with every refresh and are left out
+func changedFields(previous, current *registry.NetworkServiceEndpoint) []string {
+	var fields []string
+	if previous.GetUrl() != current.GetUrl() {
+		fields = append(fields, "url")
+	}
+	if !proto.Equal(&registry.NetworkServiceEndpoint{NetworkServiceNames: previous.GetNetworkServiceNames()},
+		&registry.NetworkServiceEndpoint{NetworkServiceNames: current.GetNetworkServiceNames()}) {
+		fields = append(fields, "networkServiceNames")
+	}
+	if !proto.Equal(&registry.NetworkServiceEndpoint{NetworkServiceLabels: previous.GetNetworkServiceLabels()},
+		&registry.NetworkServiceEndpoint{NetworkServiceLabels: current.GetNetworkServiceLabels()}) {
+		fields = append(fields, "networkServiceLabels")
+	}
+	return fields
+}
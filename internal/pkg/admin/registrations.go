@@ -0,0 +1,199 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admin provides the admin gRPC service listing the registrations with their metadata for the operators and
+// the tooling, separately from the Find API of NSM
+package admin
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+// metadata is what the registry knows about a registration besides the record
+type metadata struct {
+	lastRefreshed time.Time
+	identity      string
+}
+
+// Registrations keeps the metadata of the registrations passing through the storage of NewStorage and lists the
+// registrations of the storage with it. The metadata is kept in memory, so it is known for the registrations refreshed
+// through this replica since it started.
+type Registrations struct {
+	nsReader  registry.NetworkServiceRegistryServer
+	nseReader registry.NetworkServiceEndpointRegistryServer
+
+	mu       sync.Mutex
+	services map[string]metadata
+	nses     map[string]metadata
+
+	history *history
+}
+
+// NewRegistrations creates Registrations keeping up to historySize latest changes of the endpoints, it lists the
+// registrations once passed to NewStorage
+func NewRegistrations(historySize int) *Registrations {
+	return &Registrations{
+		services: make(map[string]metadata),
+		nses:     make(map[string]metadata),
+		history:  newHistory(historySize),
+	}
+}
+
+func (r *Registrations) refreshed(registrations map[string]metadata, name, identity string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	registrations[name] = metadata{
+		lastRefreshed: time.Now(),
+		identity:      identity,
+	}
+}
+
+func (r *Registrations) removed(registrations map[string]metadata, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(registrations, name)
+}
+
+// lookup returns the metadata of the listed registrations and forgets the ones no longer in the storage, e.g. expired
+func (r *Registrations) lookup(registrations map[string]metadata, names []string) []metadata {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	listed := make(map[string]metadata, len(names))
+	result := make([]metadata, len(names))
+	for i, name := range names {
+		m, ok := registrations[name]
+		if ok {
+			listed[name] = m
+		}
+		result[i] = m
+	}
+	for name := range registrations {
+		if _, ok := listed[name]; !ok {
+			delete(registrations, name)
+		}
+	}
+	return result
+}
+
+// listNSs returns all the network services of the storage with their metadata
+func (r *Registrations) listNSs(ctx context.Context) ([]*registry.NetworkService, []metadata, error) {
+	recorder := &nsFindServer{ctx: ctx}
+	query := &registry.NetworkServiceQuery{NetworkService: new(registry.NetworkService)}
+	if err := r.nsReader.Find(query, recorder); err != nil {
+		return nil, nil, err
+	}
+	names := make([]string, len(recorder.services))
+	for i, ns := range recorder.services {
+		names[i] = ns.GetName()
+	}
+	return recorder.services, r.lookup(r.services, names), nil
+}
+
+// listNSEs returns all the endpoints of the storage with their metadata
+func (r *Registrations) listNSEs(ctx context.Context) ([]*registry.NetworkServiceEndpoint, []metadata, error) {
+	recorder := &nseFindServer{ctx: ctx}
+	query := &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint)}
+	if err := r.nseReader.Find(query, recorder); err != nil {
+		return nil, nil, err
+	}
+	names := make([]string, len(recorder.nses))
+	for i, nse := range recorder.nses {
+		names[i] = nse.GetName()
+	}
+	return recorder.nses, r.lookup(r.nses, names), nil
+}
+
+func (r *Registrations) read(s storage.Storage) {
+	r.nsReader = chain.NewNetworkServiceRegistryServer(s.NetworkServiceRegistryServer(), &tailNSServer{})
+	r.nseReader = chain.NewNetworkServiceEndpointRegistryServer(s.NetworkServiceEndpointRegistryServer(), &tailNSEServer{})
+}
+
+// nsFindServer records the network services of the storage
+type nsFindServer struct {
+	grpc.ServerStream
+	ctx      context.Context
+	services []*registry.NetworkService
+}
+
+func (s *nsFindServer) Send(resp *registry.NetworkServiceResponse) error {
+	if !resp.GetDeleted() {
+		s.services = append(s.services, resp.GetNetworkService().Clone())
+	}
+	return nil
+}
+
+func (s *nsFindServer) Context() context.Context {
+	return s.ctx
+}
+
+// nseFindServer records the endpoints of the storage
+type nseFindServer struct {
+	grpc.ServerStream
+	ctx  context.Context
+	nses []*registry.NetworkServiceEndpoint
+}
+
+func (s *nseFindServer) Send(resp *registry.NetworkServiceEndpointResponse) error {
+	if !resp.GetDeleted() {
+		s.nses = append(s.nses, resp.GetNetworkServiceEndpoint().Clone())
+	}
+	return nil
+}
+
+func (s *nseFindServer) Context() context.Context {
+	return s.ctx
+}
+
+// tailNSServer terminates the reads of the storage so they never call into the rest of the registry chain
+type tailNSServer struct{}
+
+func (t *tailNSServer) Register(_ context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	return ns, nil
+}
+
+func (t *tailNSServer) Find(_ *registry.NetworkServiceQuery, _ registry.NetworkServiceRegistry_FindServer) error {
+	return nil
+}
+
+func (t *tailNSServer) Unregister(_ context.Context, _ *registry.NetworkService) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
+
+// tailNSEServer terminates the reads of the storage so they never call into the rest of the registry chain
+type tailNSEServer struct{}
+
+func (t *tailNSEServer) Register(_ context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return nse, nil
+}
+
+func (t *tailNSEServer) Find(_ *registry.NetworkServiceEndpointQuery, _ registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return nil
+}
+
+func (t *tailNSEServer) Unregister(_ context.Context, _ *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
@@ -0,0 +1,366 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/identity"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/maintenance"
+)
+
+const (
+	serviceName = "registryk8s.admin.v1.Admin"
+	fileName    = "registryk8s/admin/v1/admin.proto"
+)
+
+// init registers the descriptor of the service, so it is listed by the gRPC server reflection and callable by grpcurl.
+// Its messages are the well-known google.protobuf.Empty and google.protobuf.Struct types.
+func init() {
+	method := func(name, inputType string, serverStreaming bool) *descriptorpb.MethodDescriptorProto {
+		return &descriptorpb.MethodDescriptorProto{
+			Name:            proto.String(name),
+			InputType:       proto.String(inputType),
+			OutputType:      proto.String(".google.protobuf.Struct"),
+			ServerStreaming: proto.Bool(serverStreaming),
+		}
+	}
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String(fileName),
+		Package:    proto.String("registryk8s.admin.v1"),
+		Dependency: []string{emptypb.File_google_protobuf_empty_proto.Path(), structpb.File_google_protobuf_struct_proto.Path()},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("Admin"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					method("ListNetworkServices", ".google.protobuf.Empty", true),
+					method("ListNetworkServiceEndpoints", ".google.protobuf.Empty", true),
+					method("ListNetworkServiceEndpointHistory", ".google.protobuf.Struct", true),
+					method("SetMaintenance", ".google.protobuf.Struct", false),
+					method("Handoff", ".google.protobuf.Empty", false),
+				},
+			},
+		},
+		Syntax: proto.String("proto3"),
+	}, protoregistry.GlobalFiles)
+	if err == nil {
+		err = protoregistry.GlobalFiles.RegisterFile(file)
+	}
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Server is the admin service listing the network services and the endpoints with their metadata and the history of
+// the changes of the endpoints, turning the maintenance on or off and handing off the listeners
+type Server interface {
+	ListNetworkServices(*emptypb.Empty, grpc.ServerStream) error
+	ListNetworkServiceEndpoints(*emptypb.Empty, grpc.ServerStream) error
+	ListNetworkServiceEndpointHistory(*structpb.Struct, grpc.ServerStream) error
+	SetMaintenance(context.Context, *structpb.Struct) (*structpb.Struct, error)
+	Handoff(context.Context, *emptypb.Empty) (*structpb.Struct, error)
+}
+
+type adminServer struct {
+	registrations *Registrations
+	allowed       map[string]struct{}
+	maintenance   *maintenance.Mode
+	handoff       func(ctx context.Context) (int, error)
+}
+
+// Option is an option pattern for the admin service
+type Option func(s *adminServer)
+
+// WithMaintenance sets the maintenance mode turned on or off by SetMaintenance
+func WithMaintenance(mode *maintenance.Mode) Option {
+	return func(s *adminServer) {
+		s.maintenance = mode
+	}
+}
+
+// WithHandoff sets the function handing off the listeners to a replacement process called by Handoff, it returns the
+// pid of the replacement
+func WithHandoff(handoff func(ctx context.Context) (int, error)) Option {
+	return func(s *adminServer) {
+		s.handoff = handoff
+	}
+}
+
+// NewServer creates Server listing the registrations, only the peers presenting an X.509 SVID with one of the allowed
+// SPIFFE IDs are served
+func NewServer(r *Registrations, allowedSpiffeIDs []string, opts ...Option) Server {
+	s := &adminServer{
+		registrations: r,
+		allowed:       make(map[string]struct{}, len(allowedSpiffeIDs)),
+	}
+	for _, id := range allowedSpiffeIDs {
+		s.allowed[id] = struct{}{}
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Register registers the admin service on the server
+func Register(server *grpc.Server, s Server) {
+	server.RegisterService(&serviceDesc, s)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetMaintenance",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(structpb.Struct)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(Server).SetMaintenance(ctx, in)
+				}
+				info := &grpc.UnaryServerInfo{
+					Server:     srv,
+					FullMethod: "/" + serviceName + "/SetMaintenance",
+				}
+				return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(Server).SetMaintenance(ctx, req.(*structpb.Struct))
+				})
+			},
+		},
+		{
+			MethodName: "Handoff",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(emptypb.Empty)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(Server).Handoff(ctx, in)
+				}
+				info := &grpc.UnaryServerInfo{
+					Server:     srv,
+					FullMethod: "/" + serviceName + "/Handoff",
+				}
+				return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(Server).Handoff(ctx, req.(*emptypb.Empty))
+				})
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListNetworkServices",
+			ServerStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				in := new(emptypb.Empty)
+				if err := stream.RecvMsg(in); err != nil {
+					return err
+				}
+				return srv.(Server).ListNetworkServices(in, stream)
+			},
+		},
+		{
+			StreamName:    "ListNetworkServiceEndpoints",
+			ServerStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				in := new(emptypb.Empty)
+				if err := stream.RecvMsg(in); err != nil {
+					return err
+				}
+				return srv.(Server).ListNetworkServiceEndpoints(in, stream)
+			},
+		},
+		{
+			StreamName:    "ListNetworkServiceEndpointHistory",
+			ServerStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				in := new(structpb.Struct)
+				if err := stream.RecvMsg(in); err != nil {
+					return err
+				}
+				return srv.(Server).ListNetworkServiceEndpointHistory(in, stream)
+			},
+		},
+	},
+	Metadata: fileName,
+}
+
+func (s *adminServer) authorize(ctx context.Context) error {
+	id, ok := identity.PeerIDFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "the admin service requires an X.509 SVID")
+	}
+	if _, ok := s.allowed[id.String()]; !ok {
+		return status.Errorf(codes.PermissionDenied, "%s is not allowed to use the admin service", id.String())
+	}
+	return nil
+}
+
+func (s *adminServer) ListNetworkServices(_ *emptypb.Empty, stream grpc.ServerStream) error {
+	if err := s.authorize(stream.Context()); err != nil {
+		return err
+	}
+	services, metadata, err := s.registrations.listNSs(stream.Context())
+	if err != nil {
+		return err
+	}
+	for i, ns := range services {
+		entry, err := newEntry("networkService", ns, metadata[i])
+		if err != nil {
+			return err
+		}
+		if err = stream.SendMsg(entry); err != nil {
+			return errors.Wrapf(err, "failed to send a network service %s", ns.GetName())
+		}
+	}
+	return nil
+}
+
+func (s *adminServer) ListNetworkServiceEndpoints(_ *emptypb.Empty, stream grpc.ServerStream) error {
+	if err := s.authorize(stream.Context()); err != nil {
+		return err
+	}
+	nses, metadata, err := s.registrations.listNSEs(stream.Context())
+	if err != nil {
+		return err
+	}
+	for i, nse := range nses {
+		entry, err := newEntry("networkServiceEndpoint", nse, metadata[i])
+		if err != nil {
+			return err
+		}
+		if expirationTime := nse.GetExpirationTime(); expirationTime != nil {
+			entry.Fields["expirationTime"] = structpb.NewStringValue(expirationTime.AsTime().Format(time.RFC3339Nano))
+		}
+		if err = stream.SendMsg(entry); err != nil {
+			return errors.Wrapf(err, "failed to send a network service endpoint %s", nse.GetName())
+		}
+	}
+	return nil
+}
+
+// ListNetworkServiceEndpointHistory streams the changes of the endpoint named by the name field of the request from the
+// oldest one, the changes of all the endpoints without a name
+func (s *adminServer) ListNetworkServiceEndpointHistory(in *structpb.Struct, stream grpc.ServerStream) error {
+	if err := s.authorize(stream.Context()); err != nil {
+		return err
+	}
+	for _, c := range s.registrations.history.list(in.GetFields()["name"].GetStringValue()) {
+		entry := map[string]interface{}{
+			"time":   c.time.Format(time.RFC3339Nano),
+			"name":   c.name,
+			"action": c.action,
+		}
+		if c.identity != "" {
+			entry["identity"] = c.identity
+		}
+		if len(c.fields) > 0 {
+			fields := make([]interface{}, len(c.fields))
+			for i, field := range c.fields {
+				fields[i] = field
+			}
+			entry["changedFields"] = fields
+		}
+		result, err := structpb.NewStruct(entry)
+		if err != nil {
+			return errors.Wrapf(err, "failed to convert a change of a network service endpoint %s", c.name)
+		}
+		if err = stream.SendMsg(result); err != nil {
+			return errors.Wrapf(err, "failed to send a change of a network service endpoint %s", c.name)
+		}
+	}
+	return nil
+}
+
+// SetMaintenance turns the maintenance on or off by the enabled field of the request and returns whether it is enabled
+func (s *adminServer) SetMaintenance(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if s.maintenance == nil {
+		return nil, status.Error(codes.Unimplemented, "the maintenance is not available")
+	}
+	enabled, ok := in.GetFields()["enabled"].GetKind().(*structpb.Value_BoolValue)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "the enabled field of the request must be a bool")
+	}
+	s.maintenance.Set(enabled.BoolValue)
+	log.FromContext(ctx).Infof("maintenance enabled: %v", enabled.BoolValue)
+	return &structpb.Struct{Fields: map[string]*structpb.Value{
+		"enabled": structpb.NewBoolValue(s.maintenance.Enabled()),
+	}}, nil
+}
+
+// Handoff hands off the listeners to a replacement process and returns its pid, the registry stops serving then
+func (s *adminServer) Handoff(ctx context.Context, _ *emptypb.Empty) (*structpb.Struct, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if s.handoff == nil {
+		return nil, status.Error(codes.Unimplemented, "the handoff is not enabled")
+	}
+	pid, err := s.handoff(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &structpb.Struct{Fields: map[string]*structpb.Value{
+		"pid": structpb.NewNumberValue(float64(pid)),
+	}}, nil
+}
+
+// newEntry returns the record under the key with its metadata, the last refresh and the registering identity are left
+// out if the record was not refreshed through this replica yet
+func newEntry(key string, record proto.Message, m metadata) (*structpb.Struct, error) {
+	data, err := protojson.Marshal(record)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal a %s", key)
+	}
+	var fields map[string]interface{}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal a %s", key)
+	}
+	entry := map[string]interface{}{
+		key: fields,
+	}
+	if !m.lastRefreshed.IsZero() {
+		entry["lastRefreshed"] = m.lastRefreshed.Format(time.RFC3339Nano)
+	}
+	if m.identity != "" {
+		entry["identity"] = m.identity
+	}
+	result, err := structpb.NewStruct(entry)
+	return result, errors.Wrapf(err, "failed to convert a %s", key)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/identity"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+// NewStorage creates storage.Storage recording the metadata and the changes of the endpoints of the successful writes
+// of s in r, r lists the registrations of s. Find queries are served by s.
+func NewStorage(s storage.Storage, r *Registrations) storage.Storage {
+	r.read(s)
+	return storage.New(
+		&adminNSServer{
+			storage:       s.NetworkServiceRegistryServer(),
+			registrations: r,
+		},
+		&adminNSEServer{
+			storage:       s.NetworkServiceEndpointRegistryServer(),
+			registrations: r,
+		},
+	)
+}
+
+// spiffeID returns the SPIFFE ID of the workload registering the record or an empty string if it is unknown
+func spiffeID(ctx context.Context) string {
+	if id, ok := identity.SpiffeIDFromContext(ctx); ok {
+		return id.String()
+	}
+	return ""
+}
+
+type adminNSServer struct {
+	storage       registry.NetworkServiceRegistryServer
+	registrations *Registrations
+}
+
+func (s *adminNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	resp, err := s.storage.Register(ctx, ns)
+	if err == nil {
+		s.registrations.refreshed(s.registrations.services, resp.GetName(), spiffeID(ctx))
+	}
+	return resp, err
+}
+
+func (s *adminNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *adminNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	resp, err := s.storage.Unregister(ctx, ns)
+	if err == nil {
+		s.registrations.removed(s.registrations.services, ns.GetName())
+	}
+	return resp, err
+}
+
+type adminNSEServer struct {
+	storage       registry.NetworkServiceEndpointRegistryServer
+	registrations *Registrations
+}
+
+func (s *adminNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	resp, err := s.storage.Register(ctx, nse)
+	if err == nil {
+		s.registrations.refreshed(s.registrations.nses, resp.GetName(), spiffeID(ctx))
+		s.registrations.history.registered(resp, spiffeID(ctx))
+	}
+	return resp, err
+}
+
+func (s *adminNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *adminNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	resp, err := s.storage.Unregister(ctx, nse)
+	if err == nil {
+		s.registrations.removed(s.registrations.nses, nse.GetName())
+		s.registrations.history.unregistered(ctx, nse, spiffeID(ctx))
+	}
+	return resp, err
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit provides the audit log of the registry requests, recording who registered, unregistered or queried
+// which records with the result. It is written separately from the operational logs to a file or a socket.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/identity"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/requestid"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/rotate"
+)
+
+// The actions recorded in the audit log
+const (
+	registerAction   = "register"
+	unregisterAction = "unregister"
+	findAction       = "find"
+	conflictAction   = "conflict"
+)
+
+// The kinds of the records the actions refer to
+const (
+	nsKind  = "ns"
+	nseKind = "nse"
+)
+
+// Config contains configuration parameters for the audit log
+type Config struct {
+	Target     string `desc:"file path or unix:// or tcp:// socket URL the audit log is written to, empty disables it" split_words:"true"`
+	MaxSize    int64  `default:"104857600" desc:"size in bytes at which the audit log file is rotated" split_words:"true"`
+	MaxBackups int    `default:"10" desc:"number of the rotated audit log files kept next to the current one" split_words:"true"`
+}
+
+// Logger writes the audit records as JSON lines
+type Logger struct {
+	mu sync.Mutex
+	w  io.WriteCloser
+}
+
+// Open opens the audit log target, it returns nil if the audit log is disabled
+func Open(config *Config) (*Logger, error) {
+	if config.Target == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(config.Target)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid audit log target %s", config.Target)
+	}
+
+	var w io.WriteCloser
+	switch u.Scheme {
+	case unixScheme, tcpScheme:
+		w, err = dialSocket(u)
+	default:
+		w, err = rotate.Open(config.Target, rotate.WithMaxSize(config.MaxSize), rotate.WithMaxBackups(config.MaxBackups))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Logger{w: w}, nil
+}
+
+// Close closes the audit log target
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Close()
+}
+
+type record struct {
+	Time      time.Time `json:"time"`
+	RequestID string    `json:"requestID,omitempty"`
+	Action    string    `json:"action"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name,omitempty"`
+	Services  []string  `json:"networkServices,omitempty"`
+	Watch     bool      `json:"watch,omitempty"`
+	Results   int       `json:"results,omitempty"`
+	Owner     string    `json:"owner,omitempty"`
+	Outcome   string    `json:"outcome,omitempty"`
+	SpiffeID  string    `json:"spiffeID,omitempty"`
+	PeerID    string    `json:"peerID,omitempty"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+	Duration  string    `json:"duration"`
+	startTime time.Time
+}
+
+func newRecord(action, kind, name string) *record {
+	return &record{
+		Action:    action,
+		Kind:      kind,
+		Name:      name,
+		startTime: time.Now(),
+	}
+}
+
+// Conflict records the registration of the endpoint with the name owned by another SPIFFE ID and how the conflict was
+// resolved, the logger may be nil
+func (l *Logger) Conflict(ctx context.Context, name, owner, outcome string, err error) {
+	if l == nil {
+		return
+	}
+	r := newRecord(conflictAction, nseKind, name)
+	r.Owner = owner
+	r.Outcome = outcome
+	l.write(ctx, r, err)
+}
+
+// write completes the record with the identities of the requester and the result and writes it. A failure to write
+// the audit log is logged, but it does not fail the request.
+func (l *Logger) write(ctx context.Context, r *record, err error) {
+	r.Time = time.Now()
+	r.Duration = r.Time.Sub(r.startTime).String()
+	r.RequestID, _ = requestid.FromContext(ctx)
+	if id, ok := identity.SpiffeIDFromContext(ctx); ok {
+		r.SpiffeID = id.String()
+	}
+	if id, ok := identity.PeerIDFromContext(ctx); ok {
+		r.PeerID = id.String()
+	}
+	r.Result = status.Code(err).String()
+	if err != nil {
+		r.Error = err.Error()
+	}
+
+	data, marshalErr := json.Marshal(r)
+	if marshalErr != nil {
+		log.FromContext(ctx).Errorf("failed to marshal the audit record: %v", marshalErr)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, writeErr := l.w.Write(append(data, '\n')); writeErr != nil {
+		log.FromContext(ctx).Errorf("failed to write the audit record: %v", writeErr)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+// NewNetworkServiceRegistryServer wraps the authorize server recording the requests for the network services in the
+// audit log, including the ones it denies
+func NewNetworkServiceRegistryServer(logger *Logger, authorize registry.NetworkServiceRegistryServer) registry.NetworkServiceRegistryServer {
+	return chain.NewNetworkServiceRegistryServer(
+		&auditNSServer{
+			logger: logger,
+		},
+		authorize,
+	)
+}
+
+type auditNSServer struct {
+	logger *Logger
+}
+
+func (s *auditNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	r := newRecord(registerAction, nsKind, ns.GetName())
+	resp, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+	s.logger.write(ctx, r, err)
+	return resp, err
+}
+
+func (s *auditNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	r := newRecord(findAction, nsKind, query.GetNetworkService().GetName())
+	r.Watch = query.GetWatch()
+	counter := &countNSFindServer{NetworkServiceRegistry_FindServer: server}
+	err := next.NetworkServiceRegistryServer(server.Context()).Find(query, counter)
+	r.Results = counter.count
+	s.logger.write(server.Context(), r, err)
+	return err
+}
+
+func (s *auditNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	r := newRecord(unregisterAction, nsKind, ns.GetName())
+	resp, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+	s.logger.write(ctx, r, err)
+	return resp, err
+}
+
+// countNSFindServer counts the responses sent to the client
+type countNSFindServer struct {
+	registry.NetworkServiceRegistry_FindServer
+	count int
+}
+
+func (s *countNSFindServer) Send(resp *registry.NetworkServiceResponse) error {
+	s.count++
+	return s.NetworkServiceRegistry_FindServer.Send(resp)
+}
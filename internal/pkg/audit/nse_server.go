@@ -0,0 +1,81 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+// NewNetworkServiceEndpointRegistryServer wraps the authorize server recording the requests for the endpoints in the audit log,
+// including the ones it denies
+func NewNetworkServiceEndpointRegistryServer(logger *Logger, authorize registry.NetworkServiceEndpointRegistryServer) registry.NetworkServiceEndpointRegistryServer {
+	return chain.NewNetworkServiceEndpointRegistryServer(
+		&auditNSEServer{
+			logger: logger,
+		},
+		authorize,
+	)
+}
+
+type auditNSEServer struct {
+	logger *Logger
+}
+
+func (s *auditNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	r := newRecord(registerAction, nseKind, nse.GetName())
+	r.Services = nse.GetNetworkServiceNames()
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	s.logger.write(ctx, r, err)
+	return resp, err
+}
+
+func (s *auditNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	r := newRecord(findAction, nseKind, query.GetNetworkServiceEndpoint().GetName())
+	r.Services = query.GetNetworkServiceEndpoint().GetNetworkServiceNames()
+	r.Watch = query.GetWatch()
+	counter := &countNSEFindServer{NetworkServiceEndpointRegistry_FindServer: server}
+	err := next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, counter)
+	r.Results = counter.count
+	s.logger.write(server.Context(), r, err)
+	return err
+}
+
+func (s *auditNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	r := newRecord(unregisterAction, nseKind, nse.GetName())
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	s.logger.write(ctx, r, err)
+	return resp, err
+}
+
+// countNSEFindServer counts the responses sent to the client
+type countNSEFindServer struct {
+	registry.NetworkServiceEndpointRegistry_FindServer
+	count int
+}
+
+func (s *countNSEFindServer) Send(resp *registry.NetworkServiceEndpointResponse) error {
+	s.count++
+	return s.NetworkServiceEndpointRegistry_FindServer.Send(resp)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"net"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	unixScheme = "unix"
+	tcpScheme  = "tcp"
+)
+
+// socket is a connection to an audit log collector, it is redialed once the write fails
+type socket struct {
+	network, address string
+	conn             net.Conn
+}
+
+func dialSocket(u *url.URL) (*socket, error) {
+	s := &socket{
+		network: u.Scheme,
+		address: u.Host,
+	}
+	if s.network == unixScheme {
+		s.address = u.Path
+	}
+	if err := s.dial(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *socket) dial() error {
+	conn, err := net.Dial(s.network, s.address)
+	if err != nil {
+		return errors.Wrapf(err, "failed to connect to the audit log collector %s://%s", s.network, s.address)
+	}
+	s.conn = conn
+	return nil
+}
+
+func (s *socket) Write(p []byte) (int, error) {
+	if s.conn != nil {
+		if n, err := s.conn.Write(p); err == nil {
+			return n, nil
+		}
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	if err := s.dial(); err != nil {
+		return 0, err
+	}
+	n, err := s.conn.Write(p)
+	return n, errors.Wrapf(err, "failed to write to the audit log collector %s://%s", s.network, s.address)
+}
+
+func (s *socket) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backup provides the export and the import of the registrations of a storage for backup and restore
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+// Format is the format of the exported registrations
+type Format string
+
+// The formats of the exported registrations, the import accepts both
+const (
+	JSON Format = "json"
+	YAML Format = "yaml"
+)
+
+// Decode implements envconfig.Decoder
+func (f *Format) Decode(value string) error {
+	switch format := Format(value); format {
+	case JSON, YAML:
+		*f = format
+		return nil
+	default:
+		return errors.Errorf("unknown format %s, expected json or yaml", value)
+	}
+}
+
+// document is the exported registrations, the records are encoded with protojson so the expiration times and the
+// other well-known types keep their canonical form
+type document struct {
+	NetworkServices         []json.RawMessage `json:"networkServices"`
+	NetworkServiceEndpoints []json.RawMessage `json:"networkServiceEndpoints"`
+}
+
+// Export writes all the network services and endpoints of the storage including their expiration times to w
+func Export(ctx context.Context, s storage.Storage, w io.Writer, format Format) error {
+	var doc document
+
+	nsServer := &nsFindServer{ctx: ctx}
+	if err := s.NetworkServiceRegistryServer().Find(&registry.NetworkServiceQuery{
+		NetworkService: new(registry.NetworkService),
+	}, nsServer); err != nil {
+		return errors.Wrap(err, "failed to find the network services")
+	}
+	nseServer := &nseFindServer{ctx: ctx}
+	if err := s.NetworkServiceEndpointRegistryServer().Find(&registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+	}, nseServer); err != nil {
+		return errors.Wrap(err, "failed to find the network service endpoints")
+	}
+
+	var err error
+	if doc.NetworkServices, err = marshalAll(nsServer.records); err != nil {
+		return err
+	}
+	if doc.NetworkServiceEndpoints, err = marshalAll(nseServer.records); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(&doc, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the registrations")
+	}
+	if format == YAML {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return errors.Wrap(err, "failed to convert the registrations to YAML")
+		}
+	}
+	_, err = w.Write(data)
+	return errors.Wrap(err, "failed to write the registrations")
+}
+
+// Import registers the network services and then the endpoints read from r in JSON or YAML to the storage. The
+// endpoints keep their expiration times, so the endpoints expired since the export expire again right away.
+func Import(ctx context.Context, s storage.Storage, r io.Reader) (services, endpoints int, err error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to read the registrations")
+	}
+	// YAML is a superset of JSON, so both are converted
+	if data, err = yaml.YAMLToJSON(data); err != nil {
+		return 0, 0, errors.Wrap(err, "failed to parse the registrations")
+	}
+	var doc document
+	if err = json.Unmarshal(data, &doc); err != nil {
+		return 0, 0, errors.Wrap(err, "failed to parse the registrations")
+	}
+
+	for _, raw := range doc.NetworkServices {
+		ns := new(registry.NetworkService)
+		if err = protojson.Unmarshal(raw, ns); err != nil {
+			return services, endpoints, errors.Wrapf(err, "failed to parse a network service %s", raw)
+		}
+		if _, err = s.NetworkServiceRegistryServer().Register(ctx, ns); err != nil {
+			return services, endpoints, errors.Wrapf(err, "failed to register a network service %s", ns.GetName())
+		}
+		services++
+	}
+	for _, raw := range doc.NetworkServiceEndpoints {
+		nse := new(registry.NetworkServiceEndpoint)
+		if err = protojson.Unmarshal(raw, nse); err != nil {
+			return services, endpoints, errors.Wrapf(err, "failed to parse a network service endpoint %s", raw)
+		}
+		if _, err = s.NetworkServiceEndpointRegistryServer().Register(ctx, nse); err != nil {
+			return services, endpoints, errors.Wrapf(err, "failed to register a network service endpoint %s", nse.GetName())
+		}
+		endpoints++
+	}
+	return services, endpoints, nil
+}
+
+func marshalAll[M proto.Message](records []M) ([]json.RawMessage, error) {
+	raws := make([]json.RawMessage, 0, len(records))
+	for _, record := range records {
+		raw, err := protojson.Marshal(record)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal a registration")
+		}
+		raws = append(raws, raw)
+	}
+	return raws, nil
+}
+
+type nsFindServer struct {
+	grpc.ServerStream
+	ctx     context.Context
+	records []*registry.NetworkService
+}
+
+func (s *nsFindServer) Send(resp *registry.NetworkServiceResponse) error {
+	s.records = append(s.records, resp.GetNetworkService())
+	return nil
+}
+
+func (s *nsFindServer) Context() context.Context {
+	return s.ctx
+}
+
+type nseFindServer struct {
+	grpc.ServerStream
+	ctx     context.Context
+	records []*registry.NetworkServiceEndpoint
+}
+
+func (s *nseFindServer) Send(resp *registry.NetworkServiceEndpointResponse) error {
+	s.records = append(s.records, resp.GetNetworkServiceEndpoint())
+	return nil
+}
+
+func (s *nseFindServer) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bench provides the load generation against a running registry, simulating the endpoints refreshing their
+// registrations and the clients finding them, so the capacity of a registry can be planned without a full mesh
+package bench
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// The operations the latencies are reported for
+const (
+	registerOperation   = "register"
+	refreshOperation    = "refresh"
+	findOperation       = "find"
+	unregisterOperation = "unregister"
+)
+
+type options struct {
+	endpoints       int
+	clients         int
+	services        int
+	refreshInterval time.Duration
+	findInterval    time.Duration
+	duration        time.Duration
+	prefix          string
+	metricsURL      string
+}
+
+// Option is an option pattern for Run
+type Option func(o *options)
+
+// WithEndpoints sets the number of the simulated endpoints
+func WithEndpoints(endpoints int) Option {
+	return func(o *options) {
+		o.endpoints = endpoints
+	}
+}
+
+// WithClients sets the number of the simulated clients
+func WithClients(clients int) Option {
+	return func(o *options) {
+		o.clients = clients
+	}
+}
+
+// WithServices sets the number of the network services the endpoints are spread over
+func WithServices(services int) Option {
+	return func(o *options) {
+		o.services = services
+	}
+}
+
+// WithRefreshInterval sets the interval between the refreshes of the registration of every endpoint
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.refreshInterval = interval
+	}
+}
+
+// WithFindInterval sets the interval between the Find queries of every client
+func WithFindInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.findInterval = interval
+	}
+}
+
+// WithDuration sets how long the load is generated
+func WithDuration(duration time.Duration) Option {
+	return func(o *options) {
+		o.duration = duration
+	}
+}
+
+// WithPrefix sets the prefix of the names of the registered network services and endpoints, so the benchmark does
+// not collide with the real registrations
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithMetricsURL sets the url of the Prometheus endpoint of the registry the apiserver write rate is scraped from
+func WithMetricsURL(metricsURL string) Option {
+	return func(o *options) {
+		o.metricsURL = metricsURL
+	}
+}
+
+// Run registers the network services and then generates the load of the endpoints refreshing their registrations and
+// the clients finding the endpoints of the network services through cc for the duration. The registrations are
+// unregistered at the end.
+func Run(ctx context.Context, cc grpc.ClientConnInterface, opts ...Option) (*Report, error) {
+	o := &options{
+		endpoints:       100,
+		clients:         100,
+		services:        10,
+		refreshInterval: 10 * time.Second,
+		findInterval:    time.Second,
+		duration:        time.Minute,
+		prefix:          "bench",
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.services < 1 || o.refreshInterval <= 0 || o.findInterval <= 0 {
+		return nil, errors.New("at least one network service and positive intervals are required")
+	}
+
+	nsClient := registry.NewNetworkServiceRegistryClient(cc)
+	nseClient := registry.NewNetworkServiceEndpointRegistryClient(cc)
+	r := newRecorder()
+
+	services := make([]string, o.services)
+	for i := range services {
+		services[i] = fmt.Sprintf("%s-ns-%d", o.prefix, i)
+		if _, err := nsClient.Register(ctx, &registry.NetworkService{Name: services[i]}); err != nil {
+			return nil, errors.Wrapf(err, "failed to register the network service %s", services[i])
+		}
+	}
+	defer func() {
+		for _, service := range services {
+			_, _ = nsClient.Unregister(context.WithoutCancel(ctx), &registry.NetworkService{Name: service})
+		}
+	}()
+
+	var writesBefore float64
+	if o.metricsURL != "" {
+		var err error
+		if writesBefore, err = scrapeWrites(ctx, o.metricsURL); err != nil {
+			return nil, err
+		}
+	}
+
+	loadCtx, cancel := context.WithTimeout(ctx, o.duration)
+	defer cancel()
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < o.endpoints; i++ {
+		nse := &registry.NetworkServiceEndpoint{
+			Name:                fmt.Sprintf("%s-nse-%d", o.prefix, i),
+			NetworkServiceNames: []string{services[i%len(services)]},
+			Url:                 fmt.Sprintf("tcp://10.0.0.%d:5001", i%250+1),
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runEndpoint(loadCtx, nseClient, nse, spread(i, o.endpoints, o.refreshInterval), o.refreshInterval, r)
+		}()
+	}
+	for i := 0; i < o.clients; i++ {
+		query := &registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+				NetworkServiceNames: []string{services[i%len(services)]},
+			},
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runClient(loadCtx, nseClient, query, spread(i, o.clients, o.findInterval), o.findInterval, r)
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	report := r.report(elapsed)
+	if o.metricsURL != "" {
+		writesAfter, err := scrapeWrites(ctx, o.metricsURL)
+		if err != nil {
+			return nil, err
+		}
+		report.APIServerWriteRate = (writesAfter - writesBefore) / elapsed.Seconds()
+	}
+	return report, nil
+}
+
+// spread returns the delay of the first operation of the i-th of n simulated peers, so their operations are spread
+// over the interval instead of arriving at once
+func spread(i, n int, interval time.Duration) time.Duration {
+	return time.Duration(int64(interval) * int64(i) / int64(n))
+}
+
+func runEndpoint(ctx context.Context, client registry.NetworkServiceEndpointRegistryClient, nse *registry.NetworkServiceEndpoint, delay, interval time.Duration, r *recorder) {
+	if !sleep(ctx, delay) {
+		return
+	}
+	operation := registerOperation
+	registered := false
+	for {
+		start := time.Now()
+		_, err := client.Register(ctx, nse)
+		r.record(operation, time.Since(start), err)
+		if err == nil {
+			registered = true
+			operation = refreshOperation
+		}
+		if !sleep(ctx, interval) {
+			break
+		}
+	}
+	if registered {
+		start := time.Now()
+		_, err := client.Unregister(context.WithoutCancel(ctx), nse)
+		r.record(unregisterOperation, time.Since(start), err)
+	}
+}
+
+func runClient(ctx context.Context, client registry.NetworkServiceEndpointRegistryClient, query *registry.NetworkServiceEndpointQuery, delay, interval time.Duration, r *recorder) {
+	if !sleep(ctx, delay) {
+		return
+	}
+	for {
+		start := time.Now()
+		err := find(ctx, client, query)
+		if ctx.Err() == nil {
+			r.record(findOperation, time.Since(start), err)
+		}
+		if !sleep(ctx, interval) {
+			return
+		}
+	}
+}
+
+func find(ctx context.Context, client registry.NetworkServiceEndpointRegistryClient, query *registry.NetworkServiceEndpointQuery) error {
+	stream, err := client.Find(ctx, query)
+	if err != nil {
+		return err
+	}
+	for {
+		if _, err = stream.Recv(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// sleep waits for the duration and returns false if ctx is done meanwhile
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// writesMetric counts the requests of the Kubernetes clients of the registry by the code and the method
+const writesMetric = "registry_k8s_client_requests_total"
+
+var writeMethods = []string{"POST", "PUT", "PATCH", "DELETE"}
+
+// scrapeWrites returns the number of the apiserver writes of the registry exported by its Prometheus endpoint
+func scrapeWrites(ctx context.Context, metricsURL string) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metricsURL, http.NoBody)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid metrics url %s", metricsURL)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to scrape the metrics %s", metricsURL)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return 0, errors.Errorf("failed to scrape the metrics %s: %s", metricsURL, resp.Status)
+	}
+
+	var writes float64
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, writesMetric+"{") || !isWrite(line) {
+			continue
+		}
+		value, err := strconv.ParseFloat(line[strings.LastIndex(line, " ")+1:], 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid sample %s", line)
+		}
+		writes += value
+	}
+	return writes, errors.Wrapf(scanner.Err(), "failed to read the metrics %s", metricsURL)
+}
+
+func isWrite(line string) bool {
+	for _, method := range writeMethods {
+		if strings.Contains(line, `method="`+method+`"`) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Stats are the latencies of an operation
+type Stats struct {
+	Operation string
+	Count     int
+	Errors    int
+	Rate      float64
+	P50       time.Duration
+	P90       time.Duration
+	P99       time.Duration
+	Max       time.Duration
+}
+
+// Report is the result of a benchmark, the apiserver write rate is reported only with the metrics url of the registry
+type Report struct {
+	Duration           time.Duration
+	Operations         []Stats
+	APIServerWriteRate float64
+}
+
+// Write writes the report as a table to w
+func (r *Report) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "OPERATION\tCOUNT\tERRORS\tRATE/S\tP50\tP90\tP99\tMAX")
+	for i := range r.Operations {
+		s := &r.Operations[i]
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%v\t%v\t%v\t%v\n", s.Operation, s.Count, s.Errors, s.Rate,
+			round(s.P50), round(s.P90), round(s.P99), round(s.Max))
+	}
+	_, _ = fmt.Fprintf(tw, "\nduration: %v\n", r.Duration.Round(time.Millisecond))
+	if r.APIServerWriteRate > 0 {
+		_, _ = fmt.Fprintf(tw, "apiserver writes/s: %.1f\n", r.APIServerWriteRate)
+	}
+	return tw.Flush()
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(10 * time.Microsecond)
+}
+
+// recorder collects the latencies of the operations
+type recorder struct {
+	mu        sync.Mutex
+	latencies map[string][]time.Duration
+	errors    map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		latencies: make(map[string][]time.Duration),
+		errors:    make(map[string]int),
+	}
+}
+
+func (r *recorder) record(operation string, latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latencies[operation] = append(r.latencies[operation], latency)
+	if err != nil {
+		r.errors[operation]++
+	}
+}
+
+func (r *recorder) report(elapsed time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	report := &Report{Duration: elapsed}
+	for _, operation := range []string{registerOperation, refreshOperation, findOperation, unregisterOperation} {
+		latencies := r.latencies[operation]
+		if len(latencies) == 0 {
+			continue
+		}
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		report.Operations = append(report.Operations, Stats{
+			Operation: operation,
+			Count:     len(latencies),
+			Errors:    r.errors[operation],
+			Rate:      float64(len(latencies)) / elapsed.Seconds(),
+			P50:       percentile(latencies, 0.5),
+			P90:       percentile(latencies, 0.9),
+			P99:       percentile(latencies, 0.99),
+			Max:       latencies[len(latencies)-1],
+		})
+	}
+	return report
+}
+
+// percentile returns the p-th percentile of the sorted latencies by the nearest rank
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
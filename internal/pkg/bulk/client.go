@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// Client registers and unregisters many endpoints in one stream of a registry serving the bulk service
+type Client struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewClient creates Client calling the bulk service through cc
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{cc: cc}
+}
+
+// Register registers the endpoints and returns the registered ones in the same order. On a failure the endpoints
+// registered before the failing one are returned with the error.
+func (c *Client) Register(ctx context.Context, nses []*registry.NetworkServiceEndpoint) ([]*registry.NetworkServiceEndpoint, error) {
+	desc := &serviceDesc.Streams[0]
+	method := "/" + serviceName + "/" + desc.StreamName
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.cc.NewStream(ctx, desc, method)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to call %s", method)
+	}
+	// The endpoints are sent while the registered ones are received, so neither side blocks on a full stream
+	go func() {
+		for _, nse := range nses {
+			if stream.SendMsg(nse) != nil {
+				return
+			}
+		}
+		_ = stream.CloseSend()
+	}()
+	registered := make([]*registry.NetworkServiceEndpoint, 0, len(nses))
+	for {
+		nse := new(registry.NetworkServiceEndpoint)
+		err = stream.RecvMsg(nse)
+		if errors.Is(err, io.EOF) {
+			return registered, nil
+		}
+		if err != nil {
+			return registered, errors.Wrapf(err, "failed to receive from %s", method)
+		}
+		registered = append(registered, nse)
+	}
+}
+
+// Unregister unregisters the endpoints, on a failure the endpoints before the failing one are unregistered
+func (c *Client) Unregister(ctx context.Context, nses []*registry.NetworkServiceEndpoint) error {
+	desc := &serviceDesc.Streams[1]
+	method := "/" + serviceName + "/" + desc.StreamName
+	stream, err := c.cc.NewStream(ctx, desc, method)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call %s", method)
+	}
+	for _, nse := range nses {
+		// the status of a failure stopping the stream is returned by RecvMsg
+		if stream.SendMsg(nse) != nil {
+			break
+		}
+	}
+	if err = stream.CloseSend(); err != nil {
+		return errors.Wrapf(err, "failed to call %s", method)
+	}
+	return errors.Wrapf(stream.RecvMsg(new(emptypb.Empty)), "failed to call %s", method)
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bulk provides the service registering and unregistering many endpoints in one stream, so the forwarders
+// managing many endpoints refresh them without a request each
+package bulk
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+const (
+	serviceName = "registryk8s.bulk.v1.BulkNetworkServiceEndpointRegistry"
+	fileName    = "registryk8s/bulk/v1/bulk.proto"
+)
+
+// init registers the descriptor of the service, so it is listed by the gRPC server reflection and callable by grpcurl.
+// Its messages are the registry.NetworkServiceEndpoint of the registry API and the well-known google.protobuf.Empty.
+func init() {
+	nseDescriptor := new(registry.NetworkServiceEndpoint).ProtoReflect().Descriptor()
+	nseType := "." + string(nseDescriptor.FullName())
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String(fileName),
+		Package:    proto.String("registryk8s.bulk.v1"),
+		Dependency: []string{nseDescriptor.ParentFile().Path(), emptypb.File_google_protobuf_empty_proto.Path()},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("BulkNetworkServiceEndpointRegistry"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{
+						Name:            proto.String("Register"),
+						InputType:       proto.String(nseType),
+						OutputType:      proto.String(nseType),
+						ClientStreaming: proto.Bool(true),
+						ServerStreaming: proto.Bool(true),
+					},
+					{
+						Name:            proto.String("Unregister"),
+						InputType:       proto.String(nseType),
+						OutputType:      proto.String(".google.protobuf.Empty"),
+						ClientStreaming: proto.Bool(true),
+					},
+				},
+			},
+		},
+		Syntax: proto.String("proto3"),
+	}, protoregistry.GlobalFiles)
+	if err == nil {
+		err = protoregistry.GlobalFiles.RegisterFile(file)
+	}
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Server is the bulk service. Register registers the endpoints received on the stream one by one in the order they
+// arrive and sends each registered endpoint back, Unregister unregisters them and answers once the stream is closed.
+// Both stop at the first failing endpoint with its status, the endpoints before it are kept.
+type Server interface {
+	Register(grpc.ServerStream) error
+	Unregister(grpc.ServerStream) error
+}
+
+type bulkServer struct {
+	nseServer registry.NetworkServiceEndpointRegistryServer
+	timeout   time.Duration
+}
+
+// Option is an option of the bulk service
+type Option func(s *bulkServer)
+
+// WithTimeout sets the deadline of each endpoint like the one applied to the unary requests, 0 disables it
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *bulkServer) {
+		s.timeout = timeout
+	}
+}
+
+// NewServer creates Server passing each endpoint through the registry chain of nseServer, so they are authorized,
+// validated and written to the storage one by one exactly like the unary requests
+func NewServer(nseServer registry.NetworkServiceEndpointRegistryServer, opts ...Option) Server {
+	s := &bulkServer{
+		nseServer: nseServer,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Register registers the bulk service on the server
+func Register(server *grpc.Server, s Server) {
+	server.RegisterService(&serviceDesc, s)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*Server)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Register",
+			ClientStreams: true,
+			ServerStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				return srv.(Server).Register(stream)
+			},
+		},
+		{
+			StreamName:    "Unregister",
+			ClientStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				return srv.(Server).Unregister(stream)
+			},
+		},
+	},
+	Metadata: fileName,
+}
+
+func (s *bulkServer) Register(stream grpc.ServerStream) error {
+	for {
+		nse := new(registry.NetworkServiceEndpoint)
+		err := stream.RecvMsg(nse)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ctx, cancel := s.endpointContext(stream.Context())
+		resp, err := s.nseServer.Register(ctx, nse)
+		cancel()
+		if err != nil {
+			return withName(err, "register", nse.GetName())
+		}
+		if err = stream.SendMsg(resp); err != nil {
+			return errors.Wrapf(err, "failed to send the registered endpoint %s", nse.GetName())
+		}
+	}
+}
+
+func (s *bulkServer) Unregister(stream grpc.ServerStream) error {
+	for {
+		nse := new(registry.NetworkServiceEndpoint)
+		err := stream.RecvMsg(nse)
+		if errors.Is(err, io.EOF) {
+			return stream.SendMsg(new(emptypb.Empty))
+		}
+		if err != nil {
+			return err
+		}
+		ctx, cancel := s.endpointContext(stream.Context())
+		_, err = s.nseServer.Unregister(ctx, nse)
+		cancel()
+		if err != nil {
+			return withName(err, "unregister", nse.GetName())
+		}
+	}
+}
+
+// endpointContext returns the context of the registry chain call for one endpoint. The chain elements set the path of
+// each endpoint in the response headers, which are sent once per stream, so the headers of the endpoints are dropped.
+func (s *bulkServer) endpointContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	method := ""
+	if transportStream := grpc.ServerTransportStreamFromContext(ctx); transportStream != nil {
+		method = transportStream.Method()
+	}
+	ctx = grpc.NewContextWithServerTransportStream(ctx, &endpointStream{method: method})
+	if s.timeout > 0 {
+		return context.WithTimeout(ctx, s.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// withName returns the status of the failure naming the endpoint it failed for
+func withName(err error, action, name string) error {
+	st := status.Convert(err)
+	return status.Errorf(st.Code(), "failed to %s %s: %s", action, name, st.Message())
+}
+
+// endpointStream is the transport stream of a single endpoint of the bulk stream dropping its headers and trailers
+type endpointStream struct {
+	method string
+}
+
+func (s *endpointStream) Method() string {
+	return s.method
+}
+
+func (s *endpointStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *endpointStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *endpointStream) SetTrailer(metadata.MD) error {
+	return nil
+}
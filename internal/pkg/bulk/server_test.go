@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/memory"
+)
+
+// headerNSEServer sends a response header for every endpoint like the chain elements returning the path, and rejects
+// the endpoints named bad
+type headerNSEServer struct{}
+
+func (s *headerNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if nse.GetName() == "bad" {
+		return nil, status.Error(codes.InvalidArgument, "the endpoint is rejected")
+	}
+	if err := grpc.SendHeader(ctx, metadata.Pairs("name", nse.GetName())); err != nil {
+		return nil, err
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *headerNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *headerNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	if nse.GetName() == "bad" {
+		return nil, status.Error(codes.InvalidArgument, "the endpoint is rejected")
+	}
+	if err := grpc.SendHeader(ctx, metadata.Pairs("name", nse.GetName())); err != nil {
+		return nil, err
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+// newClient serves the bulk service passing the endpoints to the storage through headerNSEServer
+func newClient(t *testing.T) (*Client, registry.NetworkServiceEndpointRegistryServer) {
+	t.Helper()
+	nseServer := chain.NewNetworkServiceEndpointRegistryServer(new(headerNSEServer), memory.NewStorage().NetworkServiceEndpointRegistryServer())
+	server := grpc.NewServer()
+	Register(server, NewServer(nseServer))
+	ln := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(ln) }()
+	t.Cleanup(server.Stop)
+
+	cc, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = cc.Close() })
+	return NewClient(cc), nseServer
+}
+
+func nses(names ...string) []*registry.NetworkServiceEndpoint {
+	result := make([]*registry.NetworkServiceEndpoint, 0, len(names))
+	for _, name := range names {
+		result = append(result, &registry.NetworkServiceEndpoint{Name: name, NetworkServiceNames: []string{"ns"}})
+	}
+	return result
+}
+
+func stored(t *testing.T, s registry.NetworkServiceEndpointRegistryServer) map[string]bool {
+	t.Helper()
+	ch := make(chan *registry.NetworkServiceEndpointResponse, 10)
+	query := &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{}}
+	if err := s.Find(query, streamchannel.NewNetworkServiceEndpointFindServer(context.Background(), ch)); err != nil {
+		t.Fatalf("find failed: %v", err)
+	}
+	close(ch)
+	names := make(map[string]bool)
+	for resp := range ch {
+		names[resp.GetNetworkServiceEndpoint().GetName()] = true
+	}
+	return names
+}
+
+func TestRegisterAndUnregister(t *testing.T) {
+	client, nseServer := newClient(t)
+
+	registered, err := client.Register(context.Background(), nses("nse-1", "nse-2", "nse-3"))
+	if err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if len(registered) != 3 || registered[0].GetName() != "nse-1" || registered[2].GetName() != "nse-3" {
+		t.Fatalf("registered %v, want nse-1, nse-2 and nse-3 in order", registered)
+	}
+	if names := stored(t, nseServer); len(names) != 3 {
+		t.Fatalf("stored %v, want the 3 endpoints", names)
+	}
+
+	if err = client.Unregister(context.Background(), nses("nse-1", "nse-2", "nse-3")); err != nil {
+		t.Fatalf("unregister failed: %v", err)
+	}
+	if names := stored(t, nseServer); len(names) != 0 {
+		t.Errorf("stored %v after the unregistration", names)
+	}
+}
+
+func TestStopsAtTheFailingEndpoint(t *testing.T) {
+	client, nseServer := newClient(t)
+
+	registered, err := client.Register(context.Background(), nses("nse-1", "bad", "nse-3"))
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("register returned %v, want InvalidArgument", err)
+	}
+	if len(registered) != 1 || registered[0].GetName() != "nse-1" {
+		t.Errorf("registered %v, want nse-1 only", registered)
+	}
+	if names := stored(t, nseServer); len(names) != 1 || !names["nse-1"] {
+		t.Errorf("stored %v, want nse-1 only", names)
+	}
+
+	err = client.Unregister(context.Background(), nses("bad", "nse-1"))
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("unregister returned %v, want InvalidArgument", err)
+	}
+	if names := stored(t, nseServer); !names["nse-1"] {
+		t.Errorf("nse-1 after the failing endpoint is unregistered")
+	}
+}
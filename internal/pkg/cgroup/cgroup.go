@@ -0,0 +1,154 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cgroup reads the CPU and memory limits of the cgroup v2 or the cgroup v1 controllers of the process
+package cgroup
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	procCgroup = "/proc/self/cgroup"
+	cgroupRoot = "/sys/fs/cgroup"
+
+	// unlimitedMemory is the lowest value cgroup v1 reports for no memory limit, the page aligned max int64
+	unlimitedMemory = 1 << 62
+)
+
+// CPULimit returns the CPU limit in cores
+func CPULimit() (float64, bool) {
+	return limit("cpu", cpuMax, cfsQuota)
+}
+
+// MemoryLimit returns the memory limit in bytes
+func MemoryLimit() (int64, bool) {
+	return limit("memory", memoryMax, memoryLimitInBytes)
+}
+
+// limit returns the limit read by v2 from the cgroup v2 directories or by v1 from the cgroup v1 controller directories
+func limit[T any](controller string, v2, v1 func(dirs []string) (T, bool)) (T, bool) {
+	var none T
+	data, err := os.ReadFile(procCgroup)
+	if err != nil {
+		return none, false
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		switch {
+		case parts[0] == "0" && parts[1] == "":
+			if value, ok := v2(dirs(cgroupRoot, parts[2])); ok {
+				return value, true
+			}
+		case slices.Contains(strings.Split(parts[1], ","), controller):
+			if value, ok := v1(dirs(filepath.Join(cgroupRoot, parts[1]), parts[2])); ok {
+				return value, true
+			}
+		}
+	}
+	return none, false
+}
+
+// dirs returns the directory of the cgroup and the mount root, which is the directory of the cgroup when the
+// container has its own cgroup namespace
+func dirs(mount, path string) []string {
+	return []string{filepath.Join(mount, path), mount}
+}
+
+// readFirst returns the content of the file in the first directory having it
+func readFirst(dirs []string, name string) (string, string, bool) {
+	for _, dir := range dirs {
+		data, err := os.ReadFile(filepath.Clean(filepath.Join(dir, name)))
+		if err == nil {
+			return strings.TrimSpace(string(data)), dir, true
+		}
+	}
+	return "", "", false
+}
+
+// cpuMax parses the cgroup v2 cpu.max, "$MAX $PERIOD" with max meaning no limit
+func cpuMax(dirs []string) (float64, bool) {
+	data, _, ok := readFirst(dirs, "cpu.max")
+	if !ok {
+		return 0, false
+	}
+	fields := strings.Fields(data)
+	if len(fields) != 2 || fields[0] == "max" {
+		return 0, false
+	}
+	return ratio(fields[0], fields[1])
+}
+
+// cfsQuota parses the cgroup v1 cpu.cfs_quota_us and cpu.cfs_period_us, the quota -1 means no limit
+func cfsQuota(dirs []string) (float64, bool) {
+	quota, dir, ok := readFirst(dirs, "cpu.cfs_quota_us")
+	if !ok {
+		return 0, false
+	}
+	period, _, ok := readFirst([]string{dir}, "cpu.cfs_period_us")
+	if !ok {
+		return 0, false
+	}
+	return ratio(quota, period)
+}
+
+func ratio(quota, period string) (float64, bool) {
+	q, err := strconv.ParseFloat(quota, 64)
+	if err != nil || q <= 0 {
+		return 0, false
+	}
+	p, err := strconv.ParseFloat(period, 64)
+	if err != nil || p <= 0 {
+		return 0, false
+	}
+	return q / p, true
+}
+
+// memoryMax parses the cgroup v2 memory.max, max means no limit
+func memoryMax(dirs []string) (int64, bool) {
+	data, _, ok := readFirst(dirs, "memory.max")
+	if !ok || data == "max" {
+		return 0, false
+	}
+	return parseBytes(data)
+}
+
+// memoryLimitInBytes parses the cgroup v1 memory.limit_in_bytes
+func memoryLimitInBytes(dirs []string) (int64, bool) {
+	data, _, ok := readFirst(dirs, "memory.limit_in_bytes")
+	if !ok {
+		return 0, false
+	}
+	return parseBytes(data)
+}
+
+func parseBytes(data string) (int64, bool) {
+	value, err := strconv.ParseInt(data, 10, 64)
+	if err != nil || value <= 0 || value >= unlimitedMemory {
+		return 0, false
+	}
+	return value, true
+}
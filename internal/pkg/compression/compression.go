@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compression provides gzip compression of the registry responses. Importing the package registers the gzip
+// compressor, so the registry clients accept compressed responses as well.
+package compression
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// ServerOptions returns the options compressing the responses with gzip for the clients supporting it
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			setSendCompressor(ctx)
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			setSendCompressor(ss.Context())
+			return handler(srv, ss)
+		}),
+	}
+}
+
+func setSendCompressor(ctx context.Context) {
+	compressors, err := grpc.ClientSupportedCompressors(ctx)
+	if err == nil && slices.Contains(compressors, gzip.Name) {
+		_ = grpc.SetSendCompressor(ctx, gzip.Name)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connlimit provides limiting of the number of simultaneous client connections to the registry server
+package connlimit
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc/credentials"
+)
+
+// Limiter limits the number of simultaneous connections of all the credentials it wraps
+type Limiter struct {
+	maxConnections int64
+	active         atomic.Int64
+	rejected       metric.Int64Counter
+}
+
+// NewLimiter creates Limiter allowing maxConnections simultaneous connections
+func NewLimiter(maxConnections int) *Limiter {
+	rejected, _ := otel.Meter("").Int64Counter("registry_connections_rejected_total",
+		metric.WithDescription("number of client connections rejected for exceeding the connection limit"))
+	return &Limiter{
+		maxConnections: int64(maxConnections),
+		rejected:       rejected,
+	}
+}
+
+// Credentials wraps creds rejecting the server handshakes of the connections exceeding the limit. The limit is
+// enforced on the handshake, so it applies to every listener served with the credentials.
+func (l *Limiter) Credentials(creds credentials.TransportCredentials) credentials.TransportCredentials {
+	return &limitCredentials{
+		TransportCredentials: creds,
+		limiter:              l,
+	}
+}
+
+type limitCredentials struct {
+	credentials.TransportCredentials
+	limiter *Limiter
+}
+
+func (c *limitCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	l := c.limiter
+	if l.active.Add(1) > l.maxConnections {
+		l.active.Add(-1)
+		l.rejected.Add(context.Background(), 1)
+		return nil, nil, errors.Errorf("the limit of %d connections is reached", l.maxConnections)
+	}
+	conn, authInfo, err := c.TransportCredentials.ServerHandshake(rawConn)
+	if err != nil {
+		l.active.Add(-1)
+		return nil, nil, err
+	}
+	return &limitConn{
+		Conn: conn,
+		release: sync.OnceFunc(func() {
+			l.active.Add(-1)
+		}),
+	}, authInfo, nil
+}
+
+func (c *limitCredentials) Clone() credentials.TransportCredentials {
+	return &limitCredentials{
+		TransportCredentials: c.TransportCredentials.Clone(),
+		limiter:              c.limiter,
+	}
+}
+
+// limitConn releases its slot of the connection limit when it is closed
+type limitConn struct {
+	net.Conn
+	release func()
+}
+
+func (c *limitConn) Close() error {
+	c.release()
+	return c.Conn.Close()
+}
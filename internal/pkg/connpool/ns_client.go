@@ -0,0 +1,76 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connpool
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type poolNSClient struct {
+	pool *Pool
+}
+
+// NewNetworkServiceRegistryClient creates a chain element storing the pooled connection to the client URL from the context
+// for the rest of the chain, it replaces the dial chain element
+func NewNetworkServiceRegistryClient(pool *Pool) registry.NetworkServiceRegistryClient {
+	return &poolNSClient{
+		pool: pool,
+	}
+}
+
+func (c *poolNSClient) Register(ctx context.Context, in *registry.NetworkService, opts ...grpc.CallOption) (*registry.NetworkService, error) {
+	release, err := c.pool.store(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return next.NetworkServiceRegistryClient(ctx).Register(ctx, in, opts...)
+}
+
+func (c *poolNSClient) Find(ctx context.Context, in *registry.NetworkServiceQuery, opts ...grpc.CallOption) (registry.NetworkServiceRegistry_FindClient, error) {
+	release, err := c.pool.store(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := next.NetworkServiceRegistryClient(ctx).Find(ctx, in, opts...)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	go func() {
+		<-resp.Context().Done()
+		release()
+	}()
+	return resp, nil
+}
+
+func (c *poolNSClient) Unregister(ctx context.Context, in *registry.NetworkService, opts ...grpc.CallOption) (*empty.Empty, error) {
+	release, err := c.pool.store(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return next.NetworkServiceRegistryClient(ctx).Unregister(ctx, in, opts...)
+}
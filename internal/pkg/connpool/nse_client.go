@@ -0,0 +1,76 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connpool
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type poolNSEClient struct {
+	pool *Pool
+}
+
+// NewNetworkServiceEndpointRegistryClient creates a chain element storing the pooled connection to the client URL from the context
+// for the rest of the chain, it replaces the dial chain element
+func NewNetworkServiceEndpointRegistryClient(pool *Pool) registry.NetworkServiceEndpointRegistryClient {
+	return &poolNSEClient{
+		pool: pool,
+	}
+}
+
+func (c *poolNSEClient) Register(ctx context.Context, in *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
+	release, err := c.pool.store(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return next.NetworkServiceEndpointRegistryClient(ctx).Register(ctx, in, opts...)
+}
+
+func (c *poolNSEClient) Find(ctx context.Context, in *registry.NetworkServiceEndpointQuery, opts ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+	release, err := c.pool.store(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := next.NetworkServiceEndpointRegistryClient(ctx).Find(ctx, in, opts...)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	go func() {
+		<-resp.Context().Done()
+		release()
+	}()
+	return resp, nil
+}
+
+func (c *poolNSEClient) Unregister(ctx context.Context, in *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*empty.Empty, error) {
+	release, err := c.pool.store(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return next.NetworkServiceEndpointRegistryClient(ctx).Unregister(ctx, in, opts...)
+}
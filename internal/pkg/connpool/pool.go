@@ -0,0 +1,247 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connpool provides pooling of the client connections of the registry chain, e.g. to the proxy registry
+package connpool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/clientconn"
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type options struct {
+	dialOptions         []grpc.DialOption
+	dialTimeout         time.Duration
+	idleTimeout         time.Duration
+	healthCheckInterval time.Duration
+}
+
+// Option is an option pattern for the connection pool
+type Option func(o *options)
+
+// WithDialOptions sets grpc.DialOptions of the pooled connections
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = dialOptions
+	}
+}
+
+// WithDialTimeout sets the timeout of dialing a connection
+func WithDialTimeout(dialTimeout time.Duration) Option {
+	return func(o *options) {
+		o.dialTimeout = dialTimeout
+	}
+}
+
+// WithIdleTimeout sets how long an unused connection is kept open
+func WithIdleTimeout(idleTimeout time.Duration) Option {
+	return func(o *options) {
+		o.idleTimeout = idleTimeout
+	}
+}
+
+// WithHealthCheckInterval sets the interval of checking the pooled connections, the failed and idle ones are closed
+func WithHealthCheckInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.healthCheckInterval = interval
+	}
+}
+
+// conn is a pooled connection shared by its users
+type conn struct {
+	// ready is closed once the dial finished, cc and err are set before
+	ready chan struct{}
+	cc    *grpc.ClientConn
+	err   error
+
+	users    int
+	lastUsed time.Time
+	// removed connections are closed once their last user releases them
+	removed bool
+}
+
+// Pool shares one connection per target between the requests and the watches of the registry chain, so they don't
+// pay for dialing and the TLS handshake each time
+type Pool struct {
+	ctx     context.Context
+	options *options
+
+	mu    sync.Mutex
+	conns map[string]*conn
+}
+
+// New creates Pool closing its connections once ctx is done
+func New(ctx context.Context, opts ...Option) *Pool {
+	o := &options{
+		dialTimeout:         300 * time.Millisecond,
+		idleTimeout:         5 * time.Minute,
+		healthCheckInterval: 10 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	p := &Pool{
+		ctx:     ctx,
+		options: o,
+		conns:   make(map[string]*conn),
+	}
+	go p.maintain()
+	return p
+}
+
+// Get returns the connection to the target dialing it if there is none, release must be called once the connection
+// is no longer used
+func (p *Pool) Get(ctx context.Context, target string) (cc *grpc.ClientConn, release func(), err error) {
+	p.mu.Lock()
+	if p.ctx.Err() != nil {
+		p.mu.Unlock()
+		return nil, nil, errors.Wrapf(p.ctx.Err(), "failed to dial %s", target)
+	}
+	c, ok := p.conns[target]
+	if !ok {
+		c = &conn{ready: make(chan struct{})}
+		p.conns[target] = c
+	}
+	c.users++
+	p.mu.Unlock()
+
+	if !ok {
+		p.dial(ctx, target, c)
+	}
+	select {
+	case <-c.ready:
+	case <-ctx.Done():
+		p.release(c)
+		return nil, nil, errors.Wrapf(ctx.Err(), "failed to dial %s", target)
+	}
+	if c.err != nil {
+		p.release(c)
+		return nil, nil, c.err
+	}
+	return c.cc, sync.OnceFunc(func() { p.release(c) }), nil
+}
+
+// dial dials the connection on behalf of all its waiting users, so it is not canceled with the first one
+func (p *Pool) dial(ctx context.Context, target string, c *conn) {
+	dialCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), p.options.dialTimeout)
+	defer cancel()
+	cc, err := grpc.DialContext(dialCtx, target, p.options.dialOptions...)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		if cc != nil {
+			_ = cc.Close()
+		}
+		c.err = errors.Wrapf(err, "failed to dial %s", target)
+		p.remove(target, c)
+	} else {
+		c.cc = cc
+		c.lastUsed = time.Now()
+	}
+	close(c.ready)
+}
+
+// store stores the connection to the client URL from the context in the context for the rest of the chain, release
+// removes it again
+func (p *Pool) store(ctx context.Context) (release func(), err error) {
+	clientURL := clienturlctx.ClientURL(ctx)
+	if clientURL == nil {
+		return func() {}, nil
+	}
+	cc, releaseConn, err := p.Get(ctx, grpcutils.URLToTarget(clientURL))
+	if err != nil {
+		return nil, err
+	}
+	clientconn.Store(ctx, cc)
+	return sync.OnceFunc(func() {
+		clientconn.Delete(ctx)
+		releaseConn()
+	}), nil
+}
+
+func (p *Pool) release(c *conn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	c.users--
+	c.lastUsed = time.Now()
+	if c.removed && c.users == 0 && c.cc != nil {
+		_ = c.cc.Close()
+	}
+}
+
+func (p *Pool) maintain() {
+	ticker := time.NewTicker(p.options.healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			p.mu.Lock()
+			for target, c := range p.conns {
+				p.remove(target, c)
+			}
+			p.mu.Unlock()
+			return
+		case <-ticker.C:
+			p.check()
+		}
+	}
+}
+
+// check removes the failed connections, so the next users dial a new one, and the connections idle for too long
+func (p *Pool) check() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for target, c := range p.conns {
+		if c.cc == nil {
+			continue
+		}
+		if state := c.cc.GetState(); state == connectivity.TransientFailure || state == connectivity.Shutdown {
+			log.FromContext(p.ctx).WithField("connpool", "check").Warnf("connection to %s is %s, closing it", target, state)
+			p.remove(target, c)
+			continue
+		}
+		if c.users == 0 && time.Since(c.lastUsed) > p.options.idleTimeout {
+			p.remove(target, c)
+		}
+	}
+}
+
+// remove removes the connection from the pool closing it if it is unused
+func (p *Pool) remove(target string, c *conn) {
+	if p.conns[target] == c {
+		delete(p.conns, target)
+	}
+	c.removed = true
+	if c.users == 0 && c.cc != nil {
+		_ = c.cc.Close()
+	}
+}
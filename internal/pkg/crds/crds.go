@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package crds provides the installation of the NetworkService and NetworkServiceEndpoint CustomResourceDefinitions
+package crds
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	v1 "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/apis/networkservicemesh.io/v1"
+)
+
+const (
+	establishInterval = 500 * time.Millisecond
+	establishTimeout  = time.Minute
+	checkInterval     = 2 * time.Second
+)
+
+var crdResource = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+//go:embed *.yaml
+var manifests embed.FS
+
+// Ensure server-side applies the CustomResourceDefinitions expected by the registry and waits until they are
+// established, so the informers can start right after it returns
+func Ensure(ctx context.Context, client dynamic.Interface, fieldManager string) error {
+	entries, err := manifests.ReadDir(".")
+	if err != nil {
+		return errors.Wrap(err, "failed to read the CustomResourceDefinitions")
+	}
+	for _, entry := range entries {
+		if err = apply(ctx, client, fieldManager, entry.Name()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func apply(ctx context.Context, client dynamic.Interface, fieldManager, fileName string) error {
+	data, err := manifests.ReadFile(fileName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the CustomResourceDefinition %s", fileName)
+	}
+	crd := new(unstructured.Unstructured)
+	if err = yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), len(data)).Decode(&crd.Object); err != nil {
+		return errors.Wrapf(err, "failed to decode the CustomResourceDefinition %s", fileName)
+	}
+	body, err := crd.MarshalJSON()
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal the CustomResourceDefinition %s", crd.GetName())
+	}
+	force := true
+	if _, err = client.Resource(crdResource).Patch(ctx, crd.GetName(), types.ApplyPatchType, body, metav1.PatchOptions{
+		FieldManager: fieldManager,
+		Force:        &force,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to apply the CustomResourceDefinition %s", crd.GetName())
+	}
+	return waitEstablished(ctx, client, crd.GetName())
+}
+
+func waitEstablished(ctx context.Context, client dynamic.Interface, name string) error {
+	err := wait.PollUntilContextTimeout(ctx, establishInterval, establishTimeout, true, func(ctx context.Context) (bool, error) {
+		crd, err := client.Resource(crdResource).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			// the apiserver may briefly not return a just applied definition
+			return false, nil
+		}
+		conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+		for _, c := range conditions {
+			if condition, ok := c.(map[string]interface{}); ok && condition["type"] == "Established" && condition["status"] == "True" {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	return errors.Wrapf(err, "the CustomResourceDefinition %s is not established", name)
+}
+
+// Check fails if the apiserver does not serve the NetworkService and NetworkServiceEndpoint resources in the version
+// expected by the registry
+func Check(client discovery.DiscoveryInterface) error {
+	groupVersion := v1.SchemeGroupVersion.String()
+	resources, err := client.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		return errors.Wrapf(err, "the %s custom resources are not served", groupVersion)
+	}
+	served := make(map[string]struct{})
+	for i := range resources.APIResources {
+		served[resources.APIResources[i].Name] = struct{}{}
+	}
+	for _, name := range []string{"networkservices", "networkserviceendpoints"} {
+		if _, ok := served[name]; !ok {
+			return errors.Errorf("the %s custom resource %s is not served", groupVersion, name)
+		}
+	}
+	return nil
+}
+
+// Wait waits until Check passes, e.g. while the CustomResourceDefinitions are installed by another release, it fails
+// with the last Check error once ctx is done
+func Wait(ctx context.Context, client discovery.DiscoveryInterface) error {
+	var checkErr error
+	err := wait.PollUntilContextCancel(ctx, checkInterval, true, func(context.Context) (bool, error) {
+		if checkErr = Check(client); checkErr != nil {
+			log.FromContext(ctx).Infof("waiting for the CustomResourceDefinitions: %v", checkErr)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil && checkErr != nil {
+		return checkErr
+	}
+	return errors.Wrap(err, "failed to wait for the CustomResourceDefinitions")
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package deadline provides enforcement of the deadlines of the unary registry requests
+package deadline
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ServerOptions returns the interceptor applying defaultTimeout to the unary requests arriving without a deadline.
+// The handlers observe the deadline through their context, so the pending apiserver calls are cancelled once it is
+// exceeded, and the request fails with codes.DeadlineExceeded whatever error the cancelled handler returned. The
+// streams are not affected as the Find watches are expected to be long-lived.
+func ServerOptions(defaultTimeout time.Duration) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if _, ok := ctx.Deadline(); !ok && defaultTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+				defer cancel()
+			}
+			resp, err := handler(ctx, req)
+			if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, status.Errorf(codes.DeadlineExceeded, "%s exceeded the deadline: %v", info.FullMethod, err)
+			}
+			return resp, err
+		}),
+	}
+}
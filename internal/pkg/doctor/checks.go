@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+	"google.golang.org/grpc/status"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/crds"
+)
+
+// SPIRE checks the X.509 SVID is fetched from the SPIRE Workload API
+func SPIRE() Check {
+	return Check{Name: "spire", Run: func(ctx context.Context) (string, error) {
+		source, err := workloadapi.NewX509Source(ctx)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get the x509 source")
+		}
+		defer func() { _ = source.Close() }()
+		svid, err := source.GetX509SVID()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get the x509 svid")
+		}
+		return "SVID " + svid.ID.String() + " expires at " + svid.Certificates[0].NotAfter.String(), nil
+	}}
+}
+
+// APIServer checks the apiserver is reachable with the credentials of the registry
+func APIServer(client kubernetes.Interface) Check {
+	return Check{Name: "apiserver", Run: func(context.Context) (string, error) {
+		version, err := client.Discovery().ServerVersion()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get the apiserver version")
+		}
+		return "version " + version.GitVersion, nil
+	}}
+}
+
+// RBAC checks the registry is allowed the verbs on the resources in the namespace
+func RBAC(client kubernetes.Interface, namespace string, resources []authorizationv1.ResourceAttributes) Check {
+	return Check{Name: "rbac", Run: func(ctx context.Context) (string, error) {
+		var denied []string
+		for i := range resources {
+			attributes := resources[i]
+			attributes.Namespace = namespace
+			review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attributes},
+			}, metav1.CreateOptions{})
+			if err != nil {
+				return "", errors.Wrapf(err, "failed to review the access to %s", attributes.Resource)
+			}
+			if !review.Status.Allowed {
+				denied = append(denied, attributes.Verb+" "+qualified(&attributes))
+			}
+		}
+		if len(denied) > 0 {
+			return "", errors.Errorf("denied in the namespace %s: %s", namespace, strings.Join(denied, ", "))
+		}
+		return "all the required verbs are allowed", nil
+	}}
+}
+
+func qualified(attributes *authorizationv1.ResourceAttributes) string {
+	if attributes.Group == "" {
+		return attributes.Resource
+	}
+	return attributes.Resource + "." + attributes.Group
+}
+
+// CRDs checks the apiserver serves the custom resources expected by the registry
+func CRDs(client kubernetes.Interface) Check {
+	return Check{Name: "crds", Run: func(context.Context) (string, error) {
+		if err := crds.Check(client.Discovery()); err != nil {
+			return "", err
+		}
+		return "networkservices and networkserviceendpoints are served", nil
+	}}
+}
+
+// Policies checks the OPA policies at the paths are read and compiled
+func Policies(name string, paths []string) Check {
+	return Check{Name: name, Run: func(ctx context.Context) (string, error) {
+		policies, err := opa.PoliciesByFileMask(paths...)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read the policies")
+		}
+		for _, policy := range policies {
+			// the policy is compiled by its first check, only the failures without a gRPC status are not evaluations
+			if err = policy.Check(ctx, &registry.NetworkServiceEndpoint{}); err != nil {
+				if _, ok := status.FromError(err); !ok {
+					return "", errors.Wrapf(err, "failed to compile the policy %s", policy.Name())
+				}
+			}
+		}
+		return fmt.Sprintf("%d policies compiled", len(policies)), nil
+	}}
+}
+
+// Collector checks a TCP connection to the OpenTelemetry Collector endpoint is established
+func Collector(endpoint string) Check {
+	return Check{Name: "otel-collector", Run: func(ctx context.Context) (string, error) {
+		conn, err := new(net.Dialer).DialContext(ctx, "tcp", endpoint)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to connect to %s", endpoint)
+		}
+		_ = conn.Close()
+		return "connected to " + endpoint, nil
+	}}
+}
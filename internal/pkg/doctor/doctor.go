@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package doctor provides the self-diagnostics of the dependencies of the registry, run by the doctor command while
+// the registry is brought up in a cluster
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// Check diagnoses a dependency of the registry, it returns a short description of what it found or the error
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) (string, error)
+}
+
+// Result is the outcome of a Check
+type Result struct {
+	Name   string
+	Detail string
+	Err    error
+}
+
+// Run runs the checks one by one, each bounded by the timeout, and returns their results in the same order
+func Run(ctx context.Context, checks []Check, timeout time.Duration) []Result {
+	results := make([]Result, 0, len(checks))
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		detail, err := check.Run(checkCtx)
+		cancel()
+		results = append(results, Result{Name: check.Name, Detail: detail, Err: err})
+	}
+	return results
+}
+
+// Report writes the results as a table to w and returns the number of the failed checks
+func Report(w io.Writer, results []Result) (int, error) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	failed := 0
+	for _, result := range results {
+		status, detail := "ok", result.Detail
+		if result.Err != nil {
+			status, detail = "FAIL", result.Err.Error()
+			failed++
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Name, status, detail); err != nil {
+			return failed, err
+		}
+	}
+	if _, err := fmt.Fprintf(tw, "\n%d of %d checks failed\n", failed, len(results)); err != nil {
+		return failed, err
+	}
+	return failed, tw.Flush()
+}
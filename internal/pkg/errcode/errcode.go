@@ -0,0 +1,174 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package errcode provides the stable codes of the operator-facing failures, attached to the logs, the metrics and
+// the gRPC status details, so the alerts and the runbooks do not depend on the error messages
+package errcode
+
+import (
+	"context"
+	"path"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Code is the stable code of a failure
+type Code string
+
+// The codes of the failures
+const (
+	// SpireUnavailable means the SPIRE agent did not provide the X.509 SVID
+	SpireUnavailable Code = "SPIRE_UNAVAILABLE"
+	// APIServerThrottled means the apiserver or the client rate limiter rejected the request
+	APIServerThrottled Code = "APISERVER_THROTTLED"
+	// APIServerUnavailable means the apiserver did not respond in time or is not available
+	APIServerUnavailable Code = "APISERVER_UNAVAILABLE"
+	// PolicyDenied means the authorization policies denied the request
+	PolicyDenied Code = "POLICY_DENIED"
+	// CRConflict means the custom resource was changed concurrently or already exists
+	CRConflict Code = "CR_CONFLICT"
+	// CRInvalid means the apiserver rejected the custom resource as invalid
+	CRInvalid Code = "CR_INVALID"
+	// WrongShard means the registration belongs to a shard owned by another replica
+	WrongShard Code = "WRONG_SHARD"
+	// Timeout means the request did not complete before its deadline
+	Timeout Code = "TIMEOUT"
+	// Internal is any other failure
+	Internal Code = "INTERNAL"
+)
+
+const (
+	// Domain is the domain of the google.rpc.ErrorInfo status details carrying the code
+	Domain = "registry-k8s.networkservicemesh.io"
+
+	// LogField is the log field carrying the code
+	LogField = "errorCode"
+)
+
+type codedError struct {
+	code Code
+	err  error
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+// Wrap attaches the code to the error, it takes precedence over the classification of the wrapped error
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{code: code, err: err}
+}
+
+// Of returns the code of the error, the empty code for nil
+func Of(err error) Code {
+	if err == nil {
+		return ""
+	}
+
+	var coded *codedError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	if s, ok := status.FromError(err); ok {
+		for _, detail := range s.Details() {
+			if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == Domain {
+				return Code(info.GetReason())
+			}
+		}
+	}
+
+	switch {
+	case apierrors.IsTooManyRequests(err):
+		return APIServerThrottled
+	case apierrors.IsConflict(err), apierrors.IsAlreadyExists(err):
+		return CRConflict
+	case apierrors.IsInvalid(err):
+		return CRInvalid
+	case apierrors.IsServerTimeout(err), apierrors.IsTimeout(err), apierrors.IsServiceUnavailable(err):
+		return APIServerUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return Timeout
+	}
+
+	switch status.Code(err) {
+	case codes.PermissionDenied:
+		return PolicyDenied
+	case codes.DeadlineExceeded:
+		return Timeout
+	default:
+		return Internal
+	}
+}
+
+// Status converts the error to a gRPC status carrying its code in the google.rpc.ErrorInfo details
+func Status(err error) *status.Status {
+	s := status.Convert(err)
+	if err == nil {
+		return s
+	}
+	for _, detail := range s.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == Domain {
+			return s
+		}
+	}
+	if withDetails, detailsErr := s.WithDetails(&errdetails.ErrorInfo{Reason: string(Of(err)), Domain: Domain}); detailsErr == nil {
+		return withDetails
+	}
+	return s
+}
+
+// ServerOptions returns the interceptors counting the failed requests by their code and returning the code to the
+// client in the status details
+func ServerOptions() []grpc.ServerOption {
+	errorsCounter, _ := otel.Meter("").Int64Counter("registry_errors_total",
+		metric.WithDescription("number of failed requests by the method and the error code"))
+	count := func(ctx context.Context, method string, err error) error {
+		if err == nil {
+			return nil
+		}
+		s := Status(err)
+		errorsCounter.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("method", path.Base(method)), attribute.String("code", string(Of(s.Err())))))
+		return s.Err()
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			resp, err := handler(ctx, req)
+			return resp, count(ctx, info.FullMethod, err)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return count(ss.Context(), info.FullMethod, handler(srv, ss))
+		}),
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type authorizedKey struct{}
+
+// withAuthorized returns the context carrying a flag set by the element following the authorize element, so an error
+// returned without the flag set comes from the authorization
+func withAuthorized(ctx context.Context) (context.Context, *bool) {
+	authorized := new(bool)
+	return context.WithValue(ctx, authorizedKey{}, authorized), authorized
+}
+
+func markAuthorized(ctx context.Context) {
+	if authorized, ok := ctx.Value(authorizedKey{}).(*bool); ok {
+		*authorized = true
+	}
+}
+
+// NewNetworkServiceEndpointRegistryServer wraps the authorize server recording Unauthorized Events on the endpoints
+// whose registration or unregistration it denies
+func NewNetworkServiceEndpointRegistryServer(recorder record.EventRecorder, namespace string, authorize registry.NetworkServiceEndpointRegistryServer) registry.NetworkServiceEndpointRegistryServer {
+	return chain.NewNetworkServiceEndpointRegistryServer(
+		&deniedNSEServer{
+			recorder:  recorder,
+			namespace: namespace,
+		},
+		authorize,
+		&authorizedNSEServer{},
+	)
+}
+
+// NewNetworkServiceRegistryServer wraps the authorize server recording Unauthorized Events on the network services
+// whose registration or unregistration it denies
+func NewNetworkServiceRegistryServer(recorder record.EventRecorder, namespace string, authorize registry.NetworkServiceRegistryServer) registry.NetworkServiceRegistryServer {
+	return chain.NewNetworkServiceRegistryServer(
+		&deniedNSServer{
+			recorder:  recorder,
+			namespace: namespace,
+		},
+		authorize,
+		&authorizedNSServer{},
+	)
+}
+
+type deniedNSEServer struct {
+	recorder  record.EventRecorder
+	namespace string
+}
+
+func (s *deniedNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	ctx, authorized := withAuthorized(ctx)
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil && !*authorized {
+		s.recorder.Eventf(NSEReference(s.namespace, nse.GetName()), corev1.EventTypeWarning, Unauthorized, "registration denied: %v", err)
+	}
+	return resp, err
+}
+
+func (s *deniedNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *deniedNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	ctx, authorized := withAuthorized(ctx)
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	if err != nil && !*authorized {
+		s.recorder.Eventf(NSEReference(s.namespace, nse.GetName()), corev1.EventTypeWarning, Unauthorized, "unregistration denied: %v", err)
+	}
+	return resp, err
+}
+
+type authorizedNSEServer struct{}
+
+func (s *authorizedNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	markAuthorized(ctx)
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *authorizedNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *authorizedNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	markAuthorized(ctx)
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+type deniedNSServer struct {
+	recorder  record.EventRecorder
+	namespace string
+}
+
+func (s *deniedNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	ctx, authorized := withAuthorized(ctx)
+	resp, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+	if err != nil && !*authorized {
+		s.recorder.Eventf(NSReference(s.namespace, ns.GetName()), corev1.EventTypeWarning, Unauthorized, "registration denied: %v", err)
+	}
+	return resp, err
+}
+
+func (s *deniedNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *deniedNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	ctx, authorized := withAuthorized(ctx)
+	resp, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+	if err != nil && !*authorized {
+		s.recorder.Eventf(NSReference(s.namespace, ns.GetName()), corev1.EventTypeWarning, Unauthorized, "unregistration denied: %v", err)
+	}
+	return resp, err
+}
+
+type authorizedNSServer struct{}
+
+func (s *authorizedNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	markAuthorized(ctx)
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *authorizedNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *authorizedNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	markAuthorized(ctx)
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
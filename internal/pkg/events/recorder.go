@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package events records Kubernetes Events on the registry custom resources for the registry lifecycle actions
+package events
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	v1 "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/apis/networkservicemesh.io/v1"
+	"github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/clientset/versioned/scheme"
+)
+
+// Event reasons
+const (
+	Registered     = "Registered"
+	RegisterFailed = "RegisterFailed"
+	Unregistered   = "Unregistered"
+	Expired        = "Expired"
+	Unauthorized   = "Unauthorized"
+	ChurnSpike     = "ChurnSpike"
+)
+
+// NewRecorder creates record.EventRecorder writing the Events to the API server until ctx is done
+func NewRecorder(ctx context.Context, client kubernetes.Interface, component string) record.EventRecorder {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+	go func() {
+		<-ctx.Done()
+		broadcaster.Shutdown()
+	}()
+	return broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: component})
+}
+
+// NSEReference returns the reference to the endpoint custom resource the Events are recorded on
+func NSEReference(namespace, name string) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: v1.SchemeGroupVersion.String(),
+		Kind:       "NetworkServiceEndpoint",
+		Namespace:  namespace,
+		Name:       name,
+	}
+}
+
+// NSReference returns the reference to the network service custom resource the Events are recorded on
+func NSReference(namespace, name string) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: v1.SchemeGroupVersion.String(),
+		Kind:       "NetworkService",
+		Namespace:  namespace,
+		Name:       name,
+	}
+}
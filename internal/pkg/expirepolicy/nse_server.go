@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expirepolicy
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type expirePolicyNSEServer struct {
+	store *Store
+}
+
+// NewNetworkServiceEndpointRegistryServer creates the chain element setting the expiration of the registered endpoints
+// matched by the policies of the store, the shorter expirations requested by the endpoints are kept. The following
+// expire chain element still bounds it by the tokens and the default expiration.
+func NewNetworkServiceEndpointRegistryServer(store *Store) registry.NetworkServiceEndpointRegistryServer {
+	return &expirePolicyNSEServer{
+		store: store,
+	}
+}
+
+func (s *expirePolicyNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if expiration, ok := s.store.Expiration(nse); ok {
+		expirationTime := clock.FromContext(ctx).Now().Add(expiration)
+		if nse.GetExpirationTime() == nil || nse.GetExpirationTime().AsTime().After(expirationTime) {
+			nse.ExpirationTime = timestamppb.New(expirationTime)
+		}
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *expirePolicyNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *expirePolicyNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package expirepolicy provides the expiration overrides of the endpoints of the network services matched by name or
+// by label, e.g. shorter expirations of the ephemeral test services, configured by env or a ConfigMap
+package expirepolicy
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// Policy sets the expiration of the endpoints of the network service with the name, or of the network services the
+// endpoints label with the label
+type Policy struct {
+	Service    string
+	LabelKey   string
+	LabelValue string
+	Expiration time.Duration
+}
+
+// Policies is a comma separated list of the policies as service=expiration or key:value=expiration, e.g.
+// test-ns=30s,env:test=10s
+type Policies []Policy
+
+// Decode decodes the policies from their comma separated list
+func (p *Policies) Decode(value string) error {
+	var decoded Policies
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		policy, err := parse(item)
+		if err != nil {
+			return err
+		}
+		decoded = append(decoded, policy)
+	}
+	*p = decoded
+	return nil
+}
+
+func parse(item string) (Policy, error) {
+	selector, rawExpiration, ok := strings.Cut(item, "=")
+	if !ok || selector == "" {
+		return Policy{}, errors.Errorf("invalid expiration policy %s, expected service=expiration or key:value=expiration", item)
+	}
+	expiration, err := time.ParseDuration(rawExpiration)
+	if err != nil || expiration <= 0 {
+		return Policy{}, errors.Errorf("invalid expiration of the expiration policy %s, expected a positive duration", item)
+	}
+	if key, value, isLabel := strings.Cut(selector, ":"); isLabel {
+		return Policy{LabelKey: key, LabelValue: value, Expiration: expiration}, nil
+	}
+	return Policy{Service: selector, Expiration: expiration}, nil
+}
+
+func (p *Policy) matches(service string, labels map[string]string) bool {
+	if p.Service != "" {
+		return p.Service == service
+	}
+	value, ok := labels[p.LabelKey]
+	return ok && value == p.LabelValue
+}
+
+// expiration returns the shortest expiration of the policies matching any network service of the endpoint
+func (p Policies) expiration(nse *registry.NetworkServiceEndpoint) (time.Duration, bool) {
+	var expiration time.Duration
+	for i := range p {
+		for _, service := range nse.GetNetworkServiceNames() {
+			if p[i].matches(service, nse.GetNetworkServiceLabels()[service].GetLabels()) && (expiration == 0 || p[i].Expiration < expiration) {
+				expiration = p[i].Expiration
+			}
+		}
+	}
+	return expiration, expiration > 0
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expirepolicy
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Store holds the policies configured by env and the policies of the watched ConfigMap
+type Store struct {
+	static    Policies
+	configMap atomic.Pointer[Policies]
+}
+
+// NewStore creates Store with the policies configured by env
+func NewStore(static Policies) *Store {
+	return &Store{
+		static: static,
+	}
+}
+
+// Expiration returns the shortest expiration of the policies matching the endpoint or false if none matches
+func (s *Store) Expiration(nse *registry.NetworkServiceEndpoint) (time.Duration, bool) {
+	policies := s.static
+	if dynamic := s.configMap.Load(); dynamic != nil {
+		policies = append(append(Policies(nil), policies...), *dynamic...)
+	}
+	return policies.expiration(nse)
+}
+
+// WatchConfigMap watches the ConfigMap in the namespace until ctx is done and adds its policies to the ones configured
+// by env, one policy per value in the same format, e.g. test: "test-ns=30s". The keys only name the policies. A
+// ConfigMap with an invalid policy is ignored keeping the previous policies.
+func (s *Store) WatchConfigMap(ctx context.Context, client kubernetes.Interface, namespace, name string) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(client, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}))
+	update := func(obj interface{}) {
+		if configMap, ok := obj.(*corev1.ConfigMap); ok {
+			s.update(ctx, configMap)
+		}
+	}
+	if _, err := factory.Core().V1().ConfigMaps().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    update,
+		UpdateFunc: func(_, obj interface{}) { update(obj) },
+		DeleteFunc: func(interface{}) {
+			log.FromContext(ctx).Infof("the expiration policies ConfigMap %s is deleted", name)
+			s.configMap.Store(nil)
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to watch the expiration policies ConfigMap %s", name)
+	}
+	factory.Start(ctx.Done())
+	for informerType, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return errors.Errorf("failed to sync the informer cache for %v", informerType)
+		}
+	}
+	return nil
+}
+
+func (s *Store) update(ctx context.Context, configMap *corev1.ConfigMap) {
+	keys := make([]string, 0, len(configMap.Data))
+	for key := range configMap.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	policies := make(Policies, 0, len(keys))
+	for _, key := range keys {
+		policy, err := parse(configMap.Data[key])
+		if err != nil {
+			log.FromContext(ctx).Errorf("ignoring the expiration policies ConfigMap %s: %v: %v", configMap.Name, key, err)
+			return
+		}
+		policies = append(policies, policy)
+	}
+	s.configMap.Store(&policies)
+	log.FromContext(ctx).Infof("loaded %d expiration policies from the ConfigMap %s", len(policies), configMap.Name)
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakek8s
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/yaml"
+)
+
+// apiserver emulates the parts of the apiserver the object tracker lacks and the registry relies on: the names generated
+// from generateName, a new resourceVersion on every write, the conflicts of the updates and the patches carrying a
+// stale resourceVersion, the delete preconditions, the finalizers deferring the deletion and server-side apply. The
+// applied objects are merge patched with the applied configuration, so unlike the apiserver the fields the field manager
+// stops applying are kept.
+type apiserver struct {
+	tracker k8stesting.ObjectTracker
+	decoder runtime.Decoder
+
+	// mu makes the checks of the resourceVersions and the writes atomic
+	mu      sync.Mutex
+	version atomic.Uint64
+}
+
+func (a *apiserver) next() string {
+	return strconv.FormatUint(a.version.Add(1), 10)
+}
+
+func (a *apiserver) react(action k8stesting.Action) (bool, runtime.Object, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	gvr, namespace := action.GetResource(), action.GetNamespace()
+	switch action.GetVerb() {
+	case "create":
+		create, ok := action.(k8stesting.CreateAction)
+		if !ok || action.GetSubresource() != "" {
+			return false, nil, nil
+		}
+		obj, err := a.create(gvr, namespace, create.GetObject().DeepCopyObject())
+		return true, obj, err
+	case "update":
+		update, ok := action.(k8stesting.UpdateAction)
+		if !ok {
+			return false, nil, nil
+		}
+		obj, err := a.update(gvr, namespace, update.GetObject().DeepCopyObject())
+		return true, obj, err
+	case "delete":
+		deleteAction, ok := action.(k8stesting.DeleteAction)
+		if !ok {
+			return false, nil, nil
+		}
+		return true, nil, a.delete(gvr, namespace, deleteAction.GetName(), deleteAction.GetDeleteOptions().Preconditions)
+	case "patch":
+		patch, ok := action.(k8stesting.PatchAction)
+		if !ok {
+			return false, nil, nil
+		}
+		obj, err := a.patch(gvr, namespace, patch)
+		return true, obj, err
+	default:
+		return false, nil, nil
+	}
+}
+
+func (a *apiserver) create(gvr schema.GroupVersionResource, namespace string, obj runtime.Object) (runtime.Object, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	if accessor.GetName() == "" && accessor.GetGenerateName() != "" {
+		accessor.SetName(accessor.GetGenerateName() + rand.String(5))
+	}
+	if creationTimestamp := accessor.GetCreationTimestamp(); creationTimestamp.IsZero() {
+		accessor.SetCreationTimestamp(metav1.Now())
+	}
+	accessor.SetResourceVersion(a.next())
+	if err = a.tracker.Create(gvr, obj, namespace); err != nil {
+		return nil, err
+	}
+	return a.tracker.Get(gvr, namespace, accessor.GetName())
+}
+
+func (a *apiserver) update(gvr schema.GroupVersionResource, namespace string, obj runtime.Object) (runtime.Object, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	current, err := a.tracker.Get(gvr, namespace, accessor.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return a.write(gvr, namespace, current, obj)
+}
+
+// write replaces the current object unless obj carries a stale resourceVersion, the objects being deleted are deleted
+// once their last finalizer is removed
+func (a *apiserver) write(gvr schema.GroupVersionResource, namespace string, current, obj runtime.Object) (runtime.Object, error) {
+	currentAccessor, err := meta.Accessor(current)
+	if err != nil {
+		return nil, err
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	if version := accessor.GetResourceVersion(); version != "" && version != currentAccessor.GetResourceVersion() {
+		return nil, conflict(gvr, accessor.GetName())
+	}
+	accessor.SetCreationTimestamp(currentAccessor.GetCreationTimestamp())
+	accessor.SetDeletionTimestamp(currentAccessor.GetDeletionTimestamp())
+	if accessor.GetDeletionTimestamp() != nil && len(accessor.GetFinalizers()) == 0 {
+		return obj, a.tracker.Delete(gvr, namespace, accessor.GetName())
+	}
+	accessor.SetResourceVersion(a.next())
+	if err = a.tracker.Update(gvr, obj, namespace); err != nil {
+		return nil, err
+	}
+	return a.tracker.Get(gvr, namespace, accessor.GetName())
+}
+
+// delete deletes the object unless the precondition fails, the objects with finalizers are only marked as being deleted
+func (a *apiserver) delete(gvr schema.GroupVersionResource, namespace, name string, preconditions *metav1.Preconditions) error {
+	current, err := a.tracker.Get(gvr, namespace, name)
+	if err != nil {
+		return err
+	}
+	accessor, err := meta.Accessor(current)
+	if err != nil {
+		return err
+	}
+	if preconditions != nil && preconditions.ResourceVersion != nil && *preconditions.ResourceVersion != accessor.GetResourceVersion() {
+		return conflict(gvr, name)
+	}
+	if len(accessor.GetFinalizers()) == 0 {
+		return a.tracker.Delete(gvr, namespace, name)
+	}
+	if accessor.GetDeletionTimestamp() == nil {
+		now := metav1.Now()
+		accessor.SetDeletionTimestamp(&now)
+		accessor.SetResourceVersion(a.next())
+		return a.tracker.Update(gvr, current, namespace)
+	}
+	return nil
+}
+
+func (a *apiserver) patch(gvr schema.GroupVersionResource, namespace string, action k8stesting.PatchAction) (runtime.Object, error) {
+	current, err := a.tracker.Get(gvr, namespace, action.GetName())
+	if apierrors.IsNotFound(err) && action.GetPatchType() == types.ApplyPatchType && action.GetSubresource() == "" {
+		obj, _, decodeErr := a.decoder.Decode(action.GetPatch(), nil, nil)
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+		return a.create(gvr, namespace, obj)
+	}
+	if err != nil {
+		return nil, err
+	}
+	original, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := applyPatch(action.GetPatchType(), original, action.GetPatch(), current)
+	if err != nil {
+		return nil, apierrors.NewInvalid(schema.GroupKind{Group: gvr.Group, Kind: gvr.Resource}, action.GetName(),
+			field.ErrorList{field.Invalid(field.NewPath("patch"), string(action.GetPatch()), err.Error())})
+	}
+	obj := reflect.New(reflect.TypeOf(current).Elem()).Interface().(runtime.Object)
+	if err = json.Unmarshal(patched, obj); err != nil {
+		return nil, err
+	}
+	return a.write(gvr, namespace, current, obj)
+}
+
+// applyPatch returns the original JSON document patched, a server-side apply patch is merged into it
+func applyPatch(patchType types.PatchType, original, data []byte, dataStruct interface{}) ([]byte, error) {
+	switch patchType {
+	case types.JSONPatchType:
+		jsonPatch, err := jsonpatch.DecodePatch(data)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPatch.Apply(original)
+	case types.MergePatchType:
+		return jsonpatch.MergePatch(original, data)
+	case types.StrategicMergePatchType:
+		return strategicpatch.StrategicMergePatch(original, data, dataStruct)
+	case types.ApplyPatchType:
+		applied, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		return jsonpatch.MergePatch(original, applied)
+	default:
+		return nil, errors.Errorf("unsupported patch type %s", patchType)
+	}
+}
+
+func conflict(gvr schema.GroupVersionResource, name string) error {
+	return apierrors.NewConflict(gvr.GroupResource(), name,
+		errors.New("the object has been modified; please apply your changes to the latest version and try again"))
+}
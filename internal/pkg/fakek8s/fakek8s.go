@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fakek8s provides the fake Kubernetes clients backing the registry in the local development without a cluster,
+// the custom resources are optionally persisted to a local file
+package fakek8s
+
+import (
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	v1 "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/apis/networkservicemesh.io/v1"
+	"github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/clientset/versioned"
+	versionedfake "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/clientset/versioned/fake"
+	nsmscheme "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/clientset/versioned/scheme"
+)
+
+var (
+	once            sync.Once
+	versionedClient *versionedfake.Clientset
+	kubeClient      *kubefake.Clientset
+)
+
+func initClients() {
+	once.Do(func() {
+		versionedClient = NewVersionedClient()
+		kubeClient = NewKubeClient()
+	})
+}
+
+// VersionedClient returns the fake custom resource client shared by all its users in the process
+func VersionedClient() versioned.Interface {
+	initClients()
+	return versionedClient
+}
+
+// KubeClient returns the fake Kubernetes client shared by all its users in the process
+func KubeClient() kubernetes.Interface {
+	initClients()
+	return kubeClient
+}
+
+// NewVersionedClient creates a fake custom resource client of its own serving the CRDs as if they were installed
+func NewVersionedClient() *versionedfake.Clientset {
+	client := versionedfake.NewSimpleClientset()
+	client.PrependReactor("*", "*", (&apiserver{tracker: client.Tracker(), decoder: decoder()}).react)
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+		GroupVersion: v1.SchemeGroupVersion.String(),
+		APIResources: []metav1.APIResource{
+			{Name: "networkservices", Namespaced: true, Kind: "NetworkService"},
+			{Name: "networkserviceendpoints", Namespaced: true, Kind: "NetworkServiceEndpoint"},
+		},
+	}}
+	return client
+}
+
+// NewKubeClient creates a fake Kubernetes client of its own
+func NewKubeClient() *kubefake.Clientset {
+	client := kubefake.NewSimpleClientset()
+	client.PrependReactor("*", "*", (&apiserver{tracker: client.Tracker(), decoder: decoder()}).react)
+	return client
+}
+
+func decoder() runtime.Decoder {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = nsmscheme.AddToScheme(scheme)
+	return serializer.NewCodecFactory(scheme).UniversalDeserializer()
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakek8s
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	configMaps := KubeClient().CoreV1().ConfigMaps("default")
+	options := metav1.ApplyOptions{FieldManager: "test"}
+
+	if _, err := configMaps.Apply(ctx, corev1ac.ConfigMap("applied", "default").WithData(map[string]string{"a": "1"}), options); err != nil {
+		t.Fatalf("apply of a new object failed: %v", err)
+	}
+	created, err := configMaps.Get(ctx, "applied", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("the applied object is not created: %v", err)
+	}
+	created.Labels = map[string]string{"kept": "true"}
+	if _, err = configMaps.Update(ctx, created, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	if _, err = configMaps.Apply(ctx, corev1ac.ConfigMap("applied", "default").WithData(map[string]string{"a": "2"}), options); err != nil {
+		t.Fatalf("apply of an existing object failed: %v", err)
+	}
+	applied, err := configMaps.Get(ctx, "applied", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if applied.Data["a"] != "2" {
+		t.Errorf("the applied data is %v, want a=2", applied.Data)
+	}
+	if applied.Labels["kept"] != "true" {
+		t.Errorf("the fields not applied are lost: %v", applied.Labels)
+	}
+}
+
+func TestResourceVersions(t *testing.T) {
+	ctx := context.Background()
+	configMaps := NewKubeClient().CoreV1().ConfigMaps("default")
+
+	created, err := configMaps.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{GenerateName: "cm-"}}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if created.Name == "cm-" || created.ResourceVersion == "" {
+		t.Fatalf("got the name %q and the resourceVersion %q, want them set", created.Name, created.ResourceVersion)
+	}
+
+	updated, err := configMaps.Update(ctx, created, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if updated.ResourceVersion == created.ResourceVersion {
+		t.Errorf("the resourceVersion %q is not changed by the update", updated.ResourceVersion)
+	}
+	if _, err = configMaps.Update(ctx, created, metav1.UpdateOptions{}); !apierrors.IsConflict(err) {
+		t.Errorf("the update of a stale object got %v, want a conflict", err)
+	}
+	patch := []byte(`{"metadata":{"resourceVersion":"` + created.ResourceVersion + `","labels":{"a":"1"}}}`)
+	if _, err = configMaps.Patch(ctx, created.Name, types.MergePatchType, patch, metav1.PatchOptions{}); !apierrors.IsConflict(err) {
+		t.Errorf("the patch with a stale resourceVersion got %v, want a conflict", err)
+	}
+
+	stale := metav1.DeleteOptions{Preconditions: &metav1.Preconditions{ResourceVersion: &created.ResourceVersion}}
+	if err = configMaps.Delete(ctx, created.Name, stale); !apierrors.IsConflict(err) {
+		t.Errorf("the delete with a stale precondition got %v, want a conflict", err)
+	}
+	current := metav1.DeleteOptions{Preconditions: &metav1.Preconditions{ResourceVersion: &updated.ResourceVersion}}
+	if err = configMaps.Delete(ctx, created.Name, current); err != nil {
+		t.Errorf("the delete with a current precondition failed: %v", err)
+	}
+}
+
+func TestFinalizers(t *testing.T) {
+	ctx := context.Background()
+	configMaps := NewKubeClient().CoreV1().ConfigMaps("default")
+
+	if _, err := configMaps.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name:       "finalized",
+		Finalizers: []string{"a", "b"},
+	}}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if err := configMaps.Delete(ctx, "finalized", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	deleting, err := configMaps.Get(ctx, "finalized", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("the object with finalizers is deleted right away: %v", err)
+	}
+	if deleting.DeletionTimestamp == nil {
+		t.Error("the object with finalizers is not marked as being deleted")
+	}
+
+	remove := []byte(`[{"op":"test","path":"/metadata/finalizers/0","value":"a"},{"op":"remove","path":"/metadata/finalizers/0"}]`)
+	if _, err = configMaps.Patch(ctx, "finalized", types.JSONPatchType, remove, metav1.PatchOptions{}); err != nil {
+		t.Fatalf("the removal of the first finalizer failed: %v", err)
+	}
+	if _, err = configMaps.Patch(ctx, "finalized", types.JSONPatchType, remove, metav1.PatchOptions{}); !apierrors.IsInvalid(err) {
+		t.Errorf("the failed test operation got %v, want invalid", err)
+	}
+	if _, err = configMaps.Get(ctx, "finalized", metav1.GetOptions{}); err != nil {
+		t.Fatalf("the object with a finalizer left is deleted: %v", err)
+	}
+
+	deleting.Finalizers = nil
+	deleting.ResourceVersion = ""
+	if _, err = configMaps.Update(ctx, deleting, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("the removal of the last finalizer failed: %v", err)
+	}
+	if _, err = configMaps.Get(ctx, "finalized", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("the object without finalizers is not deleted: %v", err)
+	}
+}
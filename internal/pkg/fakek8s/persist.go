@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakek8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	v1 "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/apis/networkservicemesh.io/v1"
+)
+
+const saveInterval = time.Second
+
+// state is the content of the file the custom resources are persisted to
+type state struct {
+	NetworkServices         []v1.NetworkService         `json:"networkServices"`
+	NetworkServiceEndpoints []v1.NetworkServiceEndpoint `json:"networkServiceEndpoints"`
+}
+
+// Persist loads the custom resources of the fake custom resource client from the file if it exists and saves them to
+// it whenever they change until ctx is done. The returned function saves them one last time.
+func Persist(ctx context.Context, fileName string) (func(), error) {
+	initClients()
+	if err := load(ctx, fileName); err != nil {
+		return nil, err
+	}
+
+	last, _ := marshal(ctx)
+	save := func() {
+		data, err := marshal(ctx)
+		if err == nil && !bytes.Equal(data, last) {
+			err = write(fileName, data)
+		}
+		if err != nil {
+			log.FromContext(ctx).Errorf("failed to save the custom resources to %s: %+v", fileName, err)
+			return
+		}
+		last = data
+	}
+
+	saveCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(saveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-saveCtx.Done():
+				return
+			case <-ticker.C:
+				save()
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+		save()
+	}, nil
+}
+
+// load creates the custom resources from the file, so they get the resourceVersions of the fake apiserver
+func load(ctx context.Context, fileName string) error {
+	data, err := os.ReadFile(filepath.Clean(fileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the custom resources from %s", fileName)
+	}
+	var s state
+	if err = json.Unmarshal(data, &s); err != nil {
+		return errors.Wrapf(err, "failed to decode the custom resources from %s", fileName)
+	}
+	client := versionedClient.NetworkservicemeshV1()
+	for i := range s.NetworkServices {
+		ns := &s.NetworkServices[i]
+		if _, err = client.NetworkServices(ns.Namespace).Create(ctx, ns, metav1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "failed to load the network service %s", ns.Name)
+		}
+	}
+	for i := range s.NetworkServiceEndpoints {
+		nse := &s.NetworkServiceEndpoints[i]
+		if _, err = client.NetworkServiceEndpoints(nse.Namespace).Create(ctx, nse, metav1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "failed to load the endpoint %s", nse.Name)
+		}
+	}
+	return nil
+}
+
+func marshal(ctx context.Context) ([]byte, error) {
+	services, err := versionedClient.NetworkservicemeshV1().NetworkServices(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the network services")
+	}
+	endpoints, err := versionedClient.NetworkservicemeshV1().NetworkServiceEndpoints(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the endpoints")
+	}
+	data, err := json.MarshalIndent(&state{
+		NetworkServices:         services.Items,
+		NetworkServiceEndpoints: endpoints.Items,
+	}, "", "  ")
+	return data, errors.Wrap(err, "failed to encode the custom resources")
+}
+
+// write replaces the file atomically, so an interrupted write does not lose the previous content
+func write(fileName string, data []byte) error {
+	tmp := fileName + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return errors.Wrapf(err, "failed to write %s", tmp)
+	}
+	return errors.Wrapf(os.Rename(tmp, fileName), "failed to rename %s to %s", tmp, fileName)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+// NewNetworkServiceRegistryServer wraps the authorize server injecting the configured policy faults before its policy
+// evaluation, failing the requests with codes.Internal as a failed evaluation would. It returns authorize if no policy
+// fault is configured.
+func NewNetworkServiceRegistryServer(config *Config, authorize registry.NetworkServiceRegistryServer) registry.NetworkServiceRegistryServer {
+	i := newInjector("policy", codes.Internal, config.PolicyErrorRate, config.PolicyLatency)
+	if i == nil {
+		return authorize
+	}
+	return chain.NewNetworkServiceRegistryServer(&faultsPolicyNSServer{injector: i}, authorize)
+}
+
+type faultsPolicyNSServer struct {
+	injector *injector
+}
+
+func (s *faultsPolicyNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	if err := s.injector.inject(ctx); err != nil {
+		return nil, err
+	}
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *faultsPolicyNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	if err := s.injector.inject(server.Context()); err != nil {
+		return err
+	}
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *faultsPolicyNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	if err := s.injector.inject(ctx); err != nil {
+		return nil, err
+	}
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
+
+// NewNetworkServiceEndpointRegistryServer wraps the authorize server injecting the configured policy faults before its
+// policy evaluation, failing the requests with codes.Internal as a failed evaluation would. It returns authorize if no
+// policy fault is configured.
+func NewNetworkServiceEndpointRegistryServer(config *Config, authorize registry.NetworkServiceEndpointRegistryServer) registry.NetworkServiceEndpointRegistryServer {
+	i := newInjector("policy", codes.Internal, config.PolicyErrorRate, config.PolicyLatency)
+	if i == nil {
+		return authorize
+	}
+	return chain.NewNetworkServiceEndpointRegistryServer(&faultsPolicyNSEServer{injector: i}, authorize)
+}
+
+type faultsPolicyNSEServer struct {
+	injector *injector
+}
+
+func (s *faultsPolicyNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if err := s.injector.inject(ctx); err != nil {
+		return nil, err
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *faultsPolicyNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if err := s.injector.inject(server.Context()); err != nil {
+		return err
+	}
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *faultsPolicyNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	if err := s.injector.inject(ctx); err != nil {
+		return nil, err
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package faults provides the fault injection for resilience testing, failing or delaying a fraction of the storage
+// writes, the policy evaluations and the token minting, so the behavior of the clients under a degraded registry can
+// be verified without changing the code
+package faults
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const pointAttribute = attribute.Key("point")
+
+// Config contains configuration parameters for the fault injection, it must be enabled only in test environments
+type Config struct {
+	StorageWriteErrorRate float64       `default:"0" desc:"fraction of the storage writes, e.g. the custom resource writes, failed with an injected error, for resilience testing only" split_words:"true"`
+	StorageWriteLatency   time.Duration `default:"0" desc:"latency injected into every storage write, for resilience testing only" split_words:"true"`
+	PolicyErrorRate       float64       `default:"0" desc:"fraction of the server policy evaluations failed with an injected error, for resilience testing only" split_words:"true"`
+	PolicyLatency         time.Duration `default:"0" desc:"latency injected into every server policy evaluation, for resilience testing only" split_words:"true"`
+	TokenErrorRate        float64       `default:"0" desc:"fraction of the token minting failed with an injected error, for resilience testing only" split_words:"true"`
+	TokenLatency          time.Duration `default:"0" desc:"latency injected into every token minting, for resilience testing only" split_words:"true"`
+}
+
+// Enabled returns true if any fault is injected
+func (c *Config) Enabled() bool {
+	return c.StorageWriteErrorRate > 0 || c.StorageWriteLatency > 0 ||
+		c.PolicyErrorRate > 0 || c.PolicyLatency > 0 ||
+		c.TokenErrorRate > 0 || c.TokenLatency > 0
+}
+
+// injector delays the calls at the point by the latency and then fails the rate of them with the code
+type injector struct {
+	point    string
+	code     codes.Code
+	rate     float64
+	latency  time.Duration
+	injected metric.Int64Counter
+}
+
+// newInjector returns nil if no fault is injected at the point
+func newInjector(point string, code codes.Code, rate float64, latency time.Duration) *injector {
+	if rate <= 0 && latency <= 0 {
+		return nil
+	}
+	injected, _ := otel.Meter("").Int64Counter("registry_injected_faults_total",
+		metric.WithDescription("number of the errors injected by the fault injection by the point"))
+	return &injector{
+		point:    point,
+		code:     code,
+		rate:     rate,
+		latency:  latency,
+		injected: injected,
+	}
+}
+
+func (i *injector) inject(ctx context.Context) error {
+	if i.latency > 0 {
+		timer := time.NewTimer(i.latency)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if i.rate >= 1 || (i.rate > 0 && rand.Float64() < i.rate) { // #nosec G404 fault injection does not need a secure random
+		i.injected.Add(ctx, 1, metric.WithAttributes(pointAttribute.String(i.point)))
+		return status.Errorf(i.code, "injected %s fault", i.point)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+// NewStorage creates storage.Storage injecting the configured storage write faults into the writes of s, failing them
+// with codes.Unavailable as an unavailable apiserver would. It returns s if no storage write fault is configured.
+func NewStorage(s storage.Storage, config *Config) storage.Storage {
+	i := newInjector("storage-write", codes.Unavailable, config.StorageWriteErrorRate, config.StorageWriteLatency)
+	if i == nil {
+		return s
+	}
+	return storage.New(
+		&faultsNSServer{
+			storage:  s.NetworkServiceRegistryServer(),
+			injector: i,
+		},
+		&faultsNSEServer{
+			storage:  s.NetworkServiceEndpointRegistryServer(),
+			injector: i,
+		},
+	)
+}
+
+type faultsNSServer struct {
+	storage  registry.NetworkServiceRegistryServer
+	injector *injector
+}
+
+func (s *faultsNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	if err := s.injector.inject(ctx); err != nil {
+		return nil, err
+	}
+	return s.storage.Register(ctx, ns)
+}
+
+func (s *faultsNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *faultsNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	if err := s.injector.inject(ctx); err != nil {
+		return nil, err
+	}
+	return s.storage.Unregister(ctx, ns)
+}
+
+type faultsNSEServer struct {
+	storage  registry.NetworkServiceEndpointRegistryServer
+	injector *injector
+}
+
+func (s *faultsNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if err := s.injector.inject(ctx); err != nil {
+		return nil, err
+	}
+	return s.storage.Register(ctx, nse)
+}
+
+func (s *faultsNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *faultsNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	if err := s.injector.inject(ctx); err != nil {
+		return nil, err
+	}
+	return s.storage.Unregister(ctx, nse)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+// NewTokenGenerator wraps the generator injecting the configured token faults into the token minting. It returns the
+// generator if no token fault is configured.
+func NewTokenGenerator(config *Config, generator token.GeneratorFunc) token.GeneratorFunc {
+	i := newInjector("token", codes.Unauthenticated, config.TokenErrorRate, config.TokenLatency)
+	if i == nil {
+		return generator
+	}
+	return func(peerAuthInfo credentials.AuthInfo) (string, time.Time, error) {
+		// the generator has no context, the latency is bounded by the injector only
+		if err := i.inject(context.Background()); err != nil {
+			return "", time.Time{}, err
+		}
+		return generator(peerAuthInfo)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SVIDCheck returns Check failing if the source has no valid X.509 SVID, that is the SPIRE agent has not rotated it
+func SVIDCheck(source x509svid.Source) Check {
+	return func(_ context.Context) error {
+		svid, err := source.GetX509SVID()
+		if err != nil {
+			return errors.Wrap(err, "failed to get the X.509 SVID")
+		}
+		if len(svid.Certificates) == 0 {
+			return errors.Errorf("the X.509 SVID %s has no certificates", svid.ID)
+		}
+		if expiresAt := svid.Certificates[0].NotAfter; time.Now().After(expiresAt) {
+			return errors.Errorf("the X.509 SVID %s expired at %v", svid.ID, expiresAt)
+		}
+		return nil
+	}
+}
+
+// APIServerCheck returns Check failing if the Kubernetes apiserver is not ready
+func APIServerCheck(client kubernetes.Interface) Check {
+	return func(ctx context.Context) error {
+		return errors.Wrap(client.Discovery().RESTClient().Get().AbsPath("/readyz").Do(ctx).Error(), "the apiserver is not ready")
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package health provides grpc.health.v1.Health reporting the registry services as serving only while the backends
+// they depend on are healthy
+package health
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/api/pkg/api"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Check returns an error if a backend is not healthy
+type Check func(ctx context.Context) error
+
+type options struct {
+	interval time.Duration
+	timeout  time.Duration
+	checks   map[string]Check
+}
+
+// Option is an option pattern for the health service
+type Option func(o *options)
+
+// WithInterval sets the interval of the backend checks
+func WithInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.interval = interval
+	}
+}
+
+// WithTimeout sets the timeout of a single backend check
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithCheck adds the check of a backend, its status is also reported as a service with the backend name
+func WithCheck(backend string, check Check) Option {
+	return func(o *options) {
+		o.checks[backend] = check
+	}
+}
+
+// NewServer creates grpc.health.v1.Health shared by all the registry servers. The passed registry services and the
+// overall "" service are serving while all the backend checks pass, every backend is reported as a service with its
+// own name as well.
+func NewServer(ctx context.Context, services []interface{}, opts ...Option) grpc_health_v1.HealthServer {
+	o := &options{
+		interval: 10 * time.Second,
+		timeout:  5 * time.Second,
+		checks:   make(map[string]Check),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var serviceNames []string
+	for _, service := range services {
+		serviceNames = append(serviceNames, api.ServiceNames(service)...)
+	}
+	backends := make([]string, 0, len(o.checks))
+	for backend := range o.checks {
+		backends = append(backends, backend)
+	}
+	sort.Strings(backends)
+
+	h := &healthServer{
+		Server:       health.NewServer(),
+		options:      o,
+		serviceNames: append(serviceNames, ""),
+		backends:     backends,
+		failures:     make(map[string]string),
+	}
+	h.update(ctx)
+	go func() {
+		ticker := time.NewTicker(o.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				h.Shutdown()
+				return
+			case <-ticker.C:
+				h.update(ctx)
+			}
+		}
+	}()
+
+	return h.Server
+}
+
+type healthServer struct {
+	*health.Server
+	options      *options
+	serviceNames []string
+	backends     []string
+	failures     map[string]string
+}
+
+// update runs the backend checks and sets the serving status of the backends and the registry services
+func (h *healthServer) update(ctx context.Context) {
+	logger := log.FromContext(ctx).WithField("health", "update")
+	healthy := true
+	for _, backend := range h.backends {
+		checkCtx, cancel := context.WithTimeout(ctx, h.options.timeout)
+		err := h.options.checks[backend](checkCtx)
+		cancel()
+
+		status := grpc_health_v1.HealthCheckResponse_SERVING
+		if err != nil {
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+			healthy = false
+			if h.failures[backend] != err.Error() {
+				logger.Warnf("%s is not healthy: %v", backend, err)
+			}
+			h.failures[backend] = err.Error()
+		} else if _, ok := h.failures[backend]; ok {
+			logger.Infof("%s is healthy again", backend)
+			delete(h.failures, backend)
+		}
+		h.SetServingStatus(backend, status)
+	}
+
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if !healthy {
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	for _, serviceName := range h.serviceNames {
+		h.SetServingStatus(serviceName, status)
+	}
+}
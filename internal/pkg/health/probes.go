@@ -0,0 +1,252 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Probes serves the /livez, /readyz and /healthz HTTP endpoints for the kubelet probes and the load balancers. The
+// registry is live while the process serves the endpoints and ready while all the checks pass, the checks run
+// periodically so the probes don't load the backends.
+type Probes struct {
+	options *options
+
+	mu     sync.Mutex
+	probes map[string]*probe
+}
+
+// probe is the check of a component with its last failure, the components the registry waits for while it starts
+// have the dependency waited for and the start of the wait
+type probe struct {
+	check      Check
+	failure    string
+	dependency string
+	waiting    time.Time
+}
+
+// NewProbes creates Probes running the checks until ctx is done. More checks can be set while the registry starts.
+func NewProbes(ctx context.Context, opts ...Option) *Probes {
+	o := &options{
+		interval: 10 * time.Second,
+		timeout:  5 * time.Second,
+		checks:   make(map[string]Check),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	p := &Probes{
+		options: o,
+		probes:  make(map[string]*probe),
+	}
+	for component, check := range o.checks {
+		p.probes[component] = &probe{check: check}
+	}
+	go func() {
+		p.update(ctx)
+		ticker := time.NewTicker(o.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.update(ctx)
+				p.logWaiting(ctx)
+			}
+		}
+	}()
+	return p
+}
+
+// Set adds or replaces the check of the component and runs it right away
+func (p *Probes) Set(ctx context.Context, component string, check Check) {
+	p.set(ctx, component, &probe{check: check})
+}
+
+// Expect sets the component as not ready until the registry starts waiting for it
+func (p *Probes) Expect(ctx context.Context, component string) {
+	p.set(ctx, component, &probe{check: func(context.Context) error {
+		return errors.New("not started yet")
+	}})
+}
+
+// Pending sets the component as not ready while the registry waits for the dependency, e.g. "the X.509 SVID", until
+// its check is set. The wait is logged periodically and reported with its duration.
+func (p *Probes) Pending(ctx context.Context, component, dependency string) {
+	log.FromContext(ctx).Infof("waiting for %s", dependency)
+	p.set(ctx, component, &probe{
+		check: func(context.Context) error {
+			return errors.Errorf("waiting for %s", dependency)
+		},
+		dependency: dependency,
+		waiting:    time.Now(),
+	})
+}
+
+// Done sets the component as ready for good, e.g. once the listeners are bound
+func (p *Probes) Done(ctx context.Context, component string) {
+	p.Set(ctx, component, func(context.Context) error {
+		return nil
+	})
+}
+
+// Check returns Check reporting the last result of the check of the component run by p, so the other health reporters
+// share the result instead of running the check again
+func (p *Probes) Check(component string) Check {
+	return func(context.Context) error {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		pr, ok := p.probes[component]
+		switch {
+		case !ok:
+			return errors.Errorf("%s is not checked", component)
+		case !pr.waiting.IsZero():
+			return errors.Errorf("waiting for %s", pr.dependency)
+		case pr.failure != "":
+			return errors.New(pr.failure)
+		}
+		return nil
+	}
+}
+
+func (p *Probes) set(ctx context.Context, component string, pr *probe) {
+	p.mu.Lock()
+	previous := p.probes[component]
+	p.probes[component] = pr
+	p.mu.Unlock()
+	if previous != nil && !previous.waiting.IsZero() {
+		log.FromContext(ctx).Infof("waited %v for %s", time.Since(previous.waiting).Round(time.Millisecond), previous.dependency)
+	}
+	p.run(ctx, pr)
+}
+
+// logWaiting logs the dependencies the registry still waits for
+func (p *Probes) logWaiting(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, pr := range p.probes {
+		if !pr.waiting.IsZero() {
+			log.FromContext(ctx).Warnf("still waiting for %s for %v", pr.dependency, time.Since(pr.waiting).Round(time.Second))
+		}
+	}
+}
+
+// update runs all the checks
+func (p *Probes) update(ctx context.Context) {
+	p.mu.Lock()
+	probes := make([]*probe, 0, len(p.probes))
+	for _, pr := range p.probes {
+		probes = append(probes, pr)
+	}
+	p.mu.Unlock()
+
+	for _, pr := range probes {
+		p.run(ctx, pr)
+	}
+}
+
+// run runs the check and keeps its failure for the probes
+func (p *Probes) run(ctx context.Context, pr *probe) {
+	checkCtx, cancel := context.WithTimeout(ctx, p.options.timeout)
+	defer cancel()
+	var failure string
+	if err := pr.check(checkCtx); err != nil {
+		failure = err.Error()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pr.failure = failure
+}
+
+// ServeHTTP serves /livez always as ok, /readyz and /healthz as ok while all the checks pass and with 503 listing the
+// failed checks and the dependencies waited for with the duration of the wait otherwise, ?verbose lists the passed
+// checks as well
+func (p *Probes) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/livez" {
+		_, _ = fmt.Fprintln(w, "ok")
+		return
+	}
+
+	p.mu.Lock()
+	components := make([]string, 0, len(p.probes))
+	failures := make(map[string]string)
+	for component, pr := range p.probes {
+		components = append(components, component)
+		switch {
+		case !pr.waiting.IsZero():
+			failures[component] = fmt.Sprintf("waiting for %s for %v", pr.dependency, time.Since(pr.waiting).Round(time.Second))
+		case pr.failure != "":
+			failures[component] = "failed: " + pr.failure
+		}
+	}
+	p.mu.Unlock()
+	sort.Strings(components)
+
+	status := http.StatusOK
+	if len(failures) > 0 {
+		status = http.StatusServiceUnavailable
+	}
+	_, verbose := r.URL.Query()["verbose"]
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	for _, component := range components {
+		if failure, ok := failures[component]; ok {
+			_, _ = fmt.Fprintf(w, "[-]%s %s\n", component, failure)
+		} else if verbose {
+			_, _ = fmt.Fprintf(w, "[+]%s ok\n", component)
+		}
+	}
+	if status == http.StatusOK {
+		_, _ = fmt.Fprintln(w, "ok")
+	} else {
+		_, _ = fmt.Fprintln(w, "not ready")
+	}
+}
+
+// ListenAndServe serves the probes on listenOn until ctx is done
+func ListenAndServe(ctx context.Context, listenOn string, p *Probes) {
+	log.FromContext(ctx).Infof("health probes are enabled. Listening on %s", listenOn)
+	mux := http.NewServeMux()
+	mux.Handle("/livez", p)
+	mux.Handle("/readyz", p)
+	mux.Handle("/healthz", p)
+	server := &http.Server{
+		Addr:              listenOn,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.FromContext(ctx).Errorf("failed to serve the health probes: %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestProbesCheck(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := NewProbes(ctx)
+	p.Set(ctx, "failing", func(context.Context) error { return errors.New("failed") })
+	p.Done(ctx, "done")
+	p.Pending(ctx, "pending", "the dependency")
+
+	for _, tc := range []struct {
+		component string
+		want      string
+	}{
+		{component: "failing", want: "failed"},
+		{component: "done"},
+		{component: "pending", want: "waiting for the dependency"},
+		{component: "unknown", want: "unknown is not checked"},
+	} {
+		t.Run(tc.component, func(t *testing.T) {
+			var got string
+			if err := p.Check(tc.component)(ctx); err != nil {
+				got = err.Error()
+			}
+			if got != tc.want {
+				t.Errorf("check returned %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package identity resolves the workloads sending the registry requests from their SPIFFE IDs
+package identity
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+)
+
+// SpiffeIDFromContext returns the SPIFFE ID of the workload that originated the request, it is the subject of the
+// token in the first path segment. The token is not verified, it is expected to be authorized earlier in the chain.
+func SpiffeIDFromContext(ctx context.Context) (spiffeid.ID, bool) {
+	path := grpcmetadata.PathFromContext(ctx)
+	if len(path.PathSegments) == 0 {
+		return spiffeid.ID{}, false
+	}
+
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(path.PathSegments[0].Token, &claims); err != nil {
+		return spiffeid.ID{}, false
+	}
+	id, err := spiffeid.FromString(claims.Subject)
+	if err != nil {
+		return spiffeid.ID{}, false
+	}
+	return id, true
+}
+
+// PodFromContext returns the namespace and the name of the pod that originated the request, its SPIFFE ID is expected
+// to have the spiffe://<trust domain>/ns/<namespace>/pod/<name> format
+func PodFromContext(ctx context.Context) (namespace, name string, ok bool) {
+	id, ok := SpiffeIDFromContext(ctx)
+	if !ok {
+		return "", "", false
+	}
+	segments := strings.Split(strings.TrimPrefix(id.Path(), "/"), "/")
+	if len(segments) != 4 || segments[0] != "ns" || segments[2] != "pod" {
+		return "", "", false
+	}
+	return segments[1], segments[3], true
+}
+
+// PeerIDFromContext returns the SPIFFE ID of the X.509 SVID presented by the peer connected to the server, which may
+// be a proxy forwarding the request of another workload
+func PeerIDFromContext(ctx context.Context) (spiffeid.ID, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return spiffeid.ID{}, false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return spiffeid.ID{}, false
+	}
+	id, err := x509svid.IDFromCert(tlsInfo.State.PeerCertificates[0])
+	if err != nil {
+		return spiffeid.ID{}, false
+	}
+	return id, true
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inspect provides querying a running registry and printing the records for debugging
+package inspect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/admin"
+)
+
+// Output is the format the records are printed in
+type Output string
+
+const (
+	// Text prints the records as a table
+	Text Output = "text"
+	// JSON prints the records as JSON, one per line
+	JSON Output = "json"
+)
+
+// Decode takes a string output format and returns the Output
+func (o *Output) Decode(value string) error {
+	switch Output(strings.ToLower(value)) {
+	case Text:
+		*o = Text
+	case JSON:
+		*o = JSON
+	default:
+		return errors.Errorf("unknown output %s, expected text or json", value)
+	}
+	return nil
+}
+
+// entry is a record with its metadata from the admin service, the metadata is empty for the records found by Find
+type entry struct {
+	record        proto.Message
+	raw           *structpb.Struct
+	lastRefreshed string
+	identity      string
+}
+
+// NetworkServiceEndpoints prints the endpoints with the name of the network service, an empty name or service matches
+// all. The records are found by Find, or listed with their metadata by the admin service with withMetadata.
+func NetworkServiceEndpoints(ctx context.Context, cc grpc.ClientConnInterface, name, service string, withMetadata bool, w io.Writer, output Output) error {
+	var entries []*entry
+	if withMetadata {
+		listed, err := admin.NewClient(cc).ListNetworkServiceEndpoints(ctx)
+		if err != nil {
+			return err
+		}
+		for _, raw := range listed {
+			e, err := newEntry(raw, "networkServiceEndpoint", new(registry.NetworkServiceEndpoint))
+			if err != nil {
+				return err
+			}
+			nse := e.record.(*registry.NetworkServiceEndpoint)
+			if (name == "" || nse.GetName() == name) && (service == "" || contains(nse.GetNetworkServiceNames(), service)) {
+				entries = append(entries, e)
+			}
+		}
+	} else {
+		query := &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: name}}
+		if service != "" {
+			query.NetworkServiceEndpoint.NetworkServiceNames = []string{service}
+		}
+		stream, err := registry.NewNetworkServiceEndpointRegistryClient(cc).Find(ctx, query)
+		if err != nil {
+			return errors.Wrap(err, "failed to find the network service endpoints")
+		}
+		for {
+			resp, recvErr := stream.Recv()
+			if errors.Is(recvErr, io.EOF) {
+				break
+			}
+			if recvErr != nil {
+				return errors.Wrap(recvErr, "failed to receive a network service endpoint")
+			}
+			entries = append(entries, &entry{record: resp.GetNetworkServiceEndpoint()})
+		}
+	}
+
+	if output == JSON {
+		return printJSON(w, entries)
+	}
+	return printTable(w, withMetadata, []string{"NAME", "SERVICES", "URL", "EXPIRES"}, entries, func(record proto.Message) []string {
+		nse := record.(*registry.NetworkServiceEndpoint)
+		expires := "never"
+		if expirationTime := nse.GetExpirationTime(); expirationTime != nil {
+			expires = time.Until(expirationTime.AsTime()).Round(time.Second).String()
+		}
+		return []string{nse.GetName(), strings.Join(nse.GetNetworkServiceNames(), ","), nse.GetUrl(), expires}
+	})
+}
+
+// NetworkServices prints the network services with the name, an empty name matches all. The records are found by
+// Find, or listed with their metadata by the admin service with withMetadata.
+func NetworkServices(ctx context.Context, cc grpc.ClientConnInterface, name string, withMetadata bool, w io.Writer, output Output) error {
+	var entries []*entry
+	if withMetadata {
+		listed, err := admin.NewClient(cc).ListNetworkServices(ctx)
+		if err != nil {
+			return err
+		}
+		for _, raw := range listed {
+			e, err := newEntry(raw, "networkService", new(registry.NetworkService))
+			if err != nil {
+				return err
+			}
+			if name == "" || e.record.(*registry.NetworkService).GetName() == name {
+				entries = append(entries, e)
+			}
+		}
+	} else {
+		stream, err := registry.NewNetworkServiceRegistryClient(cc).Find(ctx, &registry.NetworkServiceQuery{
+			NetworkService: &registry.NetworkService{Name: name},
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to find the network services")
+		}
+		for {
+			resp, recvErr := stream.Recv()
+			if errors.Is(recvErr, io.EOF) {
+				break
+			}
+			if recvErr != nil {
+				return errors.Wrap(recvErr, "failed to receive a network service")
+			}
+			entries = append(entries, &entry{record: resp.GetNetworkService()})
+		}
+	}
+
+	if output == JSON {
+		return printJSON(w, entries)
+	}
+	return printTable(w, withMetadata, []string{"NAME", "PAYLOAD"}, entries, func(record proto.Message) []string {
+		ns := record.(*registry.NetworkService)
+		return []string{ns.GetName(), ns.GetPayload()}
+	})
+}
+
+// newEntry decodes the record under the key of the admin service entry into record
+func newEntry(raw *structpb.Struct, key string, record proto.Message) (*entry, error) {
+	data, err := json.Marshal(raw.GetFields()[key].AsInterface())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal a %s", key)
+	}
+	if err = protojson.Unmarshal(data, record); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal a %s", key)
+	}
+	return &entry{
+		record:        record,
+		raw:           raw,
+		lastRefreshed: raw.GetFields()["lastRefreshed"].GetStringValue(),
+		identity:      raw.GetFields()["identity"].GetStringValue(),
+	}, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// printJSON prints the records, or the entries of the admin service including the metadata, one per line
+func printJSON(w io.Writer, entries []*entry) error {
+	for _, e := range entries {
+		var message proto.Message = e.record
+		if e.raw != nil {
+			message = e.raw
+		}
+		data, err := protojson.Marshal(message)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal a record")
+		}
+		if _, err = fmt.Fprintln(w, string(data)); err != nil {
+			return errors.Wrap(err, "failed to print a record")
+		}
+	}
+	return nil
+}
+
+// printTable prints the columns of the records returned by row and the metadata columns with withMetadata
+func printTable(w io.Writer, withMetadata bool, header []string, entries []*entry, row func(record proto.Message) []string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	if withMetadata {
+		header = append(header, "LAST REFRESHED", "IDENTITY")
+	}
+	_, _ = fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, e := range entries {
+		columns := row(e.record)
+		if withMetadata {
+			columns = append(columns, orUnknown(e.lastRefreshed), orUnknown(e.identity))
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	}
+	return errors.Wrap(tw.Flush(), "failed to print the records")
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return "<unknown>"
+	}
+	return value
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package latency provides registry chain elements recording the duration of the requests and of their stages, so the
+// time spent in the policies, the rest of the chain and the storage can be told apart
+package latency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	nsKind  = "ns"
+	nseKind = "nse"
+
+	registerOp   = "register"
+	unregisterOp = "unregister"
+	findOp       = "find"
+)
+
+type options struct {
+	slowThreshold time.Duration
+}
+
+// Option is an option pattern for the latency chain elements
+type Option func(o *options)
+
+// WithSlowThreshold logs a warning with the timing breakdown of the requests taking longer than the threshold, 0
+// disables it
+func WithSlowThreshold(threshold time.Duration) Option {
+	return func(o *options) {
+		o.slowThreshold = threshold
+	}
+}
+
+type instruments struct {
+	requests metric.Float64Histogram
+	stages   metric.Float64Histogram
+	events   metric.Float64Histogram
+}
+
+func newInstruments() *instruments {
+	meter := otel.Meter("")
+	requests, _ := meter.Float64Histogram("registry_request_duration_seconds",
+		metric.WithDescription("duration of the register, unregister and non-watch find requests"), metric.WithUnit("s"))
+	stages, _ := meter.Float64Histogram("registry_request_stage_duration_seconds",
+		metric.WithDescription("duration from the start of the request until it passed the stage of the chain"), metric.WithUnit("s"))
+	events, _ := meter.Float64Histogram("registry_watch_event_delivery_seconds",
+		metric.WithDescription("duration of sending an event to a find watch"), metric.WithUnit("s"))
+	return &instruments{
+		requests: requests,
+		stages:   stages,
+		events:   events,
+	}
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
+
+func (i *instruments) request(ctx context.Context, kind, op string, start time.Time, err error) {
+	i.requests.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+		attribute.String("kind", kind), attribute.String("op", op), attribute.String("outcome", outcome(err))))
+}
+
+func (i *instruments) stage(ctx context.Context, kind, stage string) {
+	r, ok := ctx.Value(requestKey{}).(*request)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(r.start)
+	r.add(stage+".reached", elapsed)
+	i.stages.Record(ctx, elapsed.Seconds(), metric.WithAttributes(
+		attribute.String("kind", kind), attribute.String("op", r.op), attribute.String("stage", stage)))
+}
+
+func (i *instruments) event(ctx context.Context, kind string, start time.Time, err error) {
+	i.events.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+		attribute.String("kind", kind), attribute.String("outcome", outcome(err))))
+}
+
+type requestKey struct{}
+
+// request is the request being timed, the stages report the time elapsed since its start and the observed operations
+// their duration
+type request struct {
+	op    string
+	start time.Time
+
+	mu        sync.Mutex
+	breakdown []step
+}
+
+type step struct {
+	name     string
+	duration time.Duration
+}
+
+func (r *request) add(name string, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.breakdown = append(r.breakdown, step{name: name, duration: duration})
+}
+
+func withRequest(ctx context.Context, op string, start time.Time) context.Context {
+	return context.WithValue(ctx, requestKey{}, &request{op: op, start: start})
+}
+
+// Observe adds the duration of an operation done for the request, e.g. a write to the apiserver, to the timing
+// breakdown of the slow request log
+func Observe(ctx context.Context, name string, duration time.Duration) {
+	if r, ok := ctx.Value(requestKey{}).(*request); ok {
+		r.add(name+".took", duration)
+	}
+}
+
+// logSlow logs the request with its timing breakdown if it took longer than the threshold
+func logSlow(ctx context.Context, threshold time.Duration, kind string, err error) {
+	r, ok := ctx.Value(requestKey{}).(*request)
+	if !ok || threshold <= 0 {
+		return
+	}
+	duration := time.Since(r.start)
+	if duration <= threshold {
+		return
+	}
+
+	logger := log.FromContext(ctx).
+		WithField("kind", kind).
+		WithField("op", r.op).
+		WithField("duration", duration).
+		WithField("outcome", outcome(err))
+	r.mu.Lock()
+	for _, s := range r.breakdown {
+		logger = logger.WithField(s.name, s.duration)
+	}
+	r.mu.Unlock()
+	logger.Warnf("slow request took %v, more than %v", duration, threshold)
+}
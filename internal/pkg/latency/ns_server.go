@@ -0,0 +1,135 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package latency
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type latencyNSServer struct {
+	metrics       *instruments
+	slowThreshold time.Duration
+}
+
+// NewNetworkServiceRegistryServer creates a chain element recording the duration of the requests and of the
+// event deliveries to the watches, it is meant to be the first element of the chain
+func NewNetworkServiceRegistryServer(opts ...Option) registry.NetworkServiceRegistryServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &latencyNSServer{
+		metrics:       newInstruments(),
+		slowThreshold: o.slowThreshold,
+	}
+}
+
+func (s *latencyNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	start := time.Now()
+	ctx = withRequest(ctx, registerOp, start)
+	resp, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+	s.metrics.request(ctx, nsKind, registerOp, start, err)
+	logSlow(ctx, s.slowThreshold, nsKind, err)
+	return resp, err
+}
+
+func (s *latencyNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	if query.GetWatch() {
+		return next.NetworkServiceRegistryServer(server.Context()).Find(query, &watchNSServer{
+			NetworkServiceRegistry_FindServer: server,
+			metrics:                           s.metrics,
+		})
+	}
+	start := time.Now()
+	ctx := withRequest(server.Context(), findOp, start)
+	err := next.NetworkServiceRegistryServer(ctx).Find(query, &findNSServer{
+		NetworkServiceRegistry_FindServer: server,
+		ctx:                               ctx,
+	})
+	s.metrics.request(ctx, nsKind, findOp, start, err)
+	logSlow(ctx, s.slowThreshold, nsKind, err)
+	return err
+}
+
+func (s *latencyNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	start := time.Now()
+	ctx = withRequest(ctx, unregisterOp, start)
+	resp, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+	s.metrics.request(ctx, nsKind, unregisterOp, start, err)
+	logSlow(ctx, s.slowThreshold, nsKind, err)
+	return resp, err
+}
+
+type stageNSServer struct {
+	stage   string
+	metrics *instruments
+}
+
+// NewNetworkServiceStageServer creates a chain element recording the time from the start of the request until
+// it reached the element as the stage
+func NewNetworkServiceStageServer(stage string) registry.NetworkServiceRegistryServer {
+	return &stageNSServer{
+		stage:   stage,
+		metrics: newInstruments(),
+	}
+}
+
+func (s *stageNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	s.metrics.stage(ctx, nsKind, s.stage)
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *stageNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	s.metrics.stage(server.Context(), nsKind, s.stage)
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *stageNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	s.metrics.stage(ctx, nsKind, s.stage)
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
+
+// findNSServer passes the timed request to the rest of the chain
+type findNSServer struct {
+	registry.NetworkServiceRegistry_FindServer
+	ctx context.Context
+}
+
+func (s *findNSServer) Context() context.Context {
+	return s.ctx
+}
+
+// watchNSServer records the duration of sending the events to the watch
+type watchNSServer struct {
+	registry.NetworkServiceRegistry_FindServer
+	metrics *instruments
+}
+
+func (s *watchNSServer) Send(resp *registry.NetworkServiceResponse) error {
+	start := time.Now()
+	err := s.NetworkServiceRegistry_FindServer.Send(resp)
+	s.metrics.event(s.Context(), nsKind, start, err)
+	return err
+}
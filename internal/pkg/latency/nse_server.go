@@ -0,0 +1,135 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package latency
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type latencyNSEServer struct {
+	metrics       *instruments
+	slowThreshold time.Duration
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a chain element recording the duration of the requests and of the
+// event deliveries to the watches, it is meant to be the first element of the chain
+func NewNetworkServiceEndpointRegistryServer(opts ...Option) registry.NetworkServiceEndpointRegistryServer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &latencyNSEServer{
+		metrics:       newInstruments(),
+		slowThreshold: o.slowThreshold,
+	}
+}
+
+func (s *latencyNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	start := time.Now()
+	ctx = withRequest(ctx, registerOp, start)
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	s.metrics.request(ctx, nseKind, registerOp, start, err)
+	logSlow(ctx, s.slowThreshold, nseKind, err)
+	return resp, err
+}
+
+func (s *latencyNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if query.GetWatch() {
+		return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, &watchNSEServer{
+			NetworkServiceEndpointRegistry_FindServer: server,
+			metrics: s.metrics,
+		})
+	}
+	start := time.Now()
+	ctx := withRequest(server.Context(), findOp, start)
+	err := next.NetworkServiceEndpointRegistryServer(ctx).Find(query, &findNSEServer{
+		NetworkServiceEndpointRegistry_FindServer: server,
+		ctx: ctx,
+	})
+	s.metrics.request(ctx, nseKind, findOp, start, err)
+	logSlow(ctx, s.slowThreshold, nseKind, err)
+	return err
+}
+
+func (s *latencyNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	start := time.Now()
+	ctx = withRequest(ctx, unregisterOp, start)
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	s.metrics.request(ctx, nseKind, unregisterOp, start, err)
+	logSlow(ctx, s.slowThreshold, nseKind, err)
+	return resp, err
+}
+
+type stageNSEServer struct {
+	stage   string
+	metrics *instruments
+}
+
+// NewNetworkServiceEndpointStageServer creates a chain element recording the time from the start of the request until
+// it reached the element as the stage
+func NewNetworkServiceEndpointStageServer(stage string) registry.NetworkServiceEndpointRegistryServer {
+	return &stageNSEServer{
+		stage:   stage,
+		metrics: newInstruments(),
+	}
+}
+
+func (s *stageNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	s.metrics.stage(ctx, nseKind, s.stage)
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *stageNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	s.metrics.stage(server.Context(), nseKind, s.stage)
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *stageNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	s.metrics.stage(ctx, nseKind, s.stage)
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+// findNSEServer passes the timed request to the rest of the chain
+type findNSEServer struct {
+	registry.NetworkServiceEndpointRegistry_FindServer
+	ctx context.Context
+}
+
+func (s *findNSEServer) Context() context.Context {
+	return s.ctx
+}
+
+// watchNSEServer records the duration of sending the events to the watch
+type watchNSEServer struct {
+	registry.NetworkServiceEndpointRegistry_FindServer
+	metrics *instruments
+}
+
+func (s *watchNSEServer) Send(resp *registry.NetworkServiceEndpointResponse) error {
+	start := time.Now()
+	err := s.NetworkServiceEndpointRegistry_FindServer.Send(resp)
+	s.metrics.event(s.Context(), nseKind, start, err)
+	return err
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package leader provides Lease based leader election letting registry replicas run in active-passive mode
+package leader
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type options struct {
+	leaseName     string
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+}
+
+// Option is an option pattern for the Elector
+type Option func(o *options)
+
+// WithLeaseName sets the name of the Lease the replicas compete for
+func WithLeaseName(leaseName string) Option {
+	return func(o *options) {
+		o.leaseName = leaseName
+	}
+}
+
+// WithLeaseDuration sets how long the followers wait before taking over a Lease that is not renewed
+func WithLeaseDuration(leaseDuration time.Duration) Option {
+	return func(o *options) {
+		o.leaseDuration = leaseDuration
+	}
+}
+
+// WithRenewDeadline sets how long the leader keeps retrying to renew the Lease before giving up the leadership
+func WithRenewDeadline(renewDeadline time.Duration) Option {
+	return func(o *options) {
+		o.renewDeadline = renewDeadline
+	}
+}
+
+// WithRetryPeriod sets the interval between the attempts to acquire or renew the Lease
+func WithRetryPeriod(retryPeriod time.Duration) Option {
+	return func(o *options) {
+		o.retryPeriod = retryPeriod
+	}
+}
+
+// Elector tracks whether this replica is the leader
+type Elector struct {
+	leader atomic.Bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu sync.Mutex
+	// cancelRound ends the current campaign round of a follower, so it campaigns again right away
+	cancelRound context.CancelFunc
+}
+
+// NewElector creates Elector campaigning for the Lease in the namespace as identity until ctx is done or Resign is
+// called. The leadership is released on ctx cancellation so a follower can take over without waiting for the Lease to
+// expire. The followers watch the Lease and campaign as soon as it is released instead of on the next retry.
+func NewElector(ctx context.Context, client kubernetes.Interface, namespace, identity string, opts ...Option) (*Elector, error) {
+	o := &options{
+		leaseName:     "registry-k8s",
+		leaseDuration: 15 * time.Second,
+		renewDeadline: 10 * time.Second,
+		retryPeriod:   2 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	e := &Elector{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	logger := log.FromContext(ctx).WithField("leader", o.leaseName)
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta: metav1.ObjectMeta{
+				Name:      o.leaseName,
+				Namespace: namespace,
+			},
+			Client: client.CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{
+				Identity: identity,
+			},
+		},
+		LeaseDuration:   o.leaseDuration,
+		RenewDeadline:   o.renewDeadline,
+		RetryPeriod:     o.retryPeriod,
+		ReleaseOnCancel: true,
+		Name:            o.leaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {
+				e.leader.Store(true)
+				logger.Infof("%s started leading", identity)
+			},
+			OnStoppedLeading: func() {
+				e.leader.Store(false)
+				logger.Infof("%s stopped leading", identity)
+			},
+			OnNewLeader: func(leader string) {
+				logger.Infof("%s is the leader", leader)
+			},
+		},
+	})
+	if err != nil {
+		cancel()
+		return nil, errors.Wrap(err, "failed to create leader elector")
+	}
+
+	if err := e.watchReleases(ctx, client, namespace, o.leaseName, elector); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		defer close(e.done)
+		// Run returns when the leadership is lost or the round is ended, keep campaigning for it
+		for ctx.Err() == nil {
+			roundCtx, cancelRound := context.WithCancel(ctx)
+			e.mu.Lock()
+			e.cancelRound = cancelRound
+			e.mu.Unlock()
+
+			elector.Run(roundCtx)
+			cancelRound()
+		}
+	}()
+
+	return e, nil
+}
+
+// watchReleases ends the campaign round of a follower once the Lease is released, so it acquires the Lease right away
+func (e *Elector) watchReleases(ctx context.Context, client kubernetes.Interface, namespace, leaseName string, elector *leaderelection.LeaderElector) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(client, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", leaseName).String()
+		}))
+	if _, err := factory.Coordination().V1().Leases().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, obj interface{}) {
+			lease, ok := obj.(*coordinationv1.Lease)
+			if !ok || lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != "" || elector.IsLeader() {
+				return
+			}
+			e.mu.Lock()
+			defer e.mu.Unlock()
+			if e.cancelRound != nil {
+				e.cancelRound()
+			}
+		},
+	}); err != nil {
+		return errors.Wrap(err, "failed to add Lease event handler")
+	}
+	factory.Start(ctx.Done())
+	return nil
+}
+
+// Resign stops campaigning and releases the Lease if this replica is the leader, so a follower takes over right away.
+// Resign returns once the Lease is released.
+func (e *Elector) Resign() {
+	e.cancel()
+	<-e.done
+}
+
+// IsLeader returns true if this replica currently holds the Lease
+func (e *Elector) IsLeader() bool {
+	return e.leader.Load()
+}
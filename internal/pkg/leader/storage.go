@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leader
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+// NewStorage creates storage.Storage rejecting the writes with codes.Unavailable unless the elector is the leader, so
+// the clients retry them until they reach the leader. Find queries are served by every replica.
+func NewStorage(s storage.Storage, e *Elector) storage.Storage {
+	return storage.New(
+		&leaderNSServer{
+			storage: s.NetworkServiceRegistryServer(),
+			elector: e,
+		},
+		&leaderNSEServer{
+			storage: s.NetworkServiceEndpointRegistryServer(),
+			elector: e,
+		},
+	)
+}
+
+func notLeader() error {
+	return status.Error(codes.Unavailable, "registry is not the leader")
+}
+
+type leaderNSServer struct {
+	storage registry.NetworkServiceRegistryServer
+	elector *Elector
+}
+
+func (s *leaderNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	if !s.elector.IsLeader() {
+		return nil, notLeader()
+	}
+	return s.storage.Register(ctx, ns)
+}
+
+func (s *leaderNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *leaderNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	if !s.elector.IsLeader() {
+		return nil, notLeader()
+	}
+	return s.storage.Unregister(ctx, ns)
+}
+
+type leaderNSEServer struct {
+	storage registry.NetworkServiceEndpointRegistryServer
+	elector *Elector
+}
+
+func (s *leaderNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if !s.elector.IsLeader() {
+		return nil, notLeader()
+	}
+	return s.storage.Register(ctx, nse)
+}
+
+func (s *leaderNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *leaderNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	if !s.elector.IsLeader() {
+		return nil, notLeader()
+	}
+	return s.storage.Unregister(ctx, nse)
+}
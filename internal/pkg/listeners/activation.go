@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listeners
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// listenFDsStart is the first file descriptor passed by systemd socket activation
+const listenFDsStart = 3
+
+// Bound is a bound listener with its transport security
+type Bound struct {
+	Listener net.Listener
+	Security Security
+}
+
+// Activated returns the listeners passed by systemd socket activation following sd_listen_fds(3) or handed off by
+// the replaced registry process. The names set with FileDescriptorName= select the security of the listeners, the
+// listeners without a known security name require mTLS. The names with the +proxy suffix, e.g. tls+proxy, read the
+// PROXY protocol header. The activation environment variables are unset, so they are not inherited by the child
+// processes. The returned flag is set if the listeners are handed off, they replace the configured listeners then.
+// Only the PROXY protocol options apply to the activated listeners.
+func Activated(opts ...Option) (bound []Bound, handedOff bool, err error) {
+	defer func() {
+		_ = os.Unsetenv("LISTEN_PID")
+		_ = os.Unsetenv("LISTEN_FDS")
+		_ = os.Unsetenv("LISTEN_FDNAMES")
+		_ = os.Unsetenv(handoffEnv)
+	}()
+
+	activated, handedOff := activatedBy()
+	if !activated {
+		return nil, false, nil
+	}
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "failed to parse LISTEN_FDS")
+	}
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+
+	bound = make([]Bound, 0, count)
+	for i := 0; i < count; i++ {
+		name := "LISTEN_FD_" + strconv.Itoa(listenFDsStart+i)
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+		file := os.NewFile(uintptr(listenFDsStart+i), name)
+		// FileListener duplicates the file descriptor, so the original one is closed either way
+		ln, lnErr := net.FileListener(file)
+		_ = file.Close()
+		if lnErr != nil {
+			return nil, false, errors.Wrapf(lnErr, "failed to use the activated socket %s", name)
+		}
+		securityName, proxyProtocol := strings.CutSuffix(name, proxyProtocolSuffix)
+		if proxyProtocol {
+			ln = WithProxyProtocol(ln, newOptions(opts...).proxyTrusted)
+		}
+		security := MTLS
+		if s := Security(securityName); s == TLS || s == Insecure {
+			security = s
+		}
+		bound = append(bound, Bound{Listener: ln, Security: security})
+	}
+	return bound, handedOff, nil
+}
+
+// activatedBy checks the sockets are passed to the current process, either by systemd or by the parent process
+// handing off its listeners
+func activatedBy() (activated, handedOff bool) {
+	if pid, err := strconv.Atoi(os.Getenv("LISTEN_PID")); err == nil && pid == os.Getpid() {
+		return true, false
+	}
+	if ppid, err := strconv.Atoi(os.Getenv(handoffEnv)); err == nil && ppid == os.Getppid() {
+		return true, true
+	}
+	return false, false
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listeners
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// IPFamily is the IP family of the tcp listeners
+type IPFamily string
+
+const (
+	// DualStack listens on IPv4 and IPv6, the unspecified addresses are bound to a single dual-stack socket
+	DualStack IPFamily = "dual"
+	// IPv4 listens only on IPv4, the hosts are resolved to IPv4 addresses
+	IPv4 IPFamily = "ipv4"
+	// IPv6 listens only on IPv6, the hosts are resolved to IPv6 addresses and the unspecified IPv4 address is bound
+	// as the unspecified IPv6 one, so tcp://0.0.0.0 urls work on IPv6-only clusters
+	IPv6 IPFamily = "ipv6"
+)
+
+// Decode implements envconfig.Decoder
+func (f *IPFamily) Decode(value string) error {
+	switch family := IPFamily(value); family {
+	case DualStack, IPv4, IPv6:
+		*f = family
+		return nil
+	default:
+		return errors.Errorf("unknown IP family %s, expected dual, ipv4 or ipv6", value)
+	}
+}
+
+func (f IPFamily) network() string {
+	switch f {
+	case IPv4:
+		return "tcp4"
+	case IPv6:
+		return "tcp6"
+	default:
+		return "tcp"
+	}
+}
+
+// address returns the address to bind for the host:port, the unspecified host is replaced by the unspecified address
+// of the family
+func (f IPFamily) address(hostport string) (string, error) {
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid listen address %s", hostport)
+	}
+	if ip := net.ParseIP(host); host != "" && (ip == nil || !ip.IsUnspecified()) {
+		return hostport, nil
+	}
+	switch f {
+	case IPv4:
+		host = net.IPv4zero.String()
+	case IPv6:
+		host = net.IPv6unspecified.String()
+	default:
+		host = ""
+	}
+	return net.JoinHostPort(host, port), nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listeners
+
+import (
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// handoffEnv passes the pid of the process handing off its listeners to the replacement
+	handoffEnv = "NSM_LISTEN_FDS_PPID"
+	// proxyProtocolSuffix marks the names of the listeners reading the PROXY protocol header
+	proxyProtocolSuffix = "+proxy"
+)
+
+// Handoff starts a replacement of the current process with the same arguments, it inherits the listeners as if they
+// were passed by systemd socket activation. The connections are queued on the shared sockets until the replacement
+// accepts them, so the current process can stop serving as soon as Handoff returns. The replacement is a child of the
+// current process, it has to be run by a supervisor outliving it, e.g. systemd or a process manager in the container.
+// Handoff fails in the process running as PID 1, whose exit would kill the replacement.
+func Handoff(bound []Bound) (*os.Process, error) {
+	if os.Getpid() == 1 {
+		return nil, errors.New("the replacement would be killed with the process running as PID 1, run it under a supervisor")
+	}
+	files := make([]*os.File, 0, len(bound))
+	defer func() {
+		for _, file := range files {
+			_ = file.Close()
+		}
+	}()
+	names := make([]string, 0, len(bound))
+	for _, b := range bound {
+		ln, name := b.Listener, string(b.Security)
+		if proxy, ok := ln.(*proxyListener); ok {
+			ln, name = proxy.Listener, name+proxyProtocolSuffix
+		}
+		file, err := listenerFile(ln)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+		names = append(names, name)
+	}
+
+	// #nosec G204 the replacement runs the binary the registry was started with
+	cmd := exec.Command(os.Args[0], os.Args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = files
+	cmd.Env = append(os.Environ(),
+		"LISTEN_FDS="+strconv.Itoa(len(files)),
+		"LISTEN_FDNAMES="+strings.Join(names, ":"),
+		handoffEnv+"="+strconv.Itoa(os.Getpid()))
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrap(err, "failed to start the replacement process")
+	}
+	return cmd.Process, nil
+}
+
+// listenerFile returns a duplicate of the listener file descriptor. The unix listeners stop removing their socket
+// files on close, so the socket is kept for the replacement.
+func listenerFile(ln net.Listener) (*os.File, error) {
+	switch l := ln.(type) {
+	case *net.TCPListener:
+		file, err := l.File()
+		return file, errors.Wrapf(err, "failed to get the file of the listener %s", ln.Addr())
+	case *net.UnixListener:
+		l.SetUnlinkOnClose(false)
+		file, err := l.File()
+		return file, errors.Wrapf(err, "failed to get the file of the listener %s", ln.Addr())
+	default:
+		return nil, errors.Errorf("the listener %s can not be handed off", ln.Addr())
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package listeners
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMain runs the test binary started by Handoff as the replacement: it answers the first connection accepted on
+// each handed off listener with the security of the listener and exits
+func TestMain(m *testing.M) {
+	if os.Getenv(handoffEnv) == "" {
+		os.Exit(m.Run())
+	}
+	bound, handedOff, err := Activated()
+	if err != nil || !handedOff {
+		fmt.Fprintf(os.Stderr, "the listeners are not handed off: %v\n", err)
+		os.Exit(1)
+	}
+	for _, b := range bound {
+		conn, err := b.Listener.Accept()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to accept a connection: %v\n", err)
+			os.Exit(1)
+		}
+		_, _ = fmt.Fprintln(conn, b.Security)
+		_ = conn.Close()
+		_ = b.Listener.Close()
+	}
+	os.Exit(0)
+}
+
+func TestHandoffPassesTheListenersToTheChild(t *testing.T) {
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	unix, err := net.Listen("unix", t.TempDir()+"/registry.sock")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	process, err := Handoff([]Bound{{Listener: tcp, Security: TLS}, {Listener: unix, Security: Insecure}})
+	if err != nil {
+		t.Fatalf("handoff failed: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		state, waitErr := process.Wait()
+		if waitErr == nil && !state.Success() {
+			waitErr = fmt.Errorf("the child exited with %v", state)
+		}
+		done <- waitErr
+	}()
+
+	// The parent stops serving right away, the connections are accepted by the child
+	_ = tcp.Close()
+	_ = unix.Close()
+	for _, tc := range []struct {
+		ln   net.Listener
+		want Security
+	}{
+		{ln: tcp, want: TLS},
+		{ln: unix, want: Insecure},
+	} {
+		conn, err := net.DialTimeout(tc.ln.Addr().Network(), tc.ln.Addr().String(), 5*time.Second)
+		if err != nil {
+			t.Fatalf("failed to dial the handed off listener %s: %v", tc.ln.Addr(), err)
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		_ = conn.Close()
+		if err != nil {
+			t.Fatalf("failed to read from the child on %s: %v", tc.ln.Addr(), err)
+		}
+		if got := Security(strings.TrimSpace(line)); got != tc.want {
+			t.Errorf("the child serves %s on %s, want %s", got, tc.ln.Addr(), tc.want)
+		}
+	}
+
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(10 * time.Second):
+		_ = process.Kill()
+		t.Error("the child did not exit")
+	}
+}
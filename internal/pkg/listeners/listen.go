@@ -0,0 +1,187 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listeners
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	unixScheme         = "unix"
+	unixAbstractScheme = "unix-abstract"
+
+	probeTimeout = time.Second
+)
+
+type options struct {
+	reusePort    bool
+	family       IPFamily
+	backoff      wait.Backoff
+	proxyTrusted CIDRs
+}
+
+// Option is an option pattern for Listen
+type Option func(o *options)
+
+// WithReusePort binds the tcp sockets with SO_REUSEPORT, so a replacement process can bind the same port
+func WithReusePort(reusePort bool) Option {
+	return func(o *options) {
+		o.reusePort = reusePort
+	}
+}
+
+// WithIPFamily sets the IP family of the tcp sockets
+func WithIPFamily(family IPFamily) Option {
+	return func(o *options) {
+		o.family = family
+	}
+}
+
+// WithProxyProtocolTrustedCIDRs sets the networks of the load balancers trusted to send the PROXY protocol header, the
+// connections from other peers are rejected by the listeners reading it. An empty list trusts every peer.
+func WithProxyProtocolTrustedCIDRs(trusted CIDRs) Option {
+	return func(o *options) {
+		o.proxyTrusted = trusted
+	}
+}
+
+// WithRetry sets how many times and with what initial backoff the binds failing with a transient error, e.g. the
+// address in use, are attempted, the backoff doubles with each retry up to 5 seconds
+func WithRetry(steps int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.backoff = wait.Backoff{
+			Steps:    steps,
+			Duration: backoff,
+			Factor:   2,
+			Jitter:   0.1,
+			Cap:      maxRetryBackoff,
+		}
+	}
+}
+
+// Listen listens on the url, unix:// and unix-abstract:// urls are served on unix sockets, other urls on tcp. The
+// address a tcp listener is bound to is written back to the url, so it reports the actual port and the family of the
+// unspecified host.
+func Listen(ctx context.Context, u *url.URL, opts ...Option) (net.Listener, error) {
+	o := newOptions(opts...)
+	return retry(ctx, u, o.backoff, func() (net.Listener, error) {
+		return listen(ctx, u, o)
+	})
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		family:  DualStack,
+		backoff: wait.Backoff{Steps: 1},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func listen(ctx context.Context, u *url.URL, o *options) (net.Listener, error) {
+	switch u.Scheme {
+	case unixScheme:
+		return listenUnix(ctx, socketPath(u))
+	case unixAbstractScheme:
+		// a leading @ selects the Linux abstract namespace
+		ln, err := net.Listen(unixScheme, "@"+socketName(u))
+		return ln, errors.Wrapf(err, "failed to listen on %s", u.String())
+	default:
+		address, err := o.family.address(u.Host)
+		if err != nil {
+			return nil, err
+		}
+		var lc net.ListenConfig
+		if o.reusePort {
+			lc.Control = setReusePort
+		}
+		ln, err := lc.Listen(ctx, o.family.network(), address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to listen on %s", u.String())
+		}
+		u.Host = ln.Addr().String()
+		return ln, nil
+	}
+}
+
+// Listen listens on the url of the listener, reading the PROXY protocol header of the connections if it is enabled
+func (l *Listener) Listen(ctx context.Context, opts ...Option) (Bound, error) {
+	ln, err := Listen(ctx, &l.URL, opts...)
+	if err != nil {
+		return Bound{}, err
+	}
+	if l.ProxyProtocol {
+		ln = WithProxyProtocol(ln, newOptions(opts...).proxyTrusted)
+	}
+	return Bound{Listener: ln, Security: l.Security}, nil
+}
+
+// listenUnix listens on the socket file removing it first if it is stale, that is no process accepts connections on
+// it anymore after an unclean shutdown. The socket of a live process is never removed.
+func listenUnix(ctx context.Context, path string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "failed to create the directory of %s", path)
+	}
+	if _, err := os.Stat(path); err == nil {
+		conn, dialErr := net.DialTimeout(unixScheme, path, probeTimeout)
+		if dialErr == nil {
+			_ = conn.Close()
+			return nil, errors.Wrapf(syscall.EADDRINUSE, "%s is in use by another process", path)
+		}
+		log.FromContext(ctx).Infof("removing the stale socket %s: %v", path, dialErr)
+		if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "failed to remove the stale socket %s", path)
+		}
+	}
+	ln, err := net.Listen(unixScheme, path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to listen on %s", path)
+	}
+	// #nosec G302 the socket is shared with the workloads running as other users
+	if err = os.Chmod(path, os.ModePerm); err != nil {
+		_ = ln.Close()
+		return nil, errors.Wrapf(err, "failed to change the mode of %s", path)
+	}
+	return ln, nil
+}
+
+func socketPath(u *url.URL) string {
+	if u.Path != "" {
+		return u.Path
+	}
+	return u.Opaque
+}
+
+func socketName(u *url.URL) string {
+	if u.Opaque != "" {
+		return u.Opaque
+	}
+	return u.Host + u.Path
+}
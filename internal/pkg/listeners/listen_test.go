@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listeners
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+)
+
+func TestSocketPathAndName(t *testing.T) {
+	for _, tc := range []struct {
+		url  string
+		want string
+	}{
+		{url: "unix:///var/run/registry.sock", want: "/var/run/registry.sock"},
+		{url: "unix:registry.sock", want: "registry.sock"},
+		{url: "unix-abstract://registry", want: "registry"},
+		{url: "unix-abstract:registry", want: "registry"},
+	} {
+		t.Run(tc.url, func(t *testing.T) {
+			u, err := url.Parse(tc.url)
+			if err != nil {
+				t.Fatalf("failed to parse the url: %v", err)
+			}
+			got := socketPath(u)
+			if u.Scheme == unixAbstractScheme {
+				got = socketName(u)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestListenUnixRemovesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.sock")
+	stale, err := net.Listen(unixScheme, path)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	// an unclean shutdown leaves the socket file behind
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = stale.Close()
+	if _, err = os.Stat(path); err != nil {
+		t.Fatalf("the stale socket is not left behind: %v", err)
+	}
+
+	ln, err := listenUnix(context.Background(), path)
+	if err != nil {
+		t.Fatalf("listen on the stale socket failed: %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+}
+
+func TestListenUnixKeepsLiveSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.sock")
+	live, err := listenUnix(context.Background(), path)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer func() { _ = live.Close() }()
+
+	if ln, err := listenUnix(context.Background(), path); !errors.Is(err, syscall.EADDRINUSE) {
+		if ln != nil {
+			_ = ln.Close()
+		}
+		t.Fatalf("listen on the live socket returned %v, want EADDRINUSE", err)
+	}
+	if conn, err := net.Dial(unixScheme, path); err != nil {
+		t.Errorf("the live socket is removed: %v", err)
+	} else {
+		_ = conn.Close()
+	}
+}
+
+func TestListenUnixCreatesDirectoryAndSharesSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "registry.sock")
+	ln, err := listenUnix(context.Background(), path)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat the socket: %v", err)
+	}
+	if mode := info.Mode().Perm(); mode != os.ModePerm {
+		t.Errorf("the socket mode is %v, want %v", mode, os.ModePerm)
+	}
+}
+
+func TestListenWritesBackTheBoundAddress(t *testing.T) {
+	u := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	ln, err := Listen(context.Background(), u)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+
+	if u.Host != ln.Addr().String() || u.Port() == "0" {
+		t.Errorf("the url has %s, want the bound address %s", u.Host, ln.Addr())
+	}
+}
+
+func TestListenAbstractUnixSocket(t *testing.T) {
+	u := &url.URL{Scheme: unixAbstractScheme, Opaque: "registry-test-" + strconv.Itoa(os.Getpid())}
+	ln, err := Listen(context.Background(), u)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+
+	conn, err := net.Dial(unixScheme, "@"+u.Opaque)
+	if err != nil {
+		t.Fatalf("failed to dial the abstract socket: %v", err)
+	}
+	_ = conn.Close()
+}
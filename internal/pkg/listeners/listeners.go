@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package listeners provides the configuration of the registry listeners with their own transport security
+package listeners
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// Security is the transport security of a listener
+type Security string
+
+const (
+	// MTLS requires the clients to present X.509 SVIDs, it is the security of the NSM_LISTEN_ON listeners
+	MTLS Security = "mtls"
+	// TLS serves the X.509 SVID without verifying the clients
+	TLS Security = "tls"
+	// Insecure serves plaintext, it is intended for the local unix sockets used by sidecars
+	Insecure Security = "none"
+)
+
+// Listener is a URL to listen on with its transport security
+type Listener struct {
+	URL           url.URL
+	Security      Security
+	ProxyProtocol bool
+}
+
+// Listeners is a list of listeners decoded by envconfig from JSON, e.g.
+// [{"url":"tcp://:5002","tls":"mtls","proxyProtocol":true},{"url":"unix:///run/registry.sock","tls":"none"}]
+type Listeners []Listener
+
+// FromURLs returns the listeners of the urls with the same security
+func FromURLs(urls []url.URL, security Security) Listeners {
+	result := make(Listeners, 0, len(urls))
+	for i := range urls {
+		result = append(result, Listener{URL: urls[i], Security: security})
+	}
+	return result
+}
+
+// Plaintext returns the listeners with the tcp ones serving h2c without transport security
+func (l Listeners) Plaintext() Listeners {
+	result := make(Listeners, 0, len(l))
+	for _, listener := range l {
+		if listener.URL.Scheme != unixScheme && listener.URL.Scheme != unixAbstractScheme {
+			listener.Security = Insecure
+		}
+		result = append(result, listener)
+	}
+	return result
+}
+
+// Decode implements envconfig.Decoder
+func (l *Listeners) Decode(value string) error {
+	var entries []struct {
+		URL           string `json:"url"`
+		TLS           string `json:"tls"`
+		ProxyProtocol bool   `json:"proxyProtocol"`
+	}
+	if err := json.Unmarshal([]byte(value), &entries); err != nil {
+		return errors.Wrap(err, "failed to decode the listeners")
+	}
+	result := make(Listeners, 0, len(entries))
+	for _, entry := range entries {
+		u, err := url.Parse(entry.URL)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse the listener url %s", entry.URL)
+		}
+		security := Security(entry.TLS)
+		switch security {
+		case "":
+			security = MTLS
+		case MTLS, TLS, Insecure:
+		default:
+			return errors.Errorf("unknown security %s of the listener %s, expected mtls, tls or none", entry.TLS, entry.URL)
+		}
+		if entry.ProxyProtocol && (u.Scheme == unixScheme || u.Scheme == unixAbstractScheme) {
+			return errors.Errorf("the PROXY protocol is supported only on tcp listeners, not on %s", entry.URL)
+		}
+		result = append(result, Listener{URL: *u, Security: security, ProxyProtocol: entry.ProxyProtocol})
+	}
+	*l = result
+	return nil
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listeners
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	proxyHeaderTimeout = 5 * time.Second
+	proxyV1MaxLength   = 107
+	proxyV2MaxLength   = 4096
+
+	proxyV2Local      = 0x0
+	proxyV2FamilyTCP4 = 0x11
+	proxyV2FamilyTCP6 = 0x21
+)
+
+var proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+// CIDRs is a list of IP networks decoded by envconfig from the comma separated CIDR notation, e.g. 10.0.0.0/8,fd00::/8
+type CIDRs []*net.IPNet
+
+// Decode implements envconfig.Decoder
+func (c *CIDRs) Decode(value string) error {
+	var result CIDRs
+	for _, cidr := range strings.Split(value, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse the CIDR %s", cidr)
+		}
+		result = append(result, ipNet)
+	}
+	*c = result
+	return nil
+}
+
+// trusts returns true if the list is empty or one of the networks contains the IP address of addr
+func (c CIDRs) trusts(addr net.Addr) bool {
+	if len(c) == 0 {
+		return true
+	}
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	for _, ipNet := range c {
+		if ipNet.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithProxyProtocol wraps the listener to read the PROXY protocol v1 or v2 header sent by a load balancer in front of
+// the registry, the connections report the source address from the header as their remote address. The connections
+// without the header and the ones from the peers out of the trusted networks are rejected, an empty list trusts every
+// peer.
+func WithProxyProtocol(ln net.Listener, trusted CIDRs) net.Listener {
+	return &proxyListener{
+		Listener:      ln,
+		trusted:       trusted,
+		headerTimeout: proxyHeaderTimeout,
+	}
+}
+
+type proxyListener struct {
+	net.Listener
+	trusted       CIDRs
+	headerTimeout time.Duration
+}
+
+func (l *proxyListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &proxyConn{
+		Conn:     conn,
+		reader:   bufio.NewReader(conn),
+		listener: l,
+	}, nil
+}
+
+// proxyConn reads the header on the first use instead of Accept, so a slow client can not block accepting others
+type proxyConn struct {
+	net.Conn
+	reader   *bufio.Reader
+	listener *proxyListener
+
+	once   sync.Once
+	source net.Addr
+	err    error
+}
+
+func (c *proxyConn) readHeader() {
+	c.once.Do(func() {
+		if !c.listener.trusted.trusts(c.Conn.RemoteAddr()) {
+			c.err = errors.Errorf("the PROXY protocol header from the untrusted peer %s is rejected", c.Conn.RemoteAddr())
+			return
+		}
+		_ = c.Conn.SetReadDeadline(time.Now().Add(c.listener.headerTimeout))
+		c.source, c.err = readProxyHeader(c.reader)
+		_ = c.Conn.SetReadDeadline(time.Time{})
+	})
+}
+
+func (c *proxyConn) Read(b []byte) (int, error) {
+	c.readHeader()
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.reader.Read(b)
+}
+
+func (c *proxyConn) RemoteAddr() net.Addr {
+	c.readHeader()
+	if c.source != nil {
+		return c.source
+	}
+	return c.Conn.RemoteAddr()
+}
+
+// readProxyHeader returns the source address from the header or nil if the header carries no address
+func readProxyHeader(r *bufio.Reader) (net.Addr, error) {
+	signature, err := r.Peek(len(proxyV2Signature))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the PROXY protocol header")
+	}
+	switch {
+	case bytes.Equal(signature, proxyV2Signature):
+		return readProxyV2Header(r)
+	case bytes.HasPrefix(signature, []byte("PROXY ")):
+		return readProxyV1Header(r)
+	default:
+		return nil, errors.New("the connection has no PROXY protocol header")
+	}
+}
+
+func readProxyV1Header(r *bufio.Reader) (net.Addr, error) {
+	// The line is read up to its maximum length, so a header without the line end is not buffered any further
+	line := make([]byte, 0, proxyV1MaxLength)
+	for len(line) == 0 || line[len(line)-1] != '\n' {
+		if len(line) == proxyV1MaxLength {
+			return nil, errors.Errorf("the PROXY protocol v1 header is longer than %d bytes", proxyV1MaxLength)
+		}
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the PROXY protocol v1 header")
+		}
+		line = append(line, b)
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, errors.New("malformed PROXY protocol v1 header")
+	}
+	fields := strings.Fields(string(line))
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return nil, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, errors.Errorf("malformed PROXY protocol v1 header: %q", line)
+	}
+	ip := net.ParseIP(fields[2])
+	port, err := strconv.ParseUint(fields[4], 10, 16)
+	if ip == nil || err != nil {
+		return nil, errors.Errorf("malformed PROXY protocol v1 source address: %s %s", fields[2], fields[4])
+	}
+	return &net.TCPAddr{IP: ip, Port: int(port)}, nil
+}
+
+func readProxyV2Header(r *bufio.Reader) (net.Addr, error) {
+	header := make([]byte, len(proxyV2Signature)+4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, errors.Wrap(err, "failed to read the PROXY protocol v2 header")
+	}
+	versionCommand, family := header[12], header[13]
+	if versionCommand>>4 != 2 {
+		return nil, errors.Errorf("unsupported PROXY protocol version %d", versionCommand>>4)
+	}
+	length := binary.BigEndian.Uint16(header[14:16])
+	if length > proxyV2MaxLength {
+		return nil, errors.Errorf("the PROXY protocol v2 addresses are longer than %d bytes", proxyV2MaxLength)
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, errors.Wrap(err, "failed to read the PROXY protocol v2 addresses")
+	}
+	if versionCommand&0xf == proxyV2Local {
+		return nil, nil
+	}
+	switch family {
+	case proxyV2FamilyTCP4:
+		if len(payload) < 12 {
+			return nil, errors.New("malformed PROXY protocol v2 IPv4 addresses")
+		}
+		return &net.TCPAddr{IP: net.IP(payload[0:4]), Port: int(binary.BigEndian.Uint16(payload[8:10]))}, nil
+	case proxyV2FamilyTCP6:
+		if len(payload) < 36 {
+			return nil, errors.New("malformed PROXY protocol v2 IPv6 addresses")
+		}
+		return &net.TCPAddr{IP: net.IP(payload[0:16]), Port: int(binary.BigEndian.Uint16(payload[32:34]))}, nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listeners
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+const proxyTestPayload = "payload"
+
+func proxyV2Header(versionCommand, family byte, addresses []byte, length int) []byte {
+	header := append([]byte{}, proxyV2Signature...)
+	header = append(header, versionCommand, family)
+	header = binary.BigEndian.AppendUint16(header, uint16(length))
+	return append(header, addresses...)
+}
+
+func proxyV2Addresses(source, destination net.IP, sourcePort, destinationPort uint16) []byte {
+	addresses := append(append([]byte{}, source...), destination...)
+	addresses = binary.BigEndian.AppendUint16(addresses, sourcePort)
+	return binary.BigEndian.AppendUint16(addresses, destinationPort)
+}
+
+func TestReadProxyHeader(t *testing.T) {
+	ipv4 := proxyV2Addresses(net.ParseIP("192.0.2.1").To4(), net.ParseIP("192.0.2.2").To4(), 56324, 443)
+	ipv6 := proxyV2Addresses(net.ParseIP("2001:db8::1"), net.ParseIP("2001:db8::2"), 56324, 443)
+	for _, tc := range []struct {
+		name    string
+		header  []byte
+		want    string
+		wantErr bool
+	}{
+		{name: "v1 tcp4", header: []byte("PROXY TCP4 192.0.2.1 192.0.2.2 56324 443\r\n"), want: "192.0.2.1:56324"},
+		{name: "v1 tcp6", header: []byte("PROXY TCP6 2001:db8::1 2001:db8::2 56324 443\r\n"), want: "[2001:db8::1]:56324"},
+		{name: "v1 unknown", header: []byte("PROXY UNKNOWN\r\n")},
+		{name: "v1 malformed", header: []byte("PROXY TCP4 192.0.2.1 192.0.2.2\r\n"), wantErr: true},
+		{name: "v1 malformed address", header: []byte("PROXY TCP4 192.0.2 192.0.2.2 56324 443\r\n"), wantErr: true},
+		{name: "v1 malformed port", header: []byte("PROXY TCP4 192.0.2.1 192.0.2.2 65536 443\r\n"), wantErr: true},
+		{name: "v1 without carriage return", header: []byte("PROXY TCP4 192.0.2.1 192.0.2.2 56324 443\n"), wantErr: true},
+		{name: "v1 truncated", header: []byte("PROXY TCP4 192.0.2.1 192.0"), wantErr: true},
+		{name: "v1 oversize", header: []byte("PROXY TCP4 " + strings.Repeat("1", proxyV1MaxLength) + "\r\n"), wantErr: true},
+		{name: "v2 tcp4", header: proxyV2Header(0x21, proxyV2FamilyTCP4, ipv4, len(ipv4)), want: "192.0.2.1:56324"},
+		{name: "v2 tcp6", header: proxyV2Header(0x21, proxyV2FamilyTCP6, ipv6, len(ipv6)), want: "[2001:db8::1]:56324"},
+		{name: "v2 local", header: proxyV2Header(0x20, 0, nil, 0)},
+		{name: "v2 local with addresses", header: proxyV2Header(0x20, proxyV2FamilyTCP4, ipv4, len(ipv4))},
+		{name: "v2 unspecified family", header: proxyV2Header(0x21, 0, nil, 0)},
+		{name: "v2 short addresses", header: proxyV2Header(0x21, proxyV2FamilyTCP6, ipv4, len(ipv4)), wantErr: true},
+		{name: "v2 truncated", header: proxyV2Header(0x21, proxyV2FamilyTCP4, ipv4[:6], len(ipv4)), wantErr: true},
+		{name: "v2 truncated header", header: proxyV2Header(0x21, proxyV2FamilyTCP4, nil, 0)[:14], wantErr: true},
+		{name: "v2 oversize", header: proxyV2Header(0x21, proxyV2FamilyTCP4, ipv4, proxyV2MaxLength+1), wantErr: true},
+		{name: "v2 unsupported version", header: proxyV2Header(0x11, proxyV2FamilyTCP4, ipv4, len(ipv4)), wantErr: true},
+		{name: "no header", header: []byte("GET / HTTP/1.1\r\n"), wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data := tc.header
+			if !tc.wantErr {
+				data = append(data, proxyTestPayload...)
+			}
+			r := bufio.NewReader(bytes.NewReader(data))
+			source, err := readProxyHeader(r)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("the header is accepted with the source %v", source)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to read the header: %v", err)
+			}
+			if got := addrString(source); got != tc.want {
+				t.Errorf("got the source %q, want %q", got, tc.want)
+			}
+			rest, err := io.ReadAll(r)
+			if err != nil || string(rest) != proxyTestPayload {
+				t.Errorf("got %q after the header, want %q: %v", rest, proxyTestPayload, err)
+			}
+		})
+	}
+}
+
+func addrString(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	return addr.String()
+}
+
+func TestCIDRsDecode(t *testing.T) {
+	var cidrs CIDRs
+	if err := cidrs.Decode("10.0.0.0/8, fd00::/8,"); err != nil {
+		t.Fatalf("failed to decode the CIDRs: %v", err)
+	}
+	if len(cidrs) != 2 || cidrs[0].String() != "10.0.0.0/8" || cidrs[1].String() != "fd00::/8" {
+		t.Errorf("got %v, want [10.0.0.0/8 fd00::/8]", cidrs)
+	}
+	if err := cidrs.Decode("10.0.0.0"); err == nil {
+		t.Error("the address without the prefix length is accepted")
+	}
+}
+
+// acceptProxy accepts a connection on the wrapped listener after the client sends the data
+func acceptProxy(t *testing.T, ln *proxyListener, data []byte) net.Conn {
+	t.Helper()
+	client, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	if _, err = client.Write(data); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatalf("failed to accept: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func listenProxy(t *testing.T, trusted ...string) *proxyListener {
+	t.Helper()
+	var cidrs CIDRs
+	if err := cidrs.Decode(strings.Join(trusted, ",")); err != nil {
+		t.Fatalf("failed to decode the CIDRs: %v", err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	return WithProxyProtocol(ln, cidrs).(*proxyListener)
+}
+
+func TestProxyProtocolTrustedCIDRs(t *testing.T) {
+	header := []byte("PROXY TCP4 192.0.2.1 192.0.2.2 56324 443\r\n" + proxyTestPayload)
+	for _, tc := range []struct {
+		name    string
+		trusted []string
+		want    string
+	}{
+		{name: "all trusted", want: "192.0.2.1:56324"},
+		{name: "trusted", trusted: []string{"192.0.2.0/24", "127.0.0.0/8"}, want: "192.0.2.1:56324"},
+		{name: "untrusted", trusted: []string{"192.0.2.0/24"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := acceptProxy(t, listenProxy(t, tc.trusted...), header)
+			data := make([]byte, len(proxyTestPayload))
+			_, err := io.ReadFull(conn, data)
+			if tc.want == "" {
+				if err == nil {
+					t.Fatal("the header from the untrusted peer is accepted")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to read: %v", err)
+			}
+			if got := conn.RemoteAddr().String(); got != tc.want {
+				t.Errorf("got the remote address %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestProxyProtocolHeaderTimeout(t *testing.T) {
+	ln := listenProxy(t)
+	ln.headerTimeout = 50 * time.Millisecond
+	// the client sends only a part of the header and stalls
+	conn := acceptProxy(t, ln, []byte("PROXY TCP4 192.0.2.1"))
+
+	read := make(chan error, 1)
+	go func() {
+		_, err := conn.Read(make([]byte, 1))
+		read <- err
+	}()
+	select {
+	case err := <-read:
+		if !errors.Is(err, os.ErrDeadlineExceeded) {
+			t.Errorf("got %v, want the deadline exceeded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the header read does not time out")
+	}
+}
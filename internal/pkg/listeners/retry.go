@@ -0,0 +1,69 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listeners
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const maxRetryBackoff = 5 * time.Second
+
+// isTransient returns true for the bind errors expected to go away, e.g. the port is still held by the replaced
+// registry during a rolling restart or the socket directory is not mounted yet
+func isTransient(err error) bool {
+	for _, errno := range []syscall.Errno{syscall.EADDRINUSE, syscall.EADDRNOTAVAIL, syscall.ENOENT, syscall.ENOTCONN} {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return false
+}
+
+// retry calls listen until it succeeds, fails with an error that is not transient or the attempts run out
+func retry(ctx context.Context, u *url.URL, backoff wait.Backoff, listen func() (net.Listener, error)) (net.Listener, error) {
+	if backoff.Steps < 1 {
+		backoff.Steps = 1
+	}
+	var ln net.Listener
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, backoff, func(context.Context) (bool, error) {
+		ln, lastErr = listen()
+		switch {
+		case lastErr == nil:
+			return true, nil
+		case !isTransient(lastErr):
+			return false, lastErr
+		}
+		log.FromContext(ctx).Warnf("retrying to listen on %s: %v", u.String(), lastErr)
+		return false, nil
+	})
+	if wait.Interrupted(err) && lastErr != nil {
+		return nil, lastErr
+	}
+	return ln, err
+}
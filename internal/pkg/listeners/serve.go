@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listeners
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Serve serves the server on the listener until the server is stopped, the returned channel receives the serving error
+func Serve(ln net.Listener, server *grpc.Server) <-chan error {
+	errCh := make(chan error, 1)
+	go func() {
+		defer func() {
+			_ = ln.Close()
+		}()
+
+		if err := server.Serve(ln); err != nil {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+	return errCh
+}
+
+// Drain stops the servers gracefully: they stop accepting new connections and requests and send GOAWAY to the clients,
+// the in-flight requests and watches are given the timeout to finish before the servers are stopped forcibly
+func Drain(servers []*grpc.Server, timeout time.Duration) {
+	var wg sync.WaitGroup
+	for _, server := range servers {
+		wg.Add(1)
+		go func(server *grpc.Server) {
+			defer wg.Done()
+
+			stopped := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(stopped)
+			}()
+
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+			select {
+			case <-stopped:
+			case <-timer.C:
+				server.Stop()
+			}
+		}(server)
+	}
+	wg.Wait()
+}
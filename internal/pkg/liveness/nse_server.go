@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package liveness
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/connpool"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/tombstone"
+)
+
+// endpoint is a registered endpoint with the rest of the chain it is unregistered through
+type endpoint struct {
+	ctx      context.Context
+	next     registry.NetworkServiceEndpointRegistryServer
+	nse      *registry.NetworkServiceEndpoint
+	failures int
+}
+
+type livenessNSEServer struct {
+	pool      *connpool.Pool
+	options   *options
+	mu        sync.Mutex
+	endpoints map[string]*endpoint
+}
+
+// NewNetworkServiceEndpointRegistryServer creates the chain element probing the URLs of the registered endpoints until
+// ctx is done, the endpoints failing the configured number of consecutive probes are unregistered through the rest of
+// the chain. The gRPC probes share the connections of the pool. Without a probe the element only passes the requests
+// through.
+func NewNetworkServiceEndpointRegistryServer(ctx context.Context, pool *connpool.Pool, opts ...Option) registry.NetworkServiceEndpointRegistryServer {
+	o := &options{
+		interval:         10 * time.Second,
+		timeout:          time.Second,
+		failureThreshold: 3,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	s := &livenessNSEServer{
+		pool:      pool,
+		options:   o,
+		endpoints: make(map[string]*endpoint),
+	}
+	if o.probe != "" && o.interval > 0 {
+		go s.run(ctx)
+	}
+	return s
+}
+
+func (s *livenessNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil || s.options.probe == "" {
+		return resp, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.endpoints[resp.GetName()]
+	if !ok {
+		e = new(endpoint)
+		s.endpoints[resp.GetName()] = e
+	}
+	// The failures are kept across the refreshes, an endpoint refreshing its registration may still be unreachable
+	e.ctx = tombstone.WithReason(context.WithoutCancel(ctx), tombstone.Evicted)
+	e.next = next.NetworkServiceEndpointRegistryServer(ctx)
+	e.nse = proto.Clone(resp).(*registry.NetworkServiceEndpoint)
+	return resp, nil
+}
+
+func (s *livenessNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *livenessNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	s.mu.Lock()
+	delete(s.endpoints, nse.GetName())
+	s.mu.Unlock()
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+// run probes all the registered endpoints at once every interval
+func (s *livenessNSEServer) run(ctx context.Context) {
+	evictions, _ := otel.Meter("").Int64Counter("registry_liveness_evictions_total",
+		metric.WithDescription("number of the endpoints unregistered after failing the liveness probes"))
+	logger := log.FromContext(ctx).WithField("livenessNSEServer", "run")
+
+	ticker := time.NewTicker(s.options.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s.mu.Lock()
+		probed := make(map[*endpoint]string, len(s.endpoints))
+		for _, e := range s.endpoints {
+			probed[e] = e.nse.GetUrl()
+		}
+		s.mu.Unlock()
+
+		var wg sync.WaitGroup
+		for e, rawURL := range probed {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				probeCtx, cancel := context.WithTimeout(ctx, s.options.timeout)
+				err := s.options.probe.check(probeCtx, s.pool, rawURL)
+				cancel()
+				if s.failed(e, err) {
+					logger.Warnf("unregistering a nse %s failing the liveness probes: %v", e.nse.GetName(), err)
+					if _, unregisterErr := e.next.Unregister(e.ctx, proto.Clone(e.nse).(*registry.NetworkServiceEndpoint)); unregisterErr != nil {
+						logger.Warnf("failed to unregister a nse %s: %v", e.nse.GetName(), unregisterErr)
+						return
+					}
+					evictions.Add(ctx, 1)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+// failed counts the result of the probe of the endpoint and returns true if the endpoint is to be unregistered, it is
+// forgotten then
+func (s *livenessNSEServer) failed(e *endpoint, err error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.endpoints[e.nse.GetName()] != e {
+		return false
+	}
+	if err == nil {
+		e.failures = 0
+		return false
+	}
+	e.failures++
+	if e.failures < s.options.failureThreshold {
+		return false
+	}
+	delete(s.endpoints, e.nse.GetName())
+	return true
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package liveness provides the registry chain element probing the URLs of the registered endpoints and unregistering
+// the unreachable ones before they expire, so the clients are not handed the dead endpoints until then
+package liveness
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/connpool"
+)
+
+// Probe is the way the endpoints are probed
+type Probe string
+
+// The probes
+const (
+	// GRPC checks grpc.health.v1.Health of the endpoints, the endpoints not serving it are alive once they answer
+	GRPC Probe = "grpc"
+	// TCP connects to the endpoints
+	TCP Probe = "tcp"
+)
+
+// Decode implements envconfig.Decoder
+func (p *Probe) Decode(value string) error {
+	switch probe := Probe(value); probe {
+	case "", GRPC, TCP:
+		*p = probe
+		return nil
+	default:
+		return errors.Errorf("unknown liveness probe %s, expected grpc or tcp", value)
+	}
+}
+
+// check probes the endpoint at the URL once
+func (p Probe) check(ctx context.Context, pool *connpool.Pool, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse the url %s", rawURL)
+	}
+	switch p {
+	case GRPC:
+		cc, release, err := pool.Get(ctx, grpcutils.URLToTarget(u))
+		if err != nil {
+			return err
+		}
+		defer release()
+		resp, err := grpc_health_v1.NewHealthClient(cc).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		switch {
+		case status.Code(err) == codes.Unimplemented:
+			return nil
+		case err != nil:
+			return errors.Wrapf(err, "failed to check the health of %s", rawURL)
+		case resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING:
+			return errors.Errorf("%s is %s", rawURL, resp.GetStatus())
+		}
+		return nil
+	case TCP:
+		network, address := "tcp", u.Host
+		if u.Scheme == "unix" {
+			network, address = "unix", u.Path
+		}
+		conn, err := new(net.Dialer).DialContext(ctx, network, address)
+		if err != nil {
+			return errors.Wrapf(err, "failed to connect to %s", rawURL)
+		}
+		return conn.Close()
+	}
+	return nil
+}
+
+type options struct {
+	probe            Probe
+	interval         time.Duration
+	timeout          time.Duration
+	failureThreshold int
+}
+
+// Option is an option pattern for the liveness probing
+type Option func(o *options)
+
+// WithProbe sets the way the endpoints are probed, the empty probe disables the probing
+func WithProbe(probe Probe) Option {
+	return func(o *options) {
+		o.probe = probe
+	}
+}
+
+// WithInterval sets the interval between the probes of an endpoint
+func WithInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.interval = interval
+	}
+}
+
+// WithTimeout sets the timeout of a single probe
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithFailureThreshold sets the number of the consecutive failed probes after which an endpoint is unregistered
+func WithFailureThreshold(failureThreshold int) Option {
+	return func(o *options) {
+		o.failureThreshold = failureThreshold
+	}
+}
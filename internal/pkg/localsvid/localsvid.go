@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package localsvid provides a self-signed X.509 SVID for running the registry locally without SPIRE
+package localsvid
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+// Source serves an X.509 SVID signed by a CA generated in memory along with the bundle of the CA. The SVID is not
+// rotated, it is valid for the lifetime given to New.
+type Source struct {
+	svid   *x509svid.SVID
+	bundle *x509bundle.Bundle
+}
+
+// New creates Source with an SVID of the id valid for the lifetime
+func New(id spiffeid.ID, lifetime time.Duration) (*Source, error) {
+	notBefore := time.Now().Add(-time.Minute)
+	notAfter := notBefore.Add(lifetime)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate the CA key")
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Organization: []string{"registry-k8s"}, CommonName: "local CA"},
+		URIs:                  []*url.URL{id.TrustDomain().ID().URL()},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the CA certificate")
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the CA certificate")
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate the SVID key")
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		URIs:         []*url.URL{id.URL()},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageKeyAgreement,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the SVID certificate")
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the SVID certificate")
+	}
+
+	return &Source{
+		svid: &x509svid.SVID{
+			ID:           id,
+			Certificates: []*x509.Certificate{cert},
+			PrivateKey:   key,
+		},
+		bundle: x509bundle.FromX509Authorities(id.TrustDomain(), []*x509.Certificate{ca}),
+	}, nil
+}
+
+// GetX509SVID returns the SVID
+func (s *Source) GetX509SVID() (*x509svid.SVID, error) {
+	return s.svid, nil
+}
+
+// GetX509BundleForTrustDomain returns the bundle of the CA signing the SVID, it is the only trust domain known
+func (s *Source) GetX509BundleForTrustDomain(td spiffeid.TrustDomain) (*x509bundle.Bundle, error) {
+	if td != s.bundle.TrustDomain() {
+		return nil, errors.Errorf("no X.509 bundle for the trust domain %s", td)
+	}
+	return s.bundle, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localsvid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+func TestSVIDIsVerifiedByTheBundle(t *testing.T) {
+	id := spiffeid.RequireFromString("spiffe://local/ns/nsm-system/pod/registry-k8s")
+	s, err := New(id, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create the source: %v", err)
+	}
+
+	svid, err := s.GetX509SVID()
+	if err != nil {
+		t.Fatalf("failed to get the SVID: %v", err)
+	}
+	verifiedID, _, err := x509svid.Verify(svid.Certificates, s)
+	if err != nil {
+		t.Fatalf("the SVID is not verified by the bundle: %v", err)
+	}
+	if verifiedID != id {
+		t.Errorf("the SVID has %s, want %s", verifiedID, id)
+	}
+	if expiresAt := svid.Certificates[0].NotAfter; expiresAt.Before(time.Now().Add(50 * time.Minute)) {
+		t.Errorf("the SVID expires at %v, before its lifetime", expiresAt)
+	}
+}
+
+func TestUnknownTrustDomain(t *testing.T) {
+	s, err := New(spiffeid.RequireFromString("spiffe://local/registry"), time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create the source: %v", err)
+	}
+	if _, err = s.GetX509BundleForTrustDomain(spiffeid.RequireTrustDomainFromString("example.org")); err == nil {
+		t.Error("got a bundle of an unknown trust domain")
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/grpclog"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/requestid"
+)
+
+// The components with their own log level
+const (
+	// Authorize is the authorization of the requests by the policies
+	Authorize = "authorize"
+	// K8s is the k8s storage, its informers and the apiserver calls
+	K8s = "k8s"
+	// GRPC is the gRPC transport
+	GRPC = "grpc"
+	// Expire is the expiration of the endpoints
+	Expire = "expire"
+)
+
+var (
+	knownComponents = []string{Authorize, K8s, GRPC, Expire}
+
+	// components are the loggers of the components with a configured level, they are set once at startup
+	components = map[string]log.Logger{}
+)
+
+// Levels are the log levels of the components
+type Levels map[string]logrus.Level
+
+// Decode implements envconfig.Decoder, the levels are comma separated component=level pairs, e.g. authorize=debug
+func (l *Levels) Decode(value string) error {
+	levels := make(Levels)
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		component, name, ok := strings.Cut(pair, "=")
+		if !ok {
+			return errors.Errorf("invalid component log level %s, expected component=level", pair)
+		}
+		component = strings.ToLower(strings.TrimSpace(component))
+		if !isKnown(component) {
+			return errors.Errorf("unknown log component %s, expected one of %s", component, strings.Join(knownComponents, ", "))
+		}
+		level, err := logrus.ParseLevel(strings.TrimSpace(name))
+		if err != nil {
+			return errors.Wrapf(err, "invalid log level of the component %s", component)
+		}
+		levels[component] = level
+	}
+	*l = levels
+	return nil
+}
+
+func isKnown(component string) bool {
+	for _, known := range knownComponents {
+		if component == known {
+			return true
+		}
+	}
+	return false
+}
+
+// SetLevels creates the loggers of the components with a configured level. They write with their own level to the
+// output and in the format of the standard logger, so it must be called once the logging is configured and before
+// the components start. The loggers are created by the backend. The gRPC transport logs are redirected to the logger
+// of the grpc component if it is set.
+func SetLevels(levels Levels, backend Backend) {
+	std := logrus.StandardLogger()
+	names := make([]string, 0, len(levels))
+	for component := range levels {
+		names = append(names, component)
+	}
+	sort.Strings(names)
+	for _, component := range names {
+		logger := &logrus.Logger{
+			Out:          std.Out,
+			Formatter:    std.Formatter,
+			Hooks:        std.Hooks,
+			Level:        levels[component],
+			ExitFunc:     std.ExitFunc,
+			ReportCaller: std.ReportCaller,
+		}
+		if component == GRPC {
+			setGRPCLogger(logger)
+		}
+		components[component] = backend(logger).WithField("component", component)
+	}
+}
+
+func setGRPCLogger(logger *logrus.Logger) {
+	writer := func(level logrus.Level) io.Writer {
+		if !logger.IsLevelEnabled(level) {
+			return io.Discard
+		}
+		return logger.WithField("component", GRPC).WriterLevel(level)
+	}
+	verbosity := 0
+	if logger.IsLevelEnabled(logrus.DebugLevel) {
+		verbosity = 2
+	}
+	grpclog.SetLoggerV2(grpclog.NewLoggerV2WithVerbosity(writer(logrus.InfoLevel), writer(logrus.WarnLevel), writer(logrus.ErrorLevel), verbosity))
+}
+
+type previousLogKey struct{}
+
+// WithComponent returns the context logging with the level of the component, it is returned unchanged if the level
+// of the component is not configured. The request ID of the context is kept.
+func WithComponent(ctx context.Context, component string) context.Context {
+	logger, ok := components[component]
+	if !ok {
+		return ctx
+	}
+	if id, ok := requestid.FromContext(ctx); ok {
+		logger = logger.WithField(requestid.LogField, id)
+	}
+	ctx = context.WithValue(ctx, previousLogKey{}, log.FromContext(ctx))
+	return log.WithLog(ctx, logger)
+}
+
+// withPrevious returns the context logging with the logger replaced by WithComponent
+func withPrevious(ctx context.Context) context.Context {
+	if logger, ok := ctx.Value(previousLogKey{}).(log.Logger); ok {
+		return log.WithLog(ctx, logger)
+	}
+	return ctx
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging provides the logrus and zap backed loggers keeping the configured log format
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type logrusLogger struct {
+	entry *logrus.Entry
+}
+
+// New returns log.Logger writing to the entry. Unlike the sdk logruslogger it does not replace the formatter of the
+// entry's logger, so the configured log format is kept once it is set as the global logger.
+func New(entry *logrus.Entry) log.Logger {
+	return &logrusLogger{
+		entry: entry,
+	}
+}
+
+func (l *logrusLogger) Info(v ...interface{}) {
+	l.entry.Info(v...)
+}
+
+func (l *logrusLogger) Infof(format string, v ...interface{}) {
+	l.entry.Infof(format, v...)
+}
+
+func (l *logrusLogger) Warn(v ...interface{}) {
+	l.entry.Warn(v...)
+}
+
+func (l *logrusLogger) Warnf(format string, v ...interface{}) {
+	l.entry.Warnf(format, v...)
+}
+
+func (l *logrusLogger) Error(v ...interface{}) {
+	l.entry.Error(v...)
+}
+
+func (l *logrusLogger) Errorf(format string, v ...interface{}) {
+	l.entry.Errorf(format, v...)
+}
+
+func (l *logrusLogger) Fatal(v ...interface{}) {
+	l.entry.Fatal(v...)
+}
+
+func (l *logrusLogger) Fatalf(format string, v ...interface{}) {
+	l.entry.Fatalf(format, v...)
+}
+
+func (l *logrusLogger) Debug(v ...interface{}) {
+	l.entry.Debug(v...)
+}
+
+func (l *logrusLogger) Debugf(format string, v ...interface{}) {
+	l.entry.Debugf(format, v...)
+}
+
+func (l *logrusLogger) Trace(v ...interface{}) {
+	l.entry.Trace(v...)
+}
+
+func (l *logrusLogger) Tracef(format string, v ...interface{}) {
+	l.entry.Tracef(format, v...)
+}
+
+func (l *logrusLogger) Object(k, v interface{}) {
+	msg, err := json.Marshal(v)
+	if err != nil {
+		l.Infof("%v=%s", k, fmt.Sprint(v))
+		return
+	}
+	l.Infof("%v=%s", k, msg)
+}
+
+func (l *logrusLogger) WithField(key, value interface{}) log.Logger {
+	return &logrusLogger{
+		entry: l.entry.WithField(fmt.Sprint(key), value),
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamcontext"
+)
+
+// NewNetworkServiceRegistryServer wraps the network service server logging with the level of the component, the following
+// elements log with the previous logger again. The server is returned unchanged if the level is not configured.
+func NewNetworkServiceRegistryServer(component string, server registry.NetworkServiceRegistryServer) registry.NetworkServiceRegistryServer {
+	if _, ok := components[component]; !ok {
+		return server
+	}
+	return chain.NewNetworkServiceRegistryServer(
+		&componentNSServer{
+			component: component,
+		},
+		server,
+		&previousNSServer{},
+	)
+}
+
+type componentNSServer struct {
+	component string
+}
+
+func (s *componentNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	ctx = WithComponent(ctx, s.component)
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *componentNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	ctx := WithComponent(server.Context(), s.component)
+	return next.NetworkServiceRegistryServer(ctx).Find(query, streamcontext.NetworkServiceRegistryFindServer(ctx, server))
+}
+
+func (s *componentNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	ctx = WithComponent(ctx, s.component)
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
+
+type previousNSServer struct{}
+
+func (s *previousNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	ctx = withPrevious(ctx)
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *previousNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	ctx := withPrevious(server.Context())
+	return next.NetworkServiceRegistryServer(ctx).Find(query, streamcontext.NetworkServiceRegistryFindServer(ctx, server))
+}
+
+func (s *previousNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	ctx = withPrevious(ctx)
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
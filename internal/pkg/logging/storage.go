@@ -0,0 +1,35 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+// NewStorage wraps the storage logging with the level of the component, it is returned unchanged if the level is
+// not configured
+func NewStorage(component string, s storage.Storage) storage.Storage {
+	if _, ok := components[component]; !ok {
+		return s
+	}
+	return storage.KeepAnnotator(storage.New(
+		NewNetworkServiceRegistryServer(component, s.NetworkServiceRegistryServer()),
+		NewNetworkServiceEndpointRegistryServer(component, s.NetworkServiceEndpointRegistryServer()),
+	), s)
+}
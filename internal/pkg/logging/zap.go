@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// traceLevel is the zap level of the logrus trace level, zap has no level below debug
+const traceLevel = zapcore.DebugLevel - 1
+
+// Backend creates the log.Logger writing with the level, format and output of the logrus logger
+type Backend func(logger *logrus.Logger) log.Logger
+
+// Backends are the logger backends by name
+var Backends = map[string]Backend{
+	"logrus": func(logger *logrus.Logger) log.Logger { return New(logrus.NewEntry(logger)) },
+	"zap":    NewZap,
+}
+
+type zapLogger struct {
+	logger *zap.Logger
+}
+
+// NewZap returns log.Logger formatting the entries with zap, it is cheaper than logrus under trace-heavy load. The
+// level and the output of the logrus logger are followed, so the level changes on signal and the log file are kept.
+// The entries are encoded in JSON if the logrus logger formats in JSON, and for humans otherwise.
+func NewZap(logger *logrus.Logger) log.Logger {
+	config := zapcore.EncoderConfig{
+		TimeKey:        logrus.FieldKeyTime,
+		LevelKey:       logrus.FieldKeyLevel,
+		MessageKey:     logrus.FieldKeyMsg,
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    encodeLevel,
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+	}
+	encoder := zapcore.NewConsoleEncoder(config)
+	if _, ok := logger.Formatter.(*logrus.JSONFormatter); ok {
+		encoder = zapcore.NewJSONEncoder(config)
+	}
+	enabled := zap.LevelEnablerFunc(func(level zapcore.Level) bool {
+		return logger.IsLevelEnabled(logrusLevel(level))
+	})
+	return &zapLogger{
+		logger: zap.New(zapcore.NewCore(encoder, zapcore.Lock(zapcore.AddSync(&output{logger: logger})), enabled)),
+	}
+}
+
+// output writes to the current output of the logrus logger, it may be replaced after the logger is created
+type output struct {
+	logger *logrus.Logger
+}
+
+func (o *output) Write(p []byte) (int, error) {
+	return o.logger.Out.Write(p)
+}
+
+func logrusLevel(level zapcore.Level) logrus.Level {
+	switch {
+	case level <= traceLevel:
+		return logrus.TraceLevel
+	case level == zapcore.DebugLevel:
+		return logrus.DebugLevel
+	case level == zapcore.InfoLevel:
+		return logrus.InfoLevel
+	case level == zapcore.WarnLevel:
+		return logrus.WarnLevel
+	case level == zapcore.ErrorLevel:
+		return logrus.ErrorLevel
+	case level == zapcore.FatalLevel:
+		return logrus.FatalLevel
+	default:
+		return logrus.PanicLevel
+	}
+}
+
+func encodeLevel(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if level == traceLevel {
+		enc.AppendString(logrus.TraceLevel.String())
+		return
+	}
+	zapcore.LowercaseLevelEncoder(level, enc)
+}
+
+// log formats the message only if the level is enabled
+func (l *zapLogger) log(level zapcore.Level, format string, v []interface{}) {
+	if !l.logger.Core().Enabled(level) {
+		return
+	}
+	msg := fmt.Sprint(v...)
+	if format != "" {
+		msg = fmt.Sprintf(format, v...)
+	}
+	if entry := l.logger.Check(level, msg); entry != nil {
+		entry.Write()
+	}
+}
+
+func (l *zapLogger) Info(v ...interface{}) {
+	l.log(zapcore.InfoLevel, "", v)
+}
+
+func (l *zapLogger) Infof(format string, v ...interface{}) {
+	l.log(zapcore.InfoLevel, format, v)
+}
+
+func (l *zapLogger) Warn(v ...interface{}) {
+	l.log(zapcore.WarnLevel, "", v)
+}
+
+func (l *zapLogger) Warnf(format string, v ...interface{}) {
+	l.log(zapcore.WarnLevel, format, v)
+}
+
+func (l *zapLogger) Error(v ...interface{}) {
+	l.log(zapcore.ErrorLevel, "", v)
+}
+
+func (l *zapLogger) Errorf(format string, v ...interface{}) {
+	l.log(zapcore.ErrorLevel, format, v)
+}
+
+func (l *zapLogger) Fatal(v ...interface{}) {
+	l.log(zapcore.FatalLevel, "", v)
+}
+
+func (l *zapLogger) Fatalf(format string, v ...interface{}) {
+	l.log(zapcore.FatalLevel, format, v)
+}
+
+func (l *zapLogger) Debug(v ...interface{}) {
+	l.log(zapcore.DebugLevel, "", v)
+}
+
+func (l *zapLogger) Debugf(format string, v ...interface{}) {
+	l.log(zapcore.DebugLevel, format, v)
+}
+
+func (l *zapLogger) Trace(v ...interface{}) {
+	l.log(traceLevel, "", v)
+}
+
+func (l *zapLogger) Tracef(format string, v ...interface{}) {
+	l.log(traceLevel, format, v)
+}
+
+func (l *zapLogger) Object(k, v interface{}) {
+	msg, err := json.Marshal(v)
+	if err != nil {
+		l.Infof("%v=%s", k, fmt.Sprint(v))
+		return
+	}
+	l.Infof("%v=%s", k, msg)
+}
+
+func (l *zapLogger) WithField(key, value interface{}) log.Logger {
+	return &zapLogger{
+		logger: l.logger.With(zap.Any(fmt.Sprint(key), value)),
+	}
+}
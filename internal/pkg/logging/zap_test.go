@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestZapFollowsTheLogrusLogger(t *testing.T) {
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.SetLevel(logrus.InfoLevel)
+	zapLogger := NewZap(logger).WithField("component", "k8s")
+
+	// the output replaced after the logger is created is followed
+	var out bytes.Buffer
+	logger.SetOutput(&out)
+
+	zapLogger.Debugf("filtered %d", 1)
+	zapLogger.Tracef("filtered %d", 2)
+	zapLogger.Infof("written %d", 3)
+	logger.SetLevel(logrus.TraceLevel)
+	zapLogger.Tracef("written %d", 4)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := []map[string]string{
+		{"level": "info", "msg": "written 3", "component": "k8s"},
+		{"level": "trace", "msg": "written 4", "component": "k8s"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d entries, want %d: %s", len(lines), len(want), out.String())
+	}
+	for i, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("the entry %q is not JSON: %v", line, err)
+		}
+		for k, v := range want[i] {
+			if entry[k] != v {
+				t.Errorf("the entry %d has %s=%v, want %s", i, k, entry[k], v)
+			}
+		}
+	}
+}
+
+func TestZapConsoleFormat(t *testing.T) {
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(&logrus.TextFormatter{})
+
+	NewZap(logger).Warn("console", " entry")
+
+	if got := out.String(); !strings.Contains(got, "warn\tconsole entry") {
+		t.Errorf("got %q, want the console encoded warning", got)
+	}
+}
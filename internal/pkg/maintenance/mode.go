@@ -0,0 +1,75 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package maintenance provides the maintenance mode rejecting the registrations while serving the Find queries
+package maintenance
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Mode tracks whether the registry is in maintenance
+type Mode struct {
+	enabled    atomic.Bool
+	retryDelay time.Duration
+}
+
+// NewMode creates Mode, retryDelay is the delay the clients are asked to retry the rejected registrations after
+func NewMode(enabled bool, retryDelay time.Duration) *Mode {
+	m := &Mode{
+		retryDelay: retryDelay,
+	}
+	m.enabled.Store(enabled)
+
+	_, _ = otel.Meter("").Int64ObservableGauge("registry_maintenance",
+		metric.WithDescription("1 while the registry is in maintenance and rejects the registrations"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			if m.Enabled() {
+				o.Observe(1)
+			} else {
+				o.Observe(0)
+			}
+			return nil
+		}))
+	return m
+}
+
+// Enabled returns true while the registry is in maintenance
+func (m *Mode) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// Set turns the maintenance on or off
+func (m *Mode) Set(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// Toggle turns the maintenance on or off and returns whether it is enabled now
+func (m *Mode) Toggle() bool {
+	for {
+		enabled := m.enabled.Load()
+		if m.enabled.CompareAndSwap(enabled, !enabled) {
+			return !enabled
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+// NewStorage creates storage.Storage rejecting the writes with codes.Unavailable carrying google.rpc.RetryInfo while
+// the registry is in maintenance, so the clients keep retrying them instead of failing. Find queries are served by s.
+func NewStorage(s storage.Storage, m *Mode) storage.Storage {
+	return storage.New(
+		&maintenanceNSServer{
+			storage: s.NetworkServiceRegistryServer(),
+			mode:    m,
+		},
+		&maintenanceNSEServer{
+			storage: s.NetworkServiceEndpointRegistryServer(),
+			mode:    m,
+		},
+	)
+}
+
+func (m *Mode) err() error {
+	s := status.New(codes.Unavailable, "registry is in maintenance")
+	if withDetails, err := s.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(m.retryDelay)}); err == nil {
+		s = withDetails
+	}
+	return s.Err()
+}
+
+type maintenanceNSServer struct {
+	storage registry.NetworkServiceRegistryServer
+	mode    *Mode
+}
+
+func (s *maintenanceNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	if s.mode.Enabled() {
+		return nil, s.mode.err()
+	}
+	return s.storage.Register(ctx, ns)
+}
+
+func (s *maintenanceNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *maintenanceNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	if s.mode.Enabled() {
+		return nil, s.mode.err()
+	}
+	return s.storage.Unregister(ctx, ns)
+}
+
+type maintenanceNSEServer struct {
+	storage registry.NetworkServiceEndpointRegistryServer
+	mode    *Mode
+}
+
+func (s *maintenanceNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if s.mode.Enabled() {
+		return nil, s.mode.err()
+	}
+	return s.storage.Register(ctx, nse)
+}
+
+func (s *maintenanceNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *maintenanceNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	if s.mode.Enabled() {
+		return nil, s.mode.err()
+	}
+	return s.storage.Unregister(ctx, nse)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package maxexpire provides the registry chain element enforcing the maximum expiration of the endpoints, so the
+// misconfigured endpoints can't register with lifetimes outliving their pods
+package maxexpire
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type maxExpireNSEServer struct {
+	maxExpiration time.Duration
+}
+
+// NewNetworkServiceEndpointRegistryServer creates the chain element shortening the expiration of the registered
+// endpoints to at most maxExpiration from now, the endpoints registered without an expiration get it as well. 0
+// disables the maximum.
+func NewNetworkServiceEndpointRegistryServer(maxExpiration time.Duration) registry.NetworkServiceEndpointRegistryServer {
+	return &maxExpireNSEServer{
+		maxExpiration: maxExpiration,
+	}
+}
+
+func (s *maxExpireNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if s.maxExpiration > 0 {
+		maxExpirationTime := clock.FromContext(ctx).Now().Add(s.maxExpiration)
+		if nse.GetExpirationTime() == nil {
+			nse.ExpirationTime = timestamppb.New(maxExpirationTime)
+		} else if requested := nse.GetExpirationTime().AsTime(); requested.After(maxExpirationTime) {
+			log.FromContext(ctx).Warnf("shortened the expiration of %s requested for %v to the maximum %v",
+				nse.GetName(), requested.Local(), maxExpirationTime.Local())
+			nse.ExpirationTime = timestamppb.New(maxExpirationTime)
+		}
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *maxExpireNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *maxExpireNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package maxprocs sets GOMAXPROCS from the CPU limit of the cgroup, so a pod limited to a fraction of the node does
+// not run a thread per node core and get throttled by the CFS quota
+package maxprocs
+
+import (
+	"context"
+	"math"
+	"os"
+	"runtime"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/cgroup"
+)
+
+// Set lowers GOMAXPROCS to the CPU limit of the cgroup rounded down, at least 1. The GOMAXPROCS env takes precedence.
+func Set(ctx context.Context) {
+	if value, ok := os.LookupEnv("GOMAXPROCS"); ok {
+		log.FromContext(ctx).Infof("GOMAXPROCS is set to %s by the env", value)
+		return
+	}
+	limit, ok := cgroup.CPULimit()
+	if !ok {
+		log.FromContext(ctx).Infof("no CPU limit found, GOMAXPROCS stays %d", runtime.GOMAXPROCS(0))
+		return
+	}
+	procs := max(1, int(math.Floor(limit)))
+	if procs < runtime.GOMAXPROCS(0) {
+		runtime.GOMAXPROCS(procs)
+	}
+	log.FromContext(ctx).Infof("GOMAXPROCS is set to %d for the CPU limit %.2f", runtime.GOMAXPROCS(0), limit)
+}
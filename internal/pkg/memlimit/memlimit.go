@@ -0,0 +1,51 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memlimit sets GOMEMLIMIT from the memory limit of the cgroup, so the garbage collector runs harder before
+// the heap reaches the limit instead of the container being OOM killed
+package memlimit
+
+import (
+	"context"
+	"os"
+	"runtime/debug"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/cgroup"
+)
+
+// Set sets GOMEMLIMIT to the memory limit of the cgroup less the headroom percent left for the memory not managed by
+// the Go runtime, 0 or more than 100 percent leaves it unset. The GOMEMLIMIT env takes precedence.
+func Set(ctx context.Context, headroomPercent int) {
+	if value, ok := os.LookupEnv("GOMEMLIMIT"); ok {
+		log.FromContext(ctx).Infof("GOMEMLIMIT is set to %s by the env", value)
+		return
+	}
+	if headroomPercent <= 0 || headroomPercent >= 100 {
+		return
+	}
+	limit, ok := cgroup.MemoryLimit()
+	if !ok {
+		log.FromContext(ctx).Info("no memory limit found, GOMEMLIMIT stays unset")
+		return
+	}
+	memLimit := limit / 100 * int64(100-headroomPercent)
+	debug.SetMemoryLimit(memLimit)
+	log.FromContext(ctx).Infof("GOMEMLIMIT is set to %d for the memory limit %d", memLimit, limit)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package metrics
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+const fdDir = "/proc/self/fd"
+
+// fdUsage returns the number of open file descriptors and their limit, the descriptors are counted on Linux only
+func fdUsage() (open, limit int64, ok bool) {
+	entries, err := os.ReadDir(fdDir)
+	if err != nil {
+		return 0, 0, false
+	}
+	var rlimit unix.Rlimit
+	if err = unix.Getrlimit(unix.RLIMIT_NOFILE, &rlimit); err != nil {
+		return 0, 0, false
+	}
+	// #nosec G115 the limit is capped to int64
+	return int64(len(entries)), int64(min(rlimit.Cur, uint64(1<<63-1))), true
+}
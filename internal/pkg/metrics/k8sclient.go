@@ -0,0 +1,76 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	clientmetrics "k8s.io/client-go/tools/metrics"
+)
+
+// throttledLatency is the rate limiter wait client-go reports as client-side throttling
+const throttledLatency = 50 * time.Millisecond
+
+type rateLimiterLatency struct {
+	waits     metric.Float64Histogram
+	throttled metric.Int64Counter
+}
+
+func (r *rateLimiterLatency) Observe(ctx context.Context, verb string, _ url.URL, latency time.Duration) {
+	attrs := metric.WithAttributes(attribute.String("verb", verb))
+	r.waits.Record(ctx, latency.Seconds(), attrs)
+	if latency > throttledLatency {
+		r.throttled.Add(ctx, 1, attrs)
+	}
+}
+
+type requestResult struct {
+	requests metric.Int64Counter
+}
+
+func (r *requestResult) Increment(ctx context.Context, code, method, _ string) {
+	r.requests.Add(ctx, 1, metric.WithAttributes(attribute.String("code", code), attribute.String("method", method)))
+}
+
+// RegisterKubernetesClient exports the rate limiter waits and the results of the requests of the Kubernetes clients,
+// e.g. the 429 responses, so the client QPS can be tuned from data. It must be called before the clients are created.
+func RegisterKubernetesClient() {
+	meter := otel.Meter("")
+	waits, _ := meter.Float64Histogram("registry_k8s_client_rate_limiter_wait_seconds",
+		metric.WithDescription("duration the Kubernetes client requests waited for the client-side rate limiter"), metric.WithUnit("s"))
+	throttled, _ := meter.Int64Counter("registry_k8s_client_throttled_total",
+		metric.WithDescription("number of the Kubernetes client requests delayed by the client-side throttling"))
+	requests, _ := meter.Int64Counter("registry_k8s_client_requests_total",
+		metric.WithDescription("number of the Kubernetes client requests by the response code"))
+
+	clientmetrics.Register(clientmetrics.RegisterOpts{
+		RateLimiterLatency: &rateLimiterLatency{
+			waits:     waits,
+			throttled: throttled,
+		},
+		RequestResult: &requestResult{
+			requests: requests,
+		},
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides the meter provider exporting the instruments to several readers, e.g. both to the
+// OpenTelemetry Collector and to a Prometheus endpoint
+package metrics
+
+import (
+	"context"
+	"io"
+
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type meterProvider struct {
+	ctx      context.Context
+	provider *sdkmetric.MeterProvider
+}
+
+func (m *meterProvider) Close() error {
+	if err := m.provider.Shutdown(m.ctx); err != nil {
+		log.FromContext(m.ctx).Errorf("failed to shutdown the meter provider: %v", err)
+	}
+	return nil
+}
+
+// Init sets the global meter provider exporting the instruments to all the readers
+func Init(ctx context.Context, res *resource.Resource, readers ...sdkmetric.Reader) io.Closer {
+	opts := []sdkmetric.Option{sdkmetric.WithResource(res)}
+	for _, reader := range readers {
+		opts = append(opts, sdkmetric.WithReader(reader))
+	}
+	provider := sdkmetric.NewMeterProvider(opts...)
+	otel.SetMeterProvider(provider)
+
+	return &meterProvider{
+		ctx:      ctx,
+		provider: provider,
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const metricsPath = "/metrics"
+
+// NewPrometheusReader creates the reader collecting the instruments into a dedicated Prometheus registry and the
+// handler exposing the registry in the Prometheus text format
+func NewPrometheusReader() (sdkmetric.Reader, http.Handler, error) {
+	registry := prometheus.NewRegistry()
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create the Prometheus exporter")
+	}
+	return exporter, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}), nil
+}
+
+// ListenAndServe serves the handler on /metrics for scraping by Prometheus until the context is done
+func ListenAndServe(ctx context.Context, listenOn string, handler http.Handler) {
+	log.FromContext(ctx).Infof("Prometheus metrics are enabled. Listening on %s", listenOn)
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, handler)
+	server := &http.Server{
+		Addr:              listenOn,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.FromContext(ctx).Errorf("failed to serve the Prometheus metrics: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// RegisterRuntime registers the gauges of the Go runtime and of the file descriptors of the process, so the latency
+// spikes can be correlated with the garbage collection or goroutine leaks
+func RegisterRuntime() error {
+	meter := otel.Meter("")
+	goroutines, err := meter.Int64ObservableGauge("go_goroutines",
+		metric.WithDescription("number of goroutines"))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the goroutines gauge")
+	}
+	gcPause, err := meter.Float64ObservableCounter("go_gc_pause_seconds_total",
+		metric.WithDescription("total duration of the stop-the-world pauses of the garbage collector"), metric.WithUnit("s"))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the GC pause counter")
+	}
+	gcCycles, err := meter.Int64ObservableCounter("go_gc_cycles_total",
+		metric.WithDescription("number of completed garbage collection cycles"))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the GC cycles counter")
+	}
+	heapAlloc, err := meter.Int64ObservableGauge("go_heap_alloc_bytes",
+		metric.WithDescription("bytes of the allocated heap objects"), metric.WithUnit("By"))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the heap allocation gauge")
+	}
+	heapSys, err := meter.Int64ObservableGauge("go_heap_sys_bytes",
+		metric.WithDescription("bytes of the heap memory obtained from the OS"), metric.WithUnit("By"))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the heap memory gauge")
+	}
+	openFDs, err := meter.Int64ObservableGauge("process_open_fds",
+		metric.WithDescription("number of open file descriptors"))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the open file descriptors gauge")
+	}
+	maxFDs, err := meter.Int64ObservableGauge("process_max_fds",
+		metric.WithDescription("maximum number of open file descriptors"))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the maximum file descriptors gauge")
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+
+		o.ObserveInt64(goroutines, int64(runtime.NumGoroutine()))
+		o.ObserveFloat64(gcPause, float64(stats.PauseTotalNs)/1e9)
+		o.ObserveInt64(gcCycles, int64(stats.NumGC))
+		// #nosec G115 the heap sizes fit in int64
+		o.ObserveInt64(heapAlloc, int64(stats.HeapAlloc))
+		// #nosec G115 the heap sizes fit in int64
+		o.ObserveInt64(heapSys, int64(stats.HeapSys))
+		if open, limit, ok := fdUsage(); ok {
+			o.ObserveInt64(openFDs, open)
+			o.ObserveInt64(maxFDs, limit)
+		}
+		return nil
+	}, goroutines, gcPause, gcCycles, heapAlloc, heapSys, openFDs, maxFDs)
+	return errors.Wrap(err, "failed to register the runtime gauges")
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package migrate provides copying of the registrations of a running registry, e.g. cmd-registry-memory, to a storage
+package migrate
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+// Copy registers the network services and then the endpoints found in the registry reachable through cc to the
+// storage. The endpoints keep their expiration times, so they stay registered until the endpoints refresh them
+// through the registry taking over.
+func Copy(ctx context.Context, cc grpc.ClientConnInterface, s storage.Storage) (services, endpoints int, err error) {
+	nsStream, err := registry.NewNetworkServiceRegistryClient(cc).Find(ctx, &registry.NetworkServiceQuery{
+		NetworkService: new(registry.NetworkService),
+	})
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to find the network services")
+	}
+	for {
+		resp, recvErr := nsStream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		if recvErr != nil {
+			return services, endpoints, errors.Wrap(recvErr, "failed to receive a network service")
+		}
+		if resp.GetDeleted() {
+			continue
+		}
+		if _, err = s.NetworkServiceRegistryServer().Register(ctx, resp.GetNetworkService()); err != nil {
+			return services, endpoints, errors.Wrapf(err, "failed to register a network service %s", resp.GetNetworkService().GetName())
+		}
+		services++
+	}
+
+	nseStream, err := registry.NewNetworkServiceEndpointRegistryClient(cc).Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+	})
+	if err != nil {
+		return services, endpoints, errors.Wrap(err, "failed to find the network service endpoints")
+	}
+	for {
+		resp, recvErr := nseStream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		if recvErr != nil {
+			return services, endpoints, errors.Wrap(recvErr, "failed to receive a network service endpoint")
+		}
+		if resp.GetDeleted() {
+			continue
+		}
+		if _, err = s.NetworkServiceEndpointRegistryServer().Register(ctx, resp.GetNetworkServiceEndpoint()); err != nil {
+			return services, endpoints, errors.Wrapf(err, "failed to register a network service endpoint %s", resp.GetNetworkServiceEndpoint().GetName())
+		}
+		endpoints++
+	}
+	return services, endpoints, nil
+}
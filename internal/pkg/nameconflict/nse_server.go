@@ -0,0 +1,244 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nameconflict provides the registry chain element resolving the registrations of the endpoints with the names
+// already registered by other SPIFFE IDs
+package nameconflict
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/audit"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/identity"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+// Strategy decides what happens to the registration of an endpoint with the name registered by another SPIFFE ID
+type Strategy string
+
+// The strategies
+const (
+	// Reject rejects the registration with AlreadyExists until the endpoint of the owner is unregistered or expires
+	Reject Strategy = "reject"
+	// LastWriterWins overwrites the endpoint of the owner making the registering SPIFFE ID the owner
+	LastWriterWins Strategy = "last-writer-wins"
+	// Rename registers the endpoint under the name suffixed with the hash of the registering SPIFFE ID
+	Rename Strategy = "rename"
+)
+
+// Decode implements envconfig.Decoder
+func (s *Strategy) Decode(value string) error {
+	switch strategy := Strategy(value); strategy {
+	case Reject, LastWriterWins, Rename:
+		*s = strategy
+		return nil
+	default:
+		return errors.Errorf("unknown name conflict strategy %s, expected reject, last-writer-wins or rename", value)
+	}
+}
+
+// OwnerAnnotation is the annotation the SPIFFE ID that has registered an endpoint is stored in with the endpoint
+const OwnerAnnotation = "registry.networkservicemesh.io/owner"
+
+// reservation is the name of an endpoint being registered by the SPIFFE ID in the count of requests
+type reservation struct {
+	requester string
+	count     int
+}
+
+type nameConflictNSEServer struct {
+	strategy  Strategy
+	auditLog  *audit.Logger
+	annotator storage.Annotator
+
+	mu           sync.Mutex
+	reservations map[string]*reservation
+}
+
+// NewNetworkServiceEndpointRegistryServer creates the chain element resolving the registrations of the endpoints with
+// the names registered by other SPIFFE IDs with the strategy, the conflicts are logged and recorded in the audit log.
+// The owners are stored with the endpoints in the annotations kept by the annotator, the names being registered are
+// reserved in memory, so the concurrent registrations conflict as well. Without the annotator only the concurrent
+// registrations conflict. The requests without a SPIFFE ID are passed through.
+func NewNetworkServiceEndpointRegistryServer(strategy Strategy, auditLog *audit.Logger, annotator storage.Annotator) registry.NetworkServiceEndpointRegistryServer {
+	return &nameConflictNSEServer{
+		strategy:     strategy,
+		auditLog:     auditLog,
+		annotator:    annotator,
+		reservations: make(map[string]*reservation),
+	}
+}
+
+func (s *nameConflictNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	id, ok := identity.SpiffeIDFromContext(ctx)
+	if !ok || nse.GetName() == "" {
+		return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	}
+	requester := id.String()
+	name, err := s.resolve(ctx, nse.GetName(), requester)
+	if err != nil {
+		return nil, err
+	}
+	defer s.release(name, requester)
+
+	nse.Name = name
+	ctx = storage.WithAnnotations(ctx, map[string]string{OwnerAnnotation: requester})
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+// resolve reserves the name of the endpoint registered by the requester resolving the conflicts with the other owners
+// by the strategy, it returns the reserved name the endpoint is registered under
+func (s *nameConflictNSEServer) resolve(ctx context.Context, name, requester string) (string, error) {
+	current, err := s.reserve(ctx, name, requester, s.strategy == LastWriterWins)
+	if err != nil || current == "" {
+		return name, err
+	}
+
+	logger := log.FromContext(ctx).WithField("nameConflictNSEServer", "Register")
+	switch s.strategy {
+	case Reject:
+		err = status.Errorf(codes.AlreadyExists, "nse %s is registered by %s", name, current)
+		logger.Warnf("rejected the registration of a nse %s by %s, it is registered by %s", name, requester, current)
+		s.auditLog.Conflict(ctx, name, current, string(Reject), err)
+		return "", err
+	case Rename:
+		renamed := renamedName(name, requester)
+		renamedOwner, renamedErr := s.reserve(ctx, renamed, requester, false)
+		if renamedErr != nil {
+			return "", renamedErr
+		}
+		if renamedOwner != "" {
+			err = status.Errorf(codes.AlreadyExists, "nse %s is registered by %s", renamed, renamedOwner)
+			s.auditLog.Conflict(ctx, renamed, renamedOwner, string(Reject), err)
+			return "", err
+		}
+		logger.Warnf("registering a nse %s by %s as %s, it is registered by %s", name, requester, renamed, current)
+		s.auditLog.Conflict(ctx, name, current, string(Rename)+" to "+renamed, nil)
+		return renamed, nil
+	default:
+		logger.Warnf("nse %s registered by %s is overwritten by %s", name, current, requester)
+		s.auditLog.Conflict(ctx, name, current, string(LastWriterWins), nil)
+		return name, nil
+	}
+}
+
+func (s *nameConflictNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *nameConflictNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	id, ok := identity.SpiffeIDFromContext(ctx)
+	if !ok || nse.GetName() == "" {
+		return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	}
+	requester := id.String()
+	current, err := s.owner(ctx, nse.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if current != "" && current != requester {
+		switch s.strategy {
+		case Reject:
+			return nil, status.Errorf(codes.PermissionDenied, "nse %s is registered by %s", nse.GetName(), current)
+		case Rename:
+			nse.Name = renamedName(nse.GetName(), requester)
+		default:
+			// the last writer owns the endpoint, the unregistration of the overwritten one must not remove it
+			return new(empty.Empty), nil
+		}
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+// reserve reserves the name for the requester until it is released, so the registrations of the name by other SPIFFE
+// IDs conflict with it meanwhile. It returns the other SPIFFE ID registering the name or owning the stored endpoint,
+// the name is reserved for the requester on such a conflict only if forced.
+func (s *nameConflictNSEServer) reserve(ctx context.Context, name, requester string, force bool) (string, error) {
+	s.mu.Lock()
+	r, ok := s.reservations[name]
+	if ok && r.requester != requester {
+		current := r.requester
+		if force {
+			s.reservations[name] = &reservation{requester: requester, count: 1}
+		}
+		s.mu.Unlock()
+		return current, nil
+	}
+	if !ok {
+		r = &reservation{requester: requester}
+		s.reservations[name] = r
+	}
+	r.count++
+	s.mu.Unlock()
+
+	current, err := s.owner(ctx, name)
+	if err != nil {
+		s.release(name, requester)
+		return "", err
+	}
+	if current == "" || current == requester {
+		return "", nil
+	}
+	if !force {
+		s.release(name, requester)
+	}
+	return current, nil
+}
+
+// release releases the reservation of the name by the requester, the reservations taken over by others are kept
+func (s *nameConflictNSEServer) release(name, requester string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.reservations[name]
+	if !ok || r.requester != requester {
+		return
+	}
+	if r.count--; r.count == 0 {
+		delete(s.reservations, name)
+	}
+}
+
+// owner returns the SPIFFE ID stored with the endpoint, "" if the endpoint is not stored or its owner is unknown
+func (s *nameConflictNSEServer) owner(ctx context.Context, name string) (string, error) {
+	if s.annotator == nil {
+		return "", nil
+	}
+	annotations, err := s.annotator.NSEAnnotations(ctx, name)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get the owner of nse %s", name)
+	}
+	return annotations[OwnerAnnotation], nil
+}
+
+// renamedName returns the name of the endpoint suffixed with the hash of the SPIFFE ID, so the refreshes of the same
+// workload keep the same name
+func renamedName(name, spiffeID string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(spiffeID))
+	return fmt.Sprintf("%s-%08x", name, h.Sum32())
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nameconflict
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/registrytest"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/memory"
+)
+
+const (
+	alice = "spiffe://example.org/alice"
+	bob   = "spiffe://example.org/bob"
+)
+
+func newServer(strategy Strategy, backend storage.Storage) registry.NetworkServiceEndpointRegistryServer {
+	return chain.NewNetworkServiceEndpointRegistryServer(
+		NewNetworkServiceEndpointRegistryServer(strategy, nil, backend.(storage.Annotator)),
+		backend.NetworkServiceEndpointRegistryServer(),
+	)
+}
+
+func owner(t *testing.T, backend storage.Storage, name string) string {
+	t.Helper()
+	annotations, err := backend.(storage.Annotator).NSEAnnotations(context.Background(), name)
+	if err != nil {
+		t.Fatalf("failed to get the annotations: %v", err)
+	}
+	return annotations[OwnerAnnotation]
+}
+
+func TestConflicts(t *testing.T) {
+	for _, tc := range []struct {
+		strategy       Strategy
+		registerCode   codes.Code
+		registeredName string
+		unregisterCode codes.Code
+		owner          string
+	}{
+		{strategy: Reject, registerCode: codes.AlreadyExists, unregisterCode: codes.PermissionDenied, owner: alice},
+		{strategy: LastWriterWins, registeredName: "nse-1", owner: bob},
+		{strategy: Rename, registeredName: renamedName("nse-1", bob), owner: alice},
+	} {
+		t.Run(string(tc.strategy), func(t *testing.T) {
+			backend := memory.NewStorage()
+			s := newServer(tc.strategy, backend)
+
+			if _, err := s.Register(registrytest.IDContext(t, alice), &registry.NetworkServiceEndpoint{Name: "nse-1"}); err != nil {
+				t.Fatalf("register failed: %v", err)
+			}
+			resp, err := s.Register(registrytest.IDContext(t, bob), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+			if code := status.Code(err); code != tc.registerCode {
+				t.Fatalf("conflicting registration returned %v, want %v", err, tc.registerCode)
+			}
+			if got := resp.GetName(); got != tc.registeredName {
+				t.Errorf("conflicting registration is registered as %q, want %q", got, tc.registeredName)
+			}
+			if got := owner(t, backend, "nse-1"); got != tc.owner {
+				t.Errorf("nse-1 is owned by %q, want %q", got, tc.owner)
+			}
+
+			// The owner is kept by the storage, so a new registry sees it as well
+			s = newServer(tc.strategy, backend)
+			if _, err = s.Unregister(registrytest.IDContext(t, bob), &registry.NetworkServiceEndpoint{Name: "nse-1"}); status.Code(err) != tc.unregisterCode {
+				t.Errorf("unregistration by bob returned %v, want %v", err, tc.unregisterCode)
+			}
+			if got := owner(t, backend, "nse-1"); tc.strategy != LastWriterWins && got != alice {
+				t.Errorf("unregistration by bob removed the nse of alice")
+			}
+		})
+	}
+}
+
+func TestRefreshIsNotConflict(t *testing.T) {
+	s := newServer(Reject, memory.NewStorage())
+	for i := 0; i < 2; i++ {
+		if _, err := s.Register(registrytest.IDContext(t, alice), &registry.NetworkServiceEndpoint{Name: "nse-1"}); err != nil {
+			t.Fatalf("registration %d failed: %v", i, err)
+		}
+	}
+}
+
+func TestConcurrentRegistrationsConflict(t *testing.T) {
+	backend := memory.NewStorage()
+	blocking := registrytest.NewBlockingNSEServer(backend.NetworkServiceEndpointRegistryServer())
+	s := chain.NewNetworkServiceEndpointRegistryServer(
+		NewNetworkServiceEndpointRegistryServer(Reject, nil, backend.(storage.Annotator)),
+		blocking,
+	)
+
+	done := make(chan error)
+	go func() {
+		_, err := s.Register(registrytest.IDContext(t, alice), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+		done <- err
+	}()
+	<-blocking.Started
+
+	// nse-1 is not stored yet, but it is reserved by alice
+	if _, err := s.Register(registrytest.IDContext(t, bob), &registry.NetworkServiceEndpoint{Name: "nse-1"}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("concurrent registration returned %v, want AlreadyExists", err)
+	}
+
+	blocking.Release <- nil
+	if err := <-done; err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if got := owner(t, backend, "nse-1"); got != alice {
+		t.Errorf("nse-1 is owned by %q, want alice", got)
+	}
+}
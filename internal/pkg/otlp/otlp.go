@@ -0,0 +1,151 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlp provides the exporters sending the traces and metrics to an OpenTelemetry Collector, optionally
+// secured with TLS or mTLS and authenticated with custom headers, and the resource describing the registry in them
+package otlp
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	grpcProtocol = "grpc"
+	httpProtocol = "http/protobuf"
+
+	// httpUnsupported is returned until the OTLP HTTP exporters are added to the module dependencies
+	httpUnsupported = "OTLP http/protobuf export is not supported by this build, use grpc"
+	unknownProtocol = "unknown OTLP protocol %s, expected grpc"
+)
+
+// Config contains configuration parameters for the connection to the OpenTelemetry Collector
+type Config struct {
+	Protocol string            `default:"grpc" desc:"OTLP protocol used to export to the collector, only grpc is currently supported" split_words:"true"`
+	TLS      bool              `default:"false" desc:"connect to the collector with TLS, implied by the CA or certificate file" split_words:"true"`
+	CAFile   string            `desc:"CA file used to verify the collector certificate, the system roots are used if it is empty" split_words:"true"`
+	CertFile string            `desc:"client certificate file for mTLS to the collector" split_words:"true"`
+	KeyFile  string            `desc:"client key file for mTLS to the collector" split_words:"true"`
+	Headers  map[string]string `desc:"headers sent with every export, e.g. authorization:Bearer <token>" redact:"true" split_words:"true"`
+
+	ClusterName        string            `desc:"k8s.cluster.name resource attribute of the traces and metrics" split_words:"true"`
+	Region             string            `desc:"cloud.region resource attribute of the traces and metrics" split_words:"true"`
+	PodName            string            `desc:"k8s.pod.name resource attribute of the traces and metrics, usually set from the downward API metadata.name" split_words:"true"`
+	NodeName           string            `desc:"k8s.node.name resource attribute of the traces and metrics, usually set from the downward API spec.nodeName" split_words:"true"`
+	ResourceAttributes map[string]string `desc:"additional resource attributes of the traces and metrics, e.g. deployment.environment:prod" split_words:"true"`
+}
+
+// tlsConfig returns the TLS config for the collector or nil if TLS is not enabled
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	if !c.TLS && c.CAFile == "" && c.CertFile == "" {
+		return nil, nil
+	}
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the collector CA file %s", c.CAFile)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no certificates found in the collector CA file %s", c.CAFile)
+		}
+	}
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load the collector client certificate")
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// NewSpanExporter creates the exporter sending the spans to the collector at the endpoint
+func NewSpanExporter(ctx context.Context, endpoint string, config *Config) (sdktrace.SpanExporter, error) {
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var client otlptrace.Client
+	switch config.Protocol {
+	case grpcProtocol:
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(endpoint),
+			otlptracegrpc.WithHeaders(config.Headers),
+		}
+		if tlsConfig != nil {
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		} else {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		client = otlptracegrpc.NewClient(opts...)
+	case httpProtocol:
+		return nil, errors.New(httpUnsupported)
+	default:
+		return nil, errors.Errorf(unknownProtocol, config.Protocol)
+	}
+
+	exporter, err := otlptrace.New(ctx, client)
+	return exporter, errors.Wrapf(err, "failed to create the span exporter to %s", endpoint)
+}
+
+// NewMetricReader creates the reader exporting the metrics to the collector at the endpoint with the interval
+func NewMetricReader(ctx context.Context, endpoint string, interval time.Duration, config *Config) (sdkmetric.Reader, error) {
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var exporter sdkmetric.Exporter
+	switch config.Protocol {
+	case grpcProtocol:
+		opts := []otlpmetricgrpc.Option{
+			otlpmetricgrpc.WithEndpoint(endpoint),
+			otlpmetricgrpc.WithHeaders(config.Headers),
+		}
+		if tlsConfig != nil {
+			opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		} else {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		}
+		exporter, err = otlpmetricgrpc.New(ctx, opts...)
+	case httpProtocol:
+		return nil, errors.New(httpUnsupported)
+	default:
+		return nil, errors.Errorf(unknownProtocol, config.Protocol)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create the metric exporter to %s", endpoint)
+	}
+	return sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(interval)), nil
+}
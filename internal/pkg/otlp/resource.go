@@ -0,0 +1,60 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Resource returns the resource describing the registry in the exported traces and metrics. The attributes of the
+// OTEL_RESOURCE_ATTRIBUTES env take precedence over the configured ones.
+func (c *Config) Resource(ctx context.Context, service, namespace string) *resource.Resource {
+	attributes := []attribute.KeyValue{
+		semconv.ServiceNameKey.String(service),
+		semconv.K8SNamespaceNameKey.String(namespace),
+	}
+	for key, value := range map[attribute.Key]string{
+		semconv.K8SClusterNameKey: c.ClusterName,
+		semconv.CloudRegionKey:    c.Region,
+		semconv.K8SPodNameKey:     c.PodName,
+		semconv.K8SNodeNameKey:    c.NodeName,
+	} {
+		if value != "" {
+			attributes = append(attributes, key.String(value))
+		}
+	}
+	for key, value := range c.ResourceAttributes {
+		attributes = append(attributes, attribute.String(key, value))
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attributes...),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		log.FromContext(ctx).Errorf("%v", err)
+	}
+	return res
+}
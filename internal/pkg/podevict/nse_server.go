@@ -0,0 +1,198 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package podevict provides the registry chain element unregistering the endpoints as soon as the pods registering
+// them are deleted, instead of leaving them to the clients until they expire
+package podevict
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/identity"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/tombstone"
+)
+
+// endpoint is a registered endpoint with the rest of the chain it is unregistered through
+type endpoint struct {
+	ctx  context.Context
+	next registry.NetworkServiceEndpointRegistryServer
+	nse  *registry.NetworkServiceEndpoint
+	pod  string
+}
+
+type podEvictNSEServer struct {
+	label     string
+	mu        sync.Mutex
+	endpoints map[string]*endpoint
+	pods      map[string]map[string]*endpoint
+
+	evictions metric.Int64Counter
+}
+
+// NewNetworkServiceEndpointRegistryServer creates the chain element watching the pods with the client until ctx is
+// done and unregistering the endpoints of the deleted pods through the rest of the chain. The pod of an endpoint is
+// named by the value of the network service label, as name or namespace/name, or if the label is empty or missing by
+// the SPIFFE ID of the workload registering it. Without a client the element only passes the requests through.
+func NewNetworkServiceEndpointRegistryServer(ctx context.Context, client kubernetes.Interface, label string) registry.NetworkServiceEndpointRegistryServer {
+	evictions, _ := otel.Meter("").Int64Counter("registry_pod_evictions_total",
+		metric.WithDescription("number of the endpoints unregistered after their pods were deleted"))
+	s := &podEvictNSEServer{
+		label:     label,
+		endpoints: make(map[string]*endpoint),
+		pods:      make(map[string]map[string]*endpoint),
+		evictions: evictions,
+	}
+	if client != nil {
+		s.watch(ctx, client)
+	}
+	return s
+}
+
+// watch watches the deletions of the pods, only their names are kept in the informer cache
+func (s *podEvictNSEServer) watch(ctx context.Context, client kubernetes.Interface) {
+	factory := informers.NewSharedInformerFactoryWithOptions(client, 0,
+		informers.WithTransform(func(obj interface{}) (interface{}, error) {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+					Namespace:       pod.GetNamespace(),
+					Name:            pod.GetName(),
+					UID:             pod.GetUID(),
+					ResourceVersion: pod.GetResourceVersion(),
+				}}, nil
+			}
+			return obj, nil
+		}))
+	if _, err := factory.Core().V1().Pods().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+				s.evict(ctx, key)
+			}
+		},
+	}); err != nil {
+		log.FromContext(ctx).Errorf("failed to watch the pods of the endpoints: %v", err)
+		return
+	}
+	factory.Start(ctx.Done())
+}
+
+func (s *podEvictNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil {
+		return resp, err
+	}
+	pod, ok := s.podOf(ctx, resp)
+	if !ok {
+		return resp, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.forget(resp.GetName())
+	e := &endpoint{
+		ctx:  tombstone.WithReason(context.WithoutCancel(ctx), tombstone.Evicted),
+		next: next.NetworkServiceEndpointRegistryServer(ctx),
+		nse:  proto.Clone(resp).(*registry.NetworkServiceEndpoint),
+		pod:  pod,
+	}
+	s.endpoints[resp.GetName()] = e
+	if s.pods[pod] == nil {
+		s.pods[pod] = make(map[string]*endpoint)
+	}
+	s.pods[pod][resp.GetName()] = e
+	return resp, nil
+}
+
+func (s *podEvictNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *podEvictNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	s.mu.Lock()
+	s.forget(nse.GetName())
+	s.mu.Unlock()
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+// podOf returns the namespace/name key of the pod of the endpoint
+func (s *podEvictNSEServer) podOf(ctx context.Context, nse *registry.NetworkServiceEndpoint) (string, bool) {
+	if s.label != "" {
+		for _, labels := range nse.GetNetworkServiceLabels() {
+			if value := labels.GetLabels()[s.label]; value != "" {
+				if strings.Contains(value, "/") {
+					return value, true
+				}
+				if namespace, _, ok := identity.PodFromContext(ctx); ok {
+					return namespace + "/" + value, true
+				}
+			}
+		}
+	}
+	namespace, name, ok := identity.PodFromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	return namespace + "/" + name, true
+}
+
+// forget stops tracking the endpoint, s.mu is expected to be locked
+func (s *podEvictNSEServer) forget(name string) {
+	e, ok := s.endpoints[name]
+	if !ok {
+		return
+	}
+	delete(s.endpoints, name)
+	delete(s.pods[e.pod], name)
+	if len(s.pods[e.pod]) == 0 {
+		delete(s.pods, e.pod)
+	}
+}
+
+// evict unregisters the endpoints of the deleted pod
+func (s *podEvictNSEServer) evict(ctx context.Context, pod string) {
+	s.mu.Lock()
+	evicted := make([]*endpoint, 0, len(s.pods[pod]))
+	for name, e := range s.pods[pod] {
+		evicted = append(evicted, e)
+		s.forget(name)
+	}
+	s.mu.Unlock()
+
+	logger := log.FromContext(ctx).WithField("podEvictNSEServer", "evict")
+	for _, e := range evicted {
+		logger.Infof("unregistering a nse %s of the deleted pod %s", e.nse.GetName(), pod)
+		if _, err := e.next.Unregister(e.ctx, proto.Clone(e.nse).(*registry.NetworkServiceEndpoint)); err != nil {
+			logger.Warnf("failed to unregister a nse %s: %v", e.nse.GetName(), err)
+			continue
+		}
+		s.evictions.Add(ctx, 1)
+	}
+}
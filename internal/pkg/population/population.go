@@ -0,0 +1,132 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package population provides gauges of the network services and endpoints registered in a storage
+package population
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+const collectTimeout = 5 * time.Second
+
+type key struct {
+	service   string
+	namespace string
+}
+
+// Register registers the gauges counting the records of the storage on every collection. The endpoints are broken
+// down by their network services and by the namespace stored in the namespaceLabel network service label.
+func Register(s storage.Storage, namespaceLabel string) error {
+	meter := otel.Meter("")
+	nses, err := meter.Int64ObservableGauge("registry_network_service_endpoints",
+		metric.WithDescription("number of the registered network service endpoints by network service and namespace"))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the network service endpoints gauge")
+	}
+	nss, err := meter.Int64ObservableGauge("registry_network_services",
+		metric.WithDescription("number of the registered network services"))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the network services gauge")
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		ctx, cancel := context.WithTimeout(ctx, collectTimeout)
+		defer cancel()
+
+		nsCollector := &nsFindServer{ctx: ctx}
+		if findErr := s.NetworkServiceRegistryServer().Find(&registry.NetworkServiceQuery{
+			NetworkService: new(registry.NetworkService),
+		}, nsCollector); findErr != nil {
+			return errors.Wrap(findErr, "failed to count the network services")
+		}
+		o.ObserveInt64(nss, nsCollector.count)
+
+		nseCollector := &nseFindServer{
+			ctx:            ctx,
+			namespaceLabel: namespaceLabel,
+			counts:         make(map[key]int64),
+		}
+		if findErr := s.NetworkServiceEndpointRegistryServer().Find(&registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+		}, nseCollector); findErr != nil {
+			return errors.Wrap(findErr, "failed to count the network service endpoints")
+		}
+		for k, count := range nseCollector.counts {
+			o.ObserveInt64(nses, count, metric.WithAttributes(
+				attribute.String("network_service", k.service), attribute.String("namespace", k.namespace)))
+		}
+		return nil
+	}, nses, nss)
+	return errors.Wrap(err, "failed to register the population gauges")
+}
+
+// nsFindServer counts the found network services
+type nsFindServer struct {
+	grpc.ServerStream
+	ctx   context.Context
+	count int64
+}
+
+func (s *nsFindServer) Send(resp *registry.NetworkServiceResponse) error {
+	if !resp.GetDeleted() {
+		s.count++
+	}
+	return nil
+}
+
+func (s *nsFindServer) Context() context.Context {
+	return s.ctx
+}
+
+// nseFindServer counts the found endpoints by their network services and namespace
+type nseFindServer struct {
+	grpc.ServerStream
+	ctx            context.Context
+	namespaceLabel string
+	counts         map[key]int64
+}
+
+func (s *nseFindServer) Send(resp *registry.NetworkServiceEndpointResponse) error {
+	if resp.GetDeleted() {
+		return nil
+	}
+	nse := resp.GetNetworkServiceEndpoint()
+	for _, service := range nse.GetNetworkServiceNames() {
+		s.counts[key{
+			service:   service,
+			namespace: nse.GetNetworkServiceLabels()[service].GetLabels()[s.namespaceLabel],
+		}]++
+	}
+	return nil
+}
+
+func (s *nseFindServer) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package profiling provides continuous CPU and heap profiling, periodically writing the profiles to a directory to
+// be collected by a sidecar or inspected on the node, so the gradual changes over long runs can be diagnosed
+package profiling
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	cpuKind  = "cpu"
+	heapKind = "heap"
+
+	timeFormat = "20060102T150405Z"
+)
+
+// Config contains configuration parameters for the continuous profiling
+type Config struct {
+	Dir         string            `desc:"directory the CPU and heap profiles are periodically written to, empty disables the continuous profiling" split_words:"true"`
+	Interval    time.Duration     `default:"5m" desc:"interval between the collected profiles" split_words:"true"`
+	CPUDuration time.Duration     `default:"10s" desc:"duration of every CPU profile" split_words:"true"`
+	Retention   int               `default:"288" desc:"number of the most recent profiles of every kind kept in the directory, 0 keeps all of them" split_words:"true"`
+	Labels      map[string]string `desc:"pprof labels of the CPU profile samples, e.g. cluster:east" split_words:"true"`
+}
+
+// Start labels the calling goroutine and the goroutines it starts afterwards with the configured labels and starts
+// writing the profiles until ctx is done. It should be called early, so the labels reach all the goroutines.
+func Start(ctx context.Context, config *Config) error {
+	if config.Dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(config.Dir, 0o750); err != nil {
+		return errors.Wrapf(err, "failed to create the profile directory %s", config.Dir)
+	}
+
+	var labels []string
+	for key, value := range config.Labels {
+		labels = append(labels, key, value)
+	}
+	pprof.SetGoroutineLabels(pprof.WithLabels(ctx, pprof.Labels(labels...)))
+
+	go run(ctx, config)
+	return nil
+}
+
+func run(ctx context.Context, config *Config) {
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+	for {
+		if err := writeCPU(ctx, config); err != nil {
+			log.FromContext(ctx).Warnf("failed to write the CPU profile: %+v", err)
+		}
+		if err := writeHeap(config); err != nil {
+			log.FromContext(ctx).Warnf("failed to write the heap profile: %+v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeCPU profiles the CPU for the configured duration, it fails if the CPU is already profiled on demand by pprof
+func writeCPU(ctx context.Context, config *Config) error {
+	return write(config, cpuKind, func(f *os.File) error {
+		if err := pprof.StartCPUProfile(f); err != nil {
+			return errors.Wrap(err, "failed to start the CPU profile")
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(config.CPUDuration):
+		}
+		pprof.StopCPUProfile()
+		return nil
+	})
+}
+
+func writeHeap(config *Config) error {
+	return write(config, heapKind, func(f *os.File) error {
+		return errors.Wrap(pprof.Lookup(heapKind).WriteTo(f, 0), "failed to write the heap profile")
+	})
+}
+
+// write writes the profile of the kind to a new file and removes the files of the kind exceeding the retention
+func write(config *Config, kind string, profile func(f *os.File) error) error {
+	name := filepath.Join(config.Dir, fmt.Sprintf("%s-%s.pb.gz", kind, time.Now().UTC().Format(timeFormat)))
+	f, err := os.Create(filepath.Clean(name))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the profile %s", name)
+	}
+	err = profile(f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = errors.Wrapf(closeErr, "failed to close the profile %s", name)
+	}
+	if err != nil {
+		_ = os.Remove(name)
+		return err
+	}
+	return prune(config.Dir, kind, config.Retention)
+}
+
+// prune removes the oldest profiles of the kind, the timestamps in the file names sort chronologically
+func prune(dir, kind string, retention int) error {
+	names, err := filepath.Glob(filepath.Join(dir, kind+"-*.pb.gz"))
+	if err != nil {
+		return errors.Wrap(err, "failed to list the profiles")
+	}
+	if retention <= 0 || len(names) <= retention {
+		return nil
+	}
+	sort.Strings(names)
+	for _, name := range names[:len(names)-retention] {
+		if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to remove the profile %s", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit provides the client-side rate limiter of the Kubernetes clients adapting to the load
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// increaseFactor raises the QPS while the requests wait for the limiter
+	increaseFactor = 1.5
+	// decreaseFactor lowers the QPS once the apiserver throttles the requests
+	decreaseFactor = 0.5
+	// decayFactor lowers the QPS slowly while the load stays below it, down to twice the observed rate
+	decayFactor = 0.9
+)
+
+type options struct {
+	interval time.Duration
+}
+
+// Option is an option pattern for the adaptive rate limiter
+type Option func(o *options)
+
+// WithInterval sets how often the QPS is adjusted
+func WithInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.interval = interval
+	}
+}
+
+// Adaptive is flowcontrol.RateLimiter adjusting its QPS within the bounds: it is raised while the requests wait for
+// the limiter, lowered when the apiserver responds with 429 Too Many Requests and decays while the load stays below
+// it. The burst is twice the QPS.
+type Adaptive struct {
+	min, max float64
+	limiter  *rate.Limiter
+
+	requests  atomic.Int64
+	delayed   atomic.Int64
+	throttled atomic.Int64
+}
+
+// New creates the adaptive rate limiter starting at qps and adjusting it until ctx is done
+func New(ctx context.Context, qps, minQPS, maxQPS float64, opts ...Option) *Adaptive {
+	o := &options{
+		interval: 10 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	qps = math.Min(math.Max(qps, minQPS), maxQPS)
+	a := &Adaptive{
+		min:     minQPS,
+		max:     maxQPS,
+		limiter: rate.NewLimiter(rate.Limit(qps), burst(qps)),
+	}
+	a.registerMetrics()
+	go a.run(ctx, o.interval)
+	return a
+}
+
+// Configure makes the clients created from the config use the limiter and report the throttling to it
+func (a *Adaptive) Configure(config *rest.Config) {
+	config.RateLimiter = a
+	config.WrapTransport = transport.Wrappers(config.WrapTransport, func(rt http.RoundTripper) http.RoundTripper {
+		return &roundTripper{RoundTripper: rt, throttled: &a.throttled}
+	})
+}
+
+// TryAccept returns true if a token is taken immediately
+func (a *Adaptive) TryAccept() bool {
+	if !a.limiter.Allow() {
+		return false
+	}
+	a.requests.Add(1)
+	return true
+}
+
+// Accept returns once a token is taken
+func (a *Adaptive) Accept() {
+	_ = a.Wait(context.Background())
+}
+
+// Wait returns nil if a token is taken before ctx is done
+func (a *Adaptive) Wait(ctx context.Context) error {
+	if a.TryAccept() {
+		return nil
+	}
+	a.delayed.Add(1)
+	if err := a.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	a.requests.Add(1)
+	return nil
+}
+
+// Stop does nothing, the adjustment stops with the context passed to New
+func (a *Adaptive) Stop() {}
+
+// QPS returns the current QPS
+func (a *Adaptive) QPS() float32 {
+	return float32(a.limiter.Limit())
+}
+
+func (a *Adaptive) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.adjust(ctx, interval)
+		}
+	}
+}
+
+func (a *Adaptive) adjust(ctx context.Context, interval time.Duration) {
+	requests, delayed, throttled := a.requests.Swap(0), a.delayed.Swap(0), a.throttled.Swap(0)
+	current := float64(a.limiter.Limit())
+	observed := float64(requests) / interval.Seconds()
+
+	qps := current
+	switch {
+	case throttled > 0:
+		qps = current * decreaseFactor
+	case delayed > 0:
+		qps = current * increaseFactor
+	case observed*2 < current:
+		qps = math.Max(current*decayFactor, observed*2)
+	}
+	qps = math.Min(math.Max(qps, a.min), a.max)
+	if qps == current {
+		return
+	}
+
+	a.limiter.SetLimit(rate.Limit(qps))
+	a.limiter.SetBurst(burst(qps))
+	log.FromContext(ctx).Debugf("adjusted the Kubernetes client QPS from %.1f to %.1f: %.1f requests/s, %d delayed, %d throttled",
+		current, qps, observed, delayed, throttled)
+}
+
+func (a *Adaptive) registerMetrics() {
+	meter := otel.Meter("")
+	qps, _ := meter.Float64ObservableGauge("registry_k8s_client_qps",
+		metric.WithDescription("current QPS of the adaptive Kubernetes client rate limiter"))
+	bursts, _ := meter.Int64ObservableGauge("registry_k8s_client_burst",
+		metric.WithDescription("current burst of the adaptive Kubernetes client rate limiter"))
+	_, _ = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveFloat64(qps, float64(a.limiter.Limit()))
+		o.ObserveInt64(bursts, int64(a.limiter.Burst()))
+		return nil
+	}, qps, bursts)
+}
+
+func burst(qps float64) int {
+	return int(math.Ceil(qps * 2))
+}
+
+// roundTripper counts the requests throttled by the apiserver
+type roundTripper struct {
+	http.RoundTripper
+	throttled *atomic.Int64
+}
+
+func (r *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.RoundTripper.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		r.throttled.Add(1)
+	}
+	return resp, err
+}
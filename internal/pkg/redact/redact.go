@@ -0,0 +1,75 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redact provides the redaction of the secrets in the configuration before it is logged
+package redact
+
+import (
+	"reflect"
+)
+
+const (
+	// Tag marks the struct fields holding secrets with redact:"true"
+	Tag = "redact"
+
+	redacted = "REDACTED"
+)
+
+// Struct returns a copy of the struct pointed by v with the values of the fields tagged with redact:"true" replaced,
+// including the fields of the nested structs. The strings are replaced with REDACTED, the values of the maps with
+// string values are replaced keeping the keys, and the other values are zeroed.
+func Struct[T any](v *T) *T {
+	c := new(T)
+	value := reflect.ValueOf(c).Elem()
+	value.Set(reflect.ValueOf(v).Elem())
+	redactStruct(value)
+	return c
+}
+
+func redactStruct(value reflect.Value) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		if value.Type().Field(i).Tag.Get(Tag) == "true" {
+			redactValue(field)
+			continue
+		}
+		if field.Kind() == reflect.Struct {
+			redactStruct(field)
+		}
+	}
+}
+
+func redactValue(field reflect.Value) {
+	switch {
+	case field.Kind() == reflect.String:
+		if field.Len() > 0 {
+			field.SetString(redacted)
+		}
+	case field.Kind() == reflect.Map && field.Type().Elem().Kind() == reflect.String && !field.IsNil():
+		m := reflect.MakeMapWithSize(field.Type(), field.Len())
+		for _, key := range field.MapKeys() {
+			m.SetMapIndex(key, reflect.ValueOf(redacted).Convert(field.Type().Elem()))
+		}
+		field.Set(m)
+	default:
+		field.Set(reflect.Zero(field.Type()))
+	}
+}
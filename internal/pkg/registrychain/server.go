@@ -0,0 +1,363 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registrychain provides the registry chain with a pluggable storage backend
+package registrychain
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"google.golang.org/grpc"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	registryserver "github.com/networkservicemesh/sdk/pkg/registry"
+	registryauthorize "github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/clientconn"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/clienturl"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/connect"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/expire"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/setpayload"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/setregistrationtime"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/updatepath"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/switchcase"
+	"github.com/networkservicemesh/sdk/pkg/registry/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/interdomain"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/audit"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/connpool"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/expirepolicy"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/latency"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/liveness"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/logging"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/maxexpire"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/nameconflict"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/podevict"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/memory"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/tombstone"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/validate"
+)
+
+// The stages of the request latency: the request passed the policies or reached the storage
+const (
+	authorizeStage = "authorize"
+	storageStage   = "storage"
+)
+
+type serverOptions struct {
+	authorizeNSRegistryServer  registry.NetworkServiceRegistryServer
+	authorizeNSRegistryClient  registry.NetworkServiceRegistryClient
+	authorizeNSERegistryServer registry.NetworkServiceEndpointRegistryServer
+	authorizeNSERegistryClient registry.NetworkServiceEndpointRegistryClient
+	storage                    storage.Storage
+	defaultExpiration          time.Duration
+	maxExpiration              time.Duration
+	expirationPolicies         *expirepolicy.Store
+	livenessOptions            []liveness.Option
+	podEvictionClient          kubernetes.Interface
+	podEvictionLabel           string
+	nameConflictStrategy       nameconflict.Strategy
+	nameConflictAuditLog       *audit.Logger
+	annotator                  storage.Annotator
+	validateOptions            []validate.Option
+	proxyRegistryURL           *url.URL
+	dialOptions                []grpc.DialOption
+	clientIdleTimeout          time.Duration
+	clientHealthCheckInterval  time.Duration
+	slowRequestThreshold       time.Duration
+}
+
+// Option modifies server option value
+type Option func(o *serverOptions)
+
+// WithAuthorizeNSRegistryServer sets server authorization NetworkServiceRegistry chain element
+func WithAuthorizeNSRegistryServer(authorizeNSRegistryServer registry.NetworkServiceRegistryServer) Option {
+	if authorizeNSRegistryServer == nil {
+		panic("authorizeNSRegistryServer cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.authorizeNSRegistryServer = authorizeNSRegistryServer
+	}
+}
+
+// WithAuthorizeNSERegistryServer sets server authorization NetworkServiceEndpointRegistry chain element
+func WithAuthorizeNSERegistryServer(authorizeNSERegistryServer registry.NetworkServiceEndpointRegistryServer) Option {
+	if authorizeNSERegistryServer == nil {
+		panic("authorizeNSERegistryServer cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.authorizeNSERegistryServer = authorizeNSERegistryServer
+	}
+}
+
+// WithAuthorizeNSRegistryClient sets client authorization NetworkServiceRegistry chain element
+func WithAuthorizeNSRegistryClient(authorizeNSRegistryClient registry.NetworkServiceRegistryClient) Option {
+	if authorizeNSRegistryClient == nil {
+		panic("authorizeNSRegistryClient cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.authorizeNSRegistryClient = authorizeNSRegistryClient
+	}
+}
+
+// WithAuthorizeNSERegistryClient sets client authorization NetworkServiceEndpointRegistry chain element
+func WithAuthorizeNSERegistryClient(authorizeNSERegistryClient registry.NetworkServiceEndpointRegistryClient) Option {
+	if authorizeNSERegistryClient == nil {
+		panic("authorizeNSERegistryClient cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.authorizeNSERegistryClient = authorizeNSERegistryClient
+	}
+}
+
+// WithStorage sets the storage persisting local network services and endpoints
+func WithStorage(s storage.Storage) Option {
+	if s == nil {
+		panic("storage cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.storage = s
+	}
+}
+
+// WithDefaultExpiration sets the default expiration for endpoints
+func WithDefaultExpiration(d time.Duration) Option {
+	return func(o *serverOptions) {
+		o.defaultExpiration = d
+	}
+}
+
+// WithMaxExpiration sets the maximum expiration of the endpoints, the longer expirations requested by the endpoints are
+// shortened to it, 0 disables it
+func WithMaxExpiration(d time.Duration) Option {
+	return func(o *serverOptions) {
+		o.maxExpiration = d
+	}
+}
+
+// WithExpirationPolicies sets the expiration policies of the endpoints of the network services matched by name or by
+// label
+func WithExpirationPolicies(store *expirepolicy.Store) Option {
+	if store == nil {
+		panic("store cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.expirationPolicies = store
+	}
+}
+
+// WithLivenessProbe sets the probing of the URLs of the registered endpoints, the endpoints failing failureThreshold
+// consecutive probes are unregistered before they expire, the empty probe disables it
+func WithLivenessProbe(probe liveness.Probe, interval, timeout time.Duration, failureThreshold int) Option {
+	return func(o *serverOptions) {
+		o.livenessOptions = []liveness.Option{
+			liveness.WithProbe(probe),
+			liveness.WithInterval(interval),
+			liveness.WithTimeout(timeout),
+			liveness.WithFailureThreshold(failureThreshold),
+		}
+	}
+}
+
+// WithPodEviction unregisters the endpoints as soon as the pods registering them are deleted, the pods are named by the
+// network service label or by the SPIFFE IDs of the workloads registering the endpoints
+func WithPodEviction(client kubernetes.Interface, label string) Option {
+	return func(o *serverOptions) {
+		o.podEvictionClient = client
+		o.podEvictionLabel = label
+	}
+}
+
+// WithNameConflictStrategy sets what happens to the registrations of the endpoints with the names registered by other
+// SPIFFE IDs, the conflicts are recorded in the audit log if it is not nil
+func WithNameConflictStrategy(strategy nameconflict.Strategy, auditLog *audit.Logger) Option {
+	return func(o *serverOptions) {
+		o.nameConflictStrategy = strategy
+		o.nameConflictAuditLog = auditLog
+	}
+}
+
+// WithAnnotator sets the storage keeping the annotations of the records, e.g. the owners of the endpoints, usually the
+// backend of the storage set by WithStorage
+func WithAnnotator(annotator storage.Annotator) Option {
+	return func(o *serverOptions) {
+		o.annotator = annotator
+	}
+}
+
+// WithValidation sets the validation of the registered network services and endpoints, the invalid ones are rejected
+// with InvalidArgument
+func WithValidation(opts ...validate.Option) Option {
+	return func(o *serverOptions) {
+		o.validateOptions = opts
+	}
+}
+
+// WithProxyRegistryURL sets URL to reach the proxy registry
+func WithProxyRegistryURL(proxyRegistryURL *url.URL) Option {
+	return func(o *serverOptions) {
+		o.proxyRegistryURL = proxyRegistryURL
+	}
+}
+
+// WithDialOptions sets grpc.DialOptions for the client
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *serverOptions) {
+		o.dialOptions = dialOptions
+	}
+}
+
+// WithClientPool sets how long an unused client connection is kept open and the interval of closing the failed ones
+func WithClientPool(idleTimeout, healthCheckInterval time.Duration) Option {
+	return func(o *serverOptions) {
+		o.clientIdleTimeout = idleTimeout
+		o.clientHealthCheckInterval = healthCheckInterval
+	}
+}
+
+// WithSlowRequestThreshold sets the duration above which the requests are logged with their timing breakdown, 0
+// disables it
+func WithSlowRequestThreshold(d time.Duration) Option {
+	return func(o *serverOptions) {
+		o.slowRequestThreshold = d
+	}
+}
+
+// NewServer creates new registry server storing local network services and endpoints in the configured storage
+func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options ...Option) registryserver.Registry {
+	opts := &serverOptions{
+		authorizeNSRegistryServer:  registryauthorize.NewNetworkServiceRegistryServer(registryauthorize.Any()),
+		authorizeNSERegistryServer: registryauthorize.NewNetworkServiceEndpointRegistryServer(registryauthorize.Any()),
+		authorizeNSRegistryClient:  registryauthorize.NewNetworkServiceRegistryClient(registryauthorize.Any()),
+		authorizeNSERegistryClient: registryauthorize.NewNetworkServiceEndpointRegistryClient(registryauthorize.Any()),
+		storage:                    memory.NewStorage(),
+		defaultExpiration:          time.Minute,
+		expirationPolicies:         expirepolicy.NewStore(nil),
+		nameConflictStrategy:       nameconflict.LastWriterWins,
+		clientIdleTimeout:          5 * time.Minute,
+		clientHealthCheckInterval:  10 * time.Second,
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	// The network services and the endpoints share the connections to the proxy registry
+	pool := connpool.New(ctx,
+		connpool.WithDialOptions(opts.dialOptions...),
+		connpool.WithIdleTimeout(opts.clientIdleTimeout),
+		connpool.WithHealthCheckInterval(opts.clientHealthCheckInterval),
+	)
+
+	nseChain := chain.NewNetworkServiceEndpointRegistryServer(
+		latency.NewNetworkServiceEndpointRegistryServer(latency.WithSlowThreshold(opts.slowRequestThreshold)),
+		grpcmetadata.NewNetworkServiceEndpointRegistryServer(),
+		updatepath.NewNetworkServiceEndpointRegistryServer(tokenGenerator),
+		opts.authorizeNSERegistryServer,
+		latency.NewNetworkServiceEndpointStageServer(authorizeStage),
+		begin.NewNetworkServiceEndpointRegistryServer(),
+		metadata.NewNetworkServiceEndpointServer(),
+		switchcase.NewNetworkServiceEndpointRegistryServer(switchcase.NSEServerCase{
+			Condition: func(c context.Context, nse *registry.NetworkServiceEndpoint) bool {
+				if interdomain.Is(nse.GetName()) {
+					return true
+				}
+				for _, ns := range nse.GetNetworkServiceNames() {
+					if interdomain.Is(ns) {
+						return true
+					}
+				}
+				return false
+			},
+			Action: chain.NewNetworkServiceEndpointRegistryServer(
+				connect.NewNetworkServiceEndpointRegistryServer(
+					chain.NewNetworkServiceEndpointRegistryClient(
+						begin.NewNetworkServiceEndpointRegistryClient(),
+						clienturl.NewNetworkServiceEndpointRegistryClient(opts.proxyRegistryURL),
+						clientconn.NewNetworkServiceEndpointRegistryClient(),
+						opts.authorizeNSERegistryClient,
+						grpcmetadata.NewNetworkServiceEndpointRegistryClient(),
+						connpool.NewNetworkServiceEndpointRegistryClient(pool),
+						connect.NewNetworkServiceEndpointRegistryClient(),
+					),
+				),
+			),
+		},
+			switchcase.NSEServerCase{
+				Condition: func(c context.Context, nse *registry.NetworkServiceEndpoint) bool { return true },
+				Action: chain.NewNetworkServiceEndpointRegistryServer(
+					validate.NewNetworkServiceEndpointRegistryServer(opts.validateOptions...),
+					nameconflict.NewNetworkServiceEndpointRegistryServer(opts.nameConflictStrategy, opts.nameConflictAuditLog, opts.annotator),
+					setregistrationtime.NewNetworkServiceEndpointRegistryServer(),
+					expirepolicy.NewNetworkServiceEndpointRegistryServer(opts.expirationPolicies),
+					maxexpire.NewNetworkServiceEndpointRegistryServer(opts.maxExpiration),
+					liveness.NewNetworkServiceEndpointRegistryServer(ctx, pool, opts.livenessOptions...),
+					podevict.NewNetworkServiceEndpointRegistryServer(ctx, opts.podEvictionClient, opts.podEvictionLabel),
+					logging.NewNetworkServiceEndpointRegistryServer(logging.Expire,
+						expire.NewNetworkServiceEndpointRegistryServer(ctx, expire.WithDefaultExpiration(opts.defaultExpiration))),
+					tombstone.NewNetworkServiceEndpointRegistryServer(),
+					latency.NewNetworkServiceEndpointStageServer(storageStage),
+					opts.storage.NetworkServiceEndpointRegistryServer(),
+				),
+			},
+		),
+	)
+	nsChain := chain.NewNetworkServiceRegistryServer(
+		latency.NewNetworkServiceRegistryServer(latency.WithSlowThreshold(opts.slowRequestThreshold)),
+		grpcmetadata.NewNetworkServiceRegistryServer(),
+		updatepath.NewNetworkServiceRegistryServer(tokenGenerator),
+		opts.authorizeNSRegistryServer,
+		latency.NewNetworkServiceStageServer(authorizeStage),
+		metadata.NewNetworkServiceServer(),
+		setpayload.NewNetworkServiceRegistryServer(),
+		switchcase.NewNetworkServiceRegistryServer(
+			switchcase.NSServerCase{
+				Condition: func(c context.Context, ns *registry.NetworkService) bool {
+					return interdomain.Is(ns.GetName())
+				},
+				Action: connect.NewNetworkServiceRegistryServer(
+					chain.NewNetworkServiceRegistryClient(
+						clienturl.NewNetworkServiceRegistryClient(opts.proxyRegistryURL),
+						begin.NewNetworkServiceRegistryClient(),
+						clientconn.NewNetworkServiceRegistryClient(),
+						opts.authorizeNSRegistryClient,
+						grpcmetadata.NewNetworkServiceRegistryClient(),
+						connpool.NewNetworkServiceRegistryClient(pool),
+						connect.NewNetworkServiceRegistryClient(),
+					),
+				),
+			},
+			switchcase.NSServerCase{
+				Condition: func(c context.Context, ns *registry.NetworkService) bool {
+					return true
+				},
+				Action: chain.NewNetworkServiceRegistryServer(
+					validate.NewNetworkServiceRegistryServer(opts.validateOptions...),
+					latency.NewNetworkServiceStageServer(storageStage),
+					opts.storage.NetworkServiceRegistryServer(),
+				),
+			},
+		),
+	)
+
+	return registryserver.NewServer(nsChain, nseChain)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registrytest provides the fixtures shared by the tests of the registry chain elements and storages
+package registrytest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+)
+
+// IDContext returns the context of the request originated by the SPIFFE ID, "" stands for an unknown workload
+func IDContext(t testing.TB, id string) context.Context {
+	t.Helper()
+	if id == "" {
+		return context.Background()
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: id}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("failed to sign the token: %v", err)
+	}
+	return grpcmetadata.PathWithContext(context.Background(), &grpcmetadata.Path{
+		PathSegments: []*grpcmetadata.PathSegment{{Token: token}},
+	})
+}
+
+// PodContext returns the context of the request originated by the pod of the namespace, "" namespace stands for an
+// unknown workload
+func PodContext(t testing.TB, namespace, name string) context.Context {
+	t.Helper()
+	if namespace == "" {
+		return context.Background()
+	}
+	return IDContext(t, fmt.Sprintf("spiffe://example.org/ns/%s/pod/%s", namespace, name))
+}
+
+// BlockingNSEServer passes the registrations to the next server once they are released, failing the ones released
+// with an error
+type BlockingNSEServer struct {
+	registry.NetworkServiceEndpointRegistryServer
+	Started chan struct{}
+	Release chan error
+}
+
+// NewBlockingNSEServer creates BlockingNSEServer passing the released registrations to s
+func NewBlockingNSEServer(s registry.NetworkServiceEndpointRegistryServer) *BlockingNSEServer {
+	return &BlockingNSEServer{
+		NetworkServiceEndpointRegistryServer: s,
+		Started:                              make(chan struct{}),
+		Release:                              make(chan error),
+	}
+}
+
+// Register signals Started and waits for the registration to be released
+func (b *BlockingNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	b.Started <- struct{}{}
+	if err := <-b.Release; err != nil {
+		return nil, err
+	}
+	return b.NetworkServiceEndpointRegistryServer.Register(ctx, nse)
+}
+
+// FindNSs returns the sorted names of the network services found by the query sent with the context
+func FindNSs(ctx context.Context, t testing.TB, s registry.NetworkServiceRegistryServer, query *registry.NetworkServiceQuery) []string {
+	t.Helper()
+	ch := make(chan *registry.NetworkServiceResponse, 100)
+	if err := s.Find(query, streamchannel.NewNetworkServiceFindServer(ctx, ch)); err != nil {
+		t.Fatalf("find failed: %v", err)
+	}
+	close(ch)
+	var names []string
+	for resp := range ch {
+		names = append(names, resp.GetNetworkService().GetName())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FindNSEs returns the endpoints found by the query sent with the context
+func FindNSEs(ctx context.Context, t testing.TB, s registry.NetworkServiceEndpointRegistryServer, query *registry.NetworkServiceEndpointQuery) []*registry.NetworkServiceEndpoint {
+	t.Helper()
+	ch := make(chan *registry.NetworkServiceEndpointResponse, 100)
+	if err := s.Find(query, streamchannel.NewNetworkServiceEndpointFindServer(ctx, ch)); err != nil {
+		t.Fatalf("find failed: %v", err)
+	}
+	close(ch)
+	var nses []*registry.NetworkServiceEndpoint
+	for resp := range ch {
+		nses = append(nses, resp.GetNetworkServiceEndpoint())
+	}
+	return nses
+}
+
+// FindNSENames returns the sorted names of the endpoints found by the query sent with the context
+func FindNSENames(ctx context.Context, t testing.TB, s registry.NetworkServiceEndpointRegistryServer, query *registry.NetworkServiceEndpointQuery) []string {
+	t.Helper()
+	var names []string
+	for _, nse := range FindNSEs(ctx, t, s, query) {
+		names = append(names, nse.GetName())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WatchNSEs starts the Find watch of the query sent with the context, the returned channel with the given buffer size
+// receives its updates and the error channel the error the watch ends with
+func WatchNSEs(ctx context.Context, s registry.NetworkServiceEndpointRegistryServer, query *registry.NetworkServiceEndpointQuery, size int) (<-chan *registry.NetworkServiceEndpointResponse, <-chan error) {
+	updates := make(chan *registry.NetworkServiceEndpointResponse, size)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.Find(query, streamchannel.NewNetworkServiceEndpointFindServer(ctx, updates))
+	}()
+	return updates, errs
+}
+
+// WaitSubscribed waits for the Find watch to be subscribed to the updates, that is to receive a refresh of the probe
+// endpoint other than the one sent with the current state. refresh registers the probe endpoint and returns its name.
+func WaitSubscribed(t testing.TB, updates <-chan *registry.NetworkServiceEndpointResponse, refresh func() string) {
+	t.Helper()
+	received := false
+	Eventually(t, func() bool {
+		probe := refresh()
+		select {
+		case resp := <-updates:
+			if resp.GetNetworkServiceEndpoint().GetName() != probe {
+				return false
+			}
+			if received {
+				return true
+			}
+			received = true
+		case <-time.After(100 * time.Millisecond):
+		}
+		return false
+	}, "the watch is not subscribed")
+}
+
+// Eventually fails the test unless the condition becomes true within 5 seconds
+func Eventually(t testing.TB, condition func() bool, format string, args ...interface{}) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf(format, args...)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
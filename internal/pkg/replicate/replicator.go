@@ -0,0 +1,219 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package replicate provides mirroring of the registrations to the registries of the peer clusters, so a standby
+// cluster already knows the endpoints of the active one
+package replicate
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/connpool"
+)
+
+const (
+	// OriginLabel is the label of the replicated endpoints naming the cluster they were registered in
+	OriginLabel = "registry-k8s.networkservicemesh.io/origin"
+
+	// MetadataKey is the gRPC metadata key marking the replicated requests with the cluster they come from, the peers
+	// don't replicate them any further
+	MetadataKey = "x-registry-origin"
+
+	peerAttribute   = attribute.Key("peer")
+	resultAttribute = attribute.Key("result")
+)
+
+type options struct {
+	selector  map[string]string
+	queueSize int
+	timeout   time.Duration
+}
+
+// Option is an option pattern for the replicator
+type Option func(o *options)
+
+// WithSelector replicates only the endpoints having all the labels for one of their network services, the network
+// services have no labels and are replicated regardless
+func WithSelector(selector map[string]string) Option {
+	return func(o *options) {
+		o.selector = selector
+	}
+}
+
+// WithQueueSize sets the number of the replications waiting for a peer, the further ones are dropped until the peer
+// catches up
+func WithQueueSize(queueSize int) Option {
+	return func(o *options) {
+		o.queueSize = queueSize
+	}
+}
+
+// WithTimeout sets the timeout of a single replication to a peer
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// replication is a registration or an unregistration replicated to a peer
+type replication func(ctx context.Context, cc grpc.ClientConnInterface) error
+
+type peer struct {
+	target string
+	queue  chan replication
+}
+
+// Replicator replicates the registrations to the peer registries in the background, each peer in its own order
+type Replicator struct {
+	ctx     context.Context
+	cluster string
+	pool    *connpool.Pool
+	options *options
+	peers   []*peer
+
+	replications metric.Int64Counter
+}
+
+// New creates Replicator of the registrations of the cluster to the peers until ctx is done
+func New(ctx context.Context, cluster string, peers []url.URL, pool *connpool.Pool, opts ...Option) *Replicator {
+	o := &options{
+		queueSize: 1024,
+		timeout:   5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	replications, _ := otel.Meter("").Int64Counter("registry_replications_total",
+		metric.WithDescription("number of the registrations replicated to the peer registries by the result"))
+	r := &Replicator{
+		ctx:          ctx,
+		cluster:      cluster,
+		pool:         pool,
+		options:      o,
+		replications: replications,
+	}
+	for i := range peers {
+		p := &peer{
+			target: grpcutils.URLToTarget(&peers[i]),
+			queue:  make(chan replication, o.queueSize),
+		}
+		r.peers = append(r.peers, p)
+		go r.run(p)
+	}
+	return r
+}
+
+// replicated returns true if the request was replicated from a peer
+func replicated(ctx context.Context) bool {
+	return len(metadata.ValueFromIncomingContext(ctx, MetadataKey)) > 0
+}
+
+// matches returns true if the endpoint is registered in this cluster and selected for the replication
+func (r *Replicator) matches(nse *registry.NetworkServiceEndpoint) bool {
+	matched := len(r.options.selector) == 0
+	for _, labels := range nse.GetNetworkServiceLabels() {
+		if origin, ok := labels.GetLabels()[OriginLabel]; ok && origin != r.cluster {
+			return false
+		}
+		if !matched && containsAll(labels.GetLabels(), r.options.selector) {
+			matched = true
+		}
+	}
+	return matched
+}
+
+func containsAll(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// tag returns a copy of the endpoint labeled with the cluster it was registered in for each of its network services
+func (r *Replicator) tag(nse *registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	nse = proto.Clone(nse).(*registry.NetworkServiceEndpoint)
+	if nse.NetworkServiceLabels == nil {
+		nse.NetworkServiceLabels = make(map[string]*registry.NetworkServiceLabels)
+	}
+	for _, name := range nse.GetNetworkServiceNames() {
+		labels := nse.NetworkServiceLabels[name]
+		if labels == nil {
+			labels = new(registry.NetworkServiceLabels)
+			nse.NetworkServiceLabels[name] = labels
+		}
+		if labels.Labels == nil {
+			labels.Labels = make(map[string]string)
+		}
+		labels.Labels[OriginLabel] = r.cluster
+	}
+	return nse
+}
+
+// enqueue queues the replication for all the peers dropping it for the peers with a full queue
+func (r *Replicator) enqueue(ctx context.Context, rep replication) {
+	for _, p := range r.peers {
+		select {
+		case p.queue <- rep:
+		default:
+			log.FromContext(ctx).Warnf("dropped a replication to %s with a full queue", p.target)
+			r.replications.Add(ctx, 1, metric.WithAttributes(peerAttribute.String(p.target), resultAttribute.String("dropped")))
+		}
+	}
+}
+
+func (r *Replicator) run(p *peer) {
+	logger := log.FromContext(r.ctx).WithField("peer", p.target)
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case rep := <-p.queue:
+			result := "ok"
+			if err := r.replicate(p, rep); err != nil {
+				logger.Warnf("failed to replicate: %s", err.Error())
+				result = "failed"
+			}
+			r.replications.Add(r.ctx, 1, metric.WithAttributes(peerAttribute.String(p.target), resultAttribute.String(result)))
+		}
+	}
+}
+
+func (r *Replicator) replicate(p *peer, rep replication) error {
+	ctx, cancel := context.WithTimeout(r.ctx, r.options.timeout)
+	defer cancel()
+	cc, release, err := r.pool.Get(ctx, p.target)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return rep(metadata.AppendToOutgoingContext(ctx, MetadataKey, r.cluster), cc)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replicate
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+// NewStorage creates storage.Storage replicating the successful writes of s by r, except for the ones replicated from
+// the peers. Find queries are served by s.
+func NewStorage(s storage.Storage, r *Replicator) storage.Storage {
+	return storage.New(
+		&replicateNSServer{
+			storage:    s.NetworkServiceRegistryServer(),
+			replicator: r,
+		},
+		&replicateNSEServer{
+			storage:    s.NetworkServiceEndpointRegistryServer(),
+			replicator: r,
+		},
+	)
+}
+
+type replicateNSServer struct {
+	storage    registry.NetworkServiceRegistryServer
+	replicator *Replicator
+}
+
+func (s *replicateNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	resp, err := s.storage.Register(ctx, ns)
+	if err != nil || replicated(ctx) {
+		return resp, err
+	}
+	s.replicator.enqueue(ctx, func(ctx context.Context, cc grpc.ClientConnInterface) error {
+		_, err := registry.NewNetworkServiceRegistryClient(cc).Register(ctx, resp)
+		return err
+	})
+	return resp, nil
+}
+
+func (s *replicateNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *replicateNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	resp, err := s.storage.Unregister(ctx, ns)
+	if err != nil || replicated(ctx) {
+		return resp, err
+	}
+	s.replicator.enqueue(ctx, func(ctx context.Context, cc grpc.ClientConnInterface) error {
+		_, err := registry.NewNetworkServiceRegistryClient(cc).Unregister(ctx, ns)
+		return err
+	})
+	return resp, nil
+}
+
+type replicateNSEServer struct {
+	storage    registry.NetworkServiceEndpointRegistryServer
+	replicator *Replicator
+}
+
+func (s *replicateNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	resp, err := s.storage.Register(ctx, nse)
+	if err != nil || replicated(ctx) || !s.replicator.matches(resp) {
+		return resp, err
+	}
+	tagged := s.replicator.tag(resp)
+	s.replicator.enqueue(ctx, func(ctx context.Context, cc grpc.ClientConnInterface) error {
+		_, err := registry.NewNetworkServiceEndpointRegistryClient(cc).Register(ctx, tagged)
+		return err
+	})
+	return resp, nil
+}
+
+func (s *replicateNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *replicateNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	resp, err := s.storage.Unregister(ctx, nse)
+	if err != nil || replicated(ctx) || !s.replicator.matches(nse) {
+		return resp, err
+	}
+	tagged := s.replicator.tag(nse)
+	s.replicator.enqueue(ctx, func(ctx context.Context, cc grpc.ClientConnInterface) error {
+		_, err := registry.NewNetworkServiceEndpointRegistryClient(cc).Unregister(ctx, tagged)
+		return err
+	})
+	return resp, nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package requestid provides the correlation of the logs and the traces of a registry request by its ID
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// MetadataKey is the gRPC metadata key carrying the request ID
+	MetadataKey = "x-request-id"
+
+	// LogField is the log field carrying the request ID
+	LogField = "requestID"
+
+	spanKey = attribute.Key("request.id")
+
+	// maxLength limits the size of the IDs accepted from the clients, as they end up in every log line of the request
+	maxLength = 128
+)
+
+type requestIDKey struct{}
+
+// FromContext returns the ID of the request
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// ServerOptions returns the interceptors taking the request ID from the x-request-id metadata or generating a new one.
+// The ID is added to the logger and to the span of the request, forwarded to the outgoing requests made on its behalf
+// and returned to the client in the response header.
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			ctx = withRequestID(ctx)
+			id, _ := FromContext(ctx)
+			_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataKey, id))
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx := withRequestID(ss.Context())
+			id, _ := FromContext(ctx)
+			_ = ss.SetHeader(metadata.Pairs(MetadataKey, id))
+			return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		}),
+	}
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	var id string
+	if values := metadata.ValueFromIncomingContext(ctx, MetadataKey); len(values) > 0 && values[0] != "" && len(values[0]) <= maxLength {
+		id = values[0]
+	} else {
+		id = uuid.NewString()
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(spanKey.String(id))
+	ctx = log.WithLog(ctx, log.FromContext(ctx).WithField(LogField, id))
+	ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, id)
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// serverStream replaces the context of the stream with the one carrying the request ID
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package requestlog provides gRPC interceptors logging the registry requests with sampling
+package requestlog
+
+import (
+	"context"
+	"math/rand"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/errcode"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/identity"
+)
+
+type options struct {
+	successRate float64
+	errorRate   float64
+	sampling    map[string]int
+	samplers    map[string]*sampler
+}
+
+// sampler passes every n-th request
+type sampler struct {
+	n     uint64
+	count atomic.Uint64
+}
+
+func (s *sampler) sample() bool {
+	return (s.count.Add(1)-1)%s.n == 0
+}
+
+// Option is an option pattern for the request logging
+type Option func(o *options)
+
+// WithSuccessRate sets the fraction of the successful requests that are logged
+func WithSuccessRate(rate float64) Option {
+	return func(o *options) {
+		o.successRate = rate
+	}
+}
+
+// WithErrorRate sets the fraction of the failed requests that are logged
+func WithErrorRate(rate float64) Option {
+	return func(o *options) {
+		o.errorRate = rate
+	}
+}
+
+// WithSampling logs only every n-th successful request of the methods, e.g. {"find": 100}, instead of the success rate.
+// The methods are matched by their lowercase name regardless of the service, the failed requests are not affected.
+func WithSampling(sampling map[string]int) Option {
+	return func(o *options) {
+		o.sampling = sampling
+	}
+}
+
+// ServerOptions returns the interceptors logging the method, the peer identity, the resource name, the duration and
+// the status of the sampled requests. The streams are logged once they are finished.
+func ServerOptions(opts ...Option) []grpc.ServerOption {
+	o := &options{
+		errorRate: 1,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	o.samplers = make(map[string]*sampler)
+	for method, n := range o.sampling {
+		if n > 0 {
+			o.samplers[strings.ToLower(method)] = &sampler{n: uint64(n)}
+		}
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			start := time.Now()
+			resp, err := handler(ctx, req)
+			o.log(ctx, info.FullMethod, resourceName(req), start, err)
+			return resp, err
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			start := time.Now()
+			stream := &recvServerStream{ServerStream: ss}
+			err := handler(srv, stream)
+			o.log(ss.Context(), info.FullMethod, resourceName(stream.first), start, err)
+			return err
+		}),
+	}
+}
+
+func (o *options) log(ctx context.Context, method, resource string, start time.Time, err error) {
+	if !o.sampled(method, err) {
+		return
+	}
+
+	logger := log.FromContext(ctx).
+		WithField("method", method).
+		WithField("resource", resource).
+		WithField("duration", time.Since(start)).
+		WithField("code", status.Code(err).String())
+	if id, ok := identity.PeerIDFromContext(ctx); ok {
+		logger = logger.WithField("peer", id.String())
+	}
+	if err != nil {
+		logger.WithField(errcode.LogField, string(errcode.Of(err))).Warnf("request failed: %v", err)
+		return
+	}
+	logger.Info("request completed")
+}
+
+// sampled decides whether the request is logged, the successful requests of the sampled methods are counted, so
+// exactly every n-th one is logged
+func (o *options) sampled(method string, err error) bool {
+	if err != nil {
+		return sample(o.errorRate)
+	}
+	if s, ok := o.samplers[strings.ToLower(path.Base(method))]; ok {
+		return s.sample()
+	}
+	return sample(o.successRate)
+}
+
+func sample(rate float64) bool {
+	return rate >= 1 || (rate > 0 && rand.Float64() < rate) // #nosec G404 sampling does not need a secure random
+}
+
+// resourceName returns the name of the record the request refers to
+func resourceName(req interface{}) string {
+	switch r := req.(type) {
+	case *registry.NetworkServiceEndpoint:
+		return r.GetName()
+	case *registry.NetworkService:
+		return r.GetName()
+	case *registry.NetworkServiceEndpointQuery:
+		return r.GetNetworkServiceEndpoint().GetName()
+	case *registry.NetworkServiceQuery:
+		return r.GetNetworkService().GetName()
+	default:
+		return ""
+	}
+}
+
+// recvServerStream remembers the first message received on the stream, the Find query
+type recvServerStream struct {
+	grpc.ServerStream
+	first interface{}
+}
+
+func (s *recvServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.first == nil {
+		s.first = m
+	}
+	return nil
+}
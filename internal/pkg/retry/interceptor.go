@@ -0,0 +1,109 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry provides retrying of the failed registry client calls
+package retry
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Codes is a set of the gRPC status codes
+type Codes []codes.Code
+
+// Decode implements envconfig.Decoder, the codes are comma separated names, e.g. UNAVAILABLE,RESOURCE_EXHAUSTED
+func (c *Codes) Decode(value string) error {
+	var decoded Codes
+	for _, name := range strings.Split(value, ",") {
+		var code codes.Code
+		if err := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(strings.TrimSpace(name))))); err != nil {
+			return errors.Errorf("unknown status code %s, expected a name like UNAVAILABLE", name)
+		}
+		decoded = append(decoded, code)
+	}
+	*c = decoded
+	return nil
+}
+
+func (c Codes) contain(code codes.Code) bool {
+	for _, retryable := range c {
+		if retryable == code {
+			return true
+		}
+	}
+	return false
+}
+
+// DialOptions returns the interceptors attempting the client calls failing with the retryable codes up to
+// maxAttempts times, the backoff between the attempts starts at backoff and doubles with each retry. Only opening a
+// stream is retried, the stream itself is not, as the messages already received can't be taken back. The calls are
+// not retried past their deadline.
+func DialOptions(retryable Codes, maxAttempts int, backoff time.Duration) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return do(ctx, method, retryable, maxAttempts, backoff, func() error {
+				return invoker(ctx, method, req, reply, cc, opts...)
+			})
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			var stream grpc.ClientStream
+			err := do(ctx, method, retryable, maxAttempts, backoff, func() error {
+				var err error
+				stream, err = streamer(ctx, desc, cc, method, opts...)
+				return err
+			})
+			return stream, err
+		}),
+	}
+}
+
+func do(ctx context.Context, method string, retryable Codes, maxAttempts int, backoff time.Duration, call func() error) error {
+	delays := wait.Backoff{
+		Steps:    maxAttempts,
+		Duration: backoff,
+		Factor:   2,
+		Jitter:   0.1,
+	}
+	for attempt := 1; ; attempt++ {
+		err := call()
+		if err == nil || attempt >= maxAttempts || !retryable.contain(status.Code(err)) {
+			return err
+		}
+		delay := delays.Step()
+		log.FromContext(ctx).Debugf("%s failed, retrying in %v: %v", method, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rotate provides a file rotated by size and age for the logs written without a log shipper
+package rotate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type options struct {
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+}
+
+// Option is an option pattern for the rotated file
+type Option func(o *options)
+
+// WithMaxSize rotates the file before a write would make it exceed the size, 0 disables the size based rotation
+func WithMaxSize(maxSize int64) Option {
+	return func(o *options) {
+		o.maxSize = maxSize
+	}
+}
+
+// WithMaxAge rotates the file once it has been written to for longer than the age, 0 disables the age based rotation
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(o *options) {
+		o.maxAge = maxAge
+	}
+}
+
+// WithMaxBackups sets the number of the rotated files kept next to the current one
+func WithMaxBackups(maxBackups int) Option {
+	return func(o *options) {
+		o.maxBackups = maxBackups
+	}
+}
+
+// File is a file rotated once it reaches the maximum size or age, the rotated files are named <path>.1 for the newest
+// up to <path>.<max backups> for the oldest
+type File struct {
+	path string
+	opts *options
+
+	mu     sync.Mutex
+	f      *os.File
+	size   int64
+	opened time.Time
+}
+
+// Open opens the file at the path for appending, creating it if it does not exist
+func Open(path string, opts ...Option) (*File, error) {
+	o := &options{
+		maxBackups: 5,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	r := &File{
+		path: filepath.Clean(path),
+		opts: o,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *File) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", r.path)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return errors.Wrapf(err, "failed to stat %s", r.path)
+	}
+	r.f, r.size, r.opened = f, info.Size(), time.Now()
+	return nil
+}
+
+// Write writes p to the file, rotating it first if it reached the maximum size or age
+func (r *File) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.size > 0 && r.expired(len(p)) {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, errors.Wrapf(err, "failed to write %s", r.path)
+}
+
+func (r *File) expired(n int) bool {
+	if r.opts.maxSize > 0 && r.size+int64(n) > r.opts.maxSize {
+		return true
+	}
+	return r.opts.maxAge > 0 && time.Since(r.opened) > r.opts.maxAge
+}
+
+// rotate shifts the rotated files dropping the oldest one and starts a new file. The current file is reopened even if
+// the shifting fails, so the rotation is retried with the next write.
+func (r *File) rotate() error {
+	if err := r.f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close %s", r.path)
+	}
+	err := r.shift()
+	if openErr := r.open(); openErr != nil {
+		return openErr
+	}
+	return err
+}
+
+func (r *File) shift() error {
+	if r.opts.maxBackups > 0 {
+		for i := r.opts.maxBackups - 1; i > 0; i-- {
+			if err := os.Rename(r.backup(i), r.backup(i+1)); err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "failed to rotate %s", r.backup(i))
+			}
+		}
+		if err := os.Rename(r.path, r.backup(1)); err != nil {
+			return errors.Wrapf(err, "failed to rotate %s", r.path)
+		}
+	} else if err := os.Remove(r.path); err != nil {
+		return errors.Wrapf(err, "failed to remove %s", r.path)
+	}
+	return nil
+}
+
+func (r *File) backup(i int) string {
+	return fmt.Sprintf("%s.%d", r.path, i)
+}
+
+// Close closes the file
+func (r *File) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.f.Close()
+}
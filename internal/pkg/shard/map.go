@@ -0,0 +1,81 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shard
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	listerscorev1 "k8s.io/client-go/listers/core/v1"
+)
+
+// Map is the shard map advertised in a ConfigMap, its data maps the shard indexes to the identities of the replicas
+// owning them
+type Map struct {
+	namespace string
+	name      string
+	lister    listerscorev1.ConfigMapLister
+}
+
+// NewMap advertises identity as the owner of the shard index in the ConfigMap and watches it until ctx is done. Each
+// replica applies only its own key with its own field manager, so the replicas don't overwrite each other. The key is
+// kept once the replica stops, its replacement owning the same shard overwrites it.
+func NewMap(ctx context.Context, client kubernetes.Interface, namespace, name string, index int, identity string) (*Map, error) {
+	configMaps := client.CoreV1().ConfigMaps(namespace)
+	fieldManager := "registry-k8s-shard-" + strconv.Itoa(index)
+	if _, err := configMaps.Apply(ctx,
+		applycorev1.ConfigMap(name, namespace).WithData(map[string]string{strconv.Itoa(index): identity}),
+		metav1.ApplyOptions{FieldManager: fieldManager, Force: true}); err != nil {
+		return nil, errors.Wrapf(err, "failed to advertise the shard %d in the ConfigMap %s", index, name)
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(client, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}))
+	m := &Map{
+		namespace: namespace,
+		name:      name,
+		lister:    factory.Core().V1().ConfigMaps().Lister(),
+	}
+	factory.Start(ctx.Done())
+	for informerType, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return nil, errors.Errorf("failed to sync the informer cache for %v", informerType)
+		}
+	}
+
+	return m, nil
+}
+
+// Owner returns the identity of the replica owning the shard or the empty string if no replica advertises it
+func (m *Map) Owner(shard int) string {
+	configMap, err := m.lister.ConfigMaps(m.namespace).Get(m.name)
+	if err != nil {
+		return ""
+	}
+	return configMap.Data[strconv.Itoa(shard)]
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shard provides sharding of the registrations by the network service name between the registry replicas
+package shard
+
+import (
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// Of returns the shard owning the registrations of the network service
+func Of(networkService string, count int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(networkService))
+	return int(h.Sum32() % uint32(count))
+}
+
+// OfEndpoint returns the shard owning the endpoint, the endpoints are owned by the shard of their first network
+// service, the endpoints without network services by the shard of their name
+func OfEndpoint(nse *registry.NetworkServiceEndpoint, count int) int {
+	if names := nse.GetNetworkServiceNames(); len(names) > 0 {
+		return Of(names[0], count)
+	}
+	return Of(nse.GetName(), count)
+}
+
+// IndexFromHostname returns the ordinal suffix of the hostname, e.g. 2 for registry-k8s-2 of a StatefulSet
+func IndexFromHostname() (int, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get the hostname")
+	}
+	index, err := strconv.Atoi(hostname[strings.LastIndex(hostname, "-")+1:])
+	if err != nil {
+		return 0, errors.Errorf("hostname %s has no ordinal suffix", hostname)
+	}
+	return index, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shard
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/errcode"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+// NewStorage creates storage.Storage serving only the writes of the shard index out of count shards, the writes of the
+// other shards are rejected with codes.Unavailable naming the owner of their shard from the map. Find queries are
+// served by s, so every replica answers them for all the shards.
+func NewStorage(s storage.Storage, index, count int, m *Map) storage.Storage {
+	o := &owner{
+		index: index,
+		count: count,
+		m:     m,
+	}
+	return storage.New(
+		&shardNSServer{
+			storage: s.NetworkServiceRegistryServer(),
+			owner:   o,
+		},
+		&shardNSEServer{
+			storage: s.NetworkServiceEndpointRegistryServer(),
+			owner:   o,
+		},
+	)
+}
+
+type owner struct {
+	index int
+	count int
+	m     *Map
+}
+
+// check returns an error unless the shard is owned by this replica
+func (o *owner) check(shard int) error {
+	if shard == o.index {
+		return nil
+	}
+	return errcode.Wrap(errcode.WrongShard,
+		status.Errorf(codes.Unavailable, "the registration belongs to the shard %d owned by %q", shard, o.m.Owner(shard)))
+}
+
+type shardNSServer struct {
+	storage registry.NetworkServiceRegistryServer
+	owner   *owner
+}
+
+func (s *shardNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	if err := s.owner.check(Of(ns.GetName(), s.owner.count)); err != nil {
+		return nil, err
+	}
+	return s.storage.Register(ctx, ns)
+}
+
+func (s *shardNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *shardNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	if err := s.owner.check(Of(ns.GetName(), s.owner.count)); err != nil {
+		return nil, err
+	}
+	return s.storage.Unregister(ctx, ns)
+}
+
+type shardNSEServer struct {
+	storage registry.NetworkServiceEndpointRegistryServer
+	owner   *owner
+}
+
+func (s *shardNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if err := s.owner.check(OfEndpoint(nse, s.owner.count)); err != nil {
+		return nil, err
+	}
+	return s.storage.Register(ctx, nse)
+}
+
+func (s *shardNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *shardNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	if err := s.owner.check(OfEndpoint(nse, s.owner.count)); err != nil {
+		return nil, err
+	}
+	return s.storage.Unregister(ctx, nse)
+}
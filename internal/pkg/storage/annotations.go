@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"maps"
+)
+
+type annotationsKey struct{}
+
+// WithAnnotations returns ctx carrying the annotations kept by the storage along with the registered record, they are
+// added to the annotations ctx already carries
+func WithAnnotations(ctx context.Context, annotations map[string]string) context.Context {
+	merged := maps.Clone(AnnotationsFromContext(ctx))
+	if merged == nil {
+		merged = make(map[string]string, len(annotations))
+	}
+	maps.Copy(merged, annotations)
+	return context.WithValue(ctx, annotationsKey{}, merged)
+}
+
+// AnnotationsFromContext returns the annotations kept by the storage along with the registered record, they must not
+// be modified
+func AnnotationsFromContext(ctx context.Context) map[string]string {
+	annotations, _ := ctx.Value(annotationsKey{}).(map[string]string)
+	return annotations
+}
+
+// Annotator is implemented by the storages keeping the annotations of the registrations along with the records, so
+// the chain elements can keep their state about the records, e.g. their owners, in the storage shared by the replicas
+// and surviving the restarts. The annotations are not sent to the clients.
+type Annotator interface {
+	// NSAnnotations returns the annotations of the stored network service, nil if it is not stored
+	NSAnnotations(ctx context.Context, name string) (map[string]string, error)
+	// NSEAnnotations returns the annotations of the stored endpoint, nil if it is not stored
+	NSEAnnotations(ctx context.Context, name string) (map[string]string, error)
+}
+
+// annotatedStorage is a wrapping storage keeping the Annotator of the wrapped one
+type annotatedStorage struct {
+	Storage
+	Annotator
+}
+
+// KeepAnnotator returns wrapper implementing the Annotator of the wrapped storage s if s implements one, so wrapping
+// s doesn't hide its annotations
+func KeepAnnotator(wrapper, s Storage) Storage {
+	annotator, ok := s.(Annotator)
+	if !ok {
+		return wrapper
+	}
+	return &annotatedStorage{Storage: wrapper, Annotator: annotator}
+}
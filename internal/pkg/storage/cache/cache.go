@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+type entry[Q, R proto.Message] struct {
+	key       string
+	query     Q
+	responses []R
+	names     map[string]struct{}
+	expiresAt time.Time
+}
+
+// read is a storage read shared by the concurrent misses of the same query
+type read[R proto.Message] struct {
+	generation uint64
+	done       chan struct{}
+	responses  []R
+	err        error
+}
+
+// findCache is a TTL and size bounded LRU cache of Find results keyed by the query
+type findCache[Q, R proto.Message] struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List
+	reads      map[string]*read[R]
+	generation uint64
+}
+
+func newFindCache[Q, R proto.Message](ttl time.Duration, maxEntries int) *findCache[Q, R] {
+	return &findCache[Q, R]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		reads:      make(map[string]*read[R]),
+	}
+}
+
+func queryKey(query proto.Message) (string, bool) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(query)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// load returns the cached responses for the key or the current generation to be passed to store on a miss
+func (c *findCache[Q, R]) load(key string) ([]R, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, c.generation, false
+	}
+	e := element.Value.(*entry[Q, R])
+	if time.Now().After(e.expiresAt) {
+		c.remove(element)
+		return nil, c.generation, false
+	}
+	c.lru.MoveToFront(element)
+	return cloneAll(e.responses), c.generation, true
+}
+
+// fill reads and caches the responses on a miss. The concurrent misses of the same query since the last invalidation
+// share a single read of the storage, so the clients polling for the same query cause one read per cache expiration.
+func (c *findCache[Q, R]) fill(ctx context.Context, generation uint64, key string, query Q, readStorage func() ([]R, map[string]struct{}, error)) ([]R, error) {
+	c.mu.Lock()
+	if r, ok := c.reads[key]; ok && r.generation == c.generation {
+		c.mu.Unlock()
+		select {
+		case <-r.done:
+			return cloneAll(r.responses), r.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	r := &read[R]{
+		generation: generation,
+		done:       make(chan struct{}),
+	}
+	c.reads[key] = r
+	c.mu.Unlock()
+
+	responses, names, err := readStorage()
+	r.responses, r.err = responses, err
+	close(r.done)
+
+	c.mu.Lock()
+	if c.reads[key] == r {
+		delete(c.reads, key)
+	}
+	c.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	c.store(generation, key, query, responses, names)
+	return cloneAll(responses), nil
+}
+
+// store caches the responses of a copy of the query unless an invalidation happened since the generation was loaded
+func (c *findCache[Q, R]) store(generation uint64, key string, query Q, responses []R, names map[string]struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	c.entries[key] = c.lru.PushFront(&entry[Q, R]{
+		key:       key,
+		query:     proto.Clone(query).(Q),
+		responses: responses,
+		names:     names,
+		expiresAt: time.Now().Add(c.ttl),
+	})
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+// invalidate drops the entries containing the record with the given name and the entries whose query matches the record
+func (c *findCache[Q, R]) invalidate(name string, matches func(query Q) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for element := c.lru.Front(); element != nil; {
+		e := element.Value.(*entry[Q, R])
+		current := element
+		element = element.Next()
+		if _, ok := e.names[name]; ok || matches(e.query) {
+			c.remove(current)
+		}
+	}
+}
+
+func cloneAll[R proto.Message](responses []R) []R {
+	clones := make([]R, 0, len(responses))
+	for _, resp := range responses {
+		clones = append(clones, proto.Clone(resp).(R))
+	}
+	return clones
+}
+
+func (c *findCache[Q, R]) remove(element *list.Element) {
+	delete(c.entries, element.Value.(*entry[Q, R]).key)
+	c.lru.Remove(element)
+}
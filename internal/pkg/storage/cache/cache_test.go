@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/memory"
+)
+
+type nseCache = findCache[*registry.NetworkServiceEndpointQuery, *registry.NetworkServiceEndpointResponse]
+
+func nseResponse(name string) *registry.NetworkServiceEndpointResponse {
+	return &registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: name}}
+}
+
+func TestFindCache(t *testing.T) {
+	query := &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{}}
+	invalidate := func(name string, matches bool) func(c *nseCache) {
+		return func(c *nseCache) {
+			c.invalidate(name, func(*registry.NetworkServiceEndpointQuery) bool { return matches })
+		}
+	}
+	for _, tc := range []struct {
+		name string
+		ttl  time.Duration
+		// before is called between loading the generation and storing the responses, after once they are stored
+		before, after func(c *nseCache)
+		wantHit       bool
+	}{
+		{name: "hit", ttl: time.Minute, wantHit: true},
+		{name: "expired", ttl: -time.Second, wantHit: false},
+		{name: "stale read", ttl: time.Minute, before: invalidate("nse-2", false), wantHit: false},
+		{name: "invalidated by the name", ttl: time.Minute, after: invalidate("nse-1", false), wantHit: false},
+		{name: "invalidated by the query", ttl: time.Minute, after: invalidate("nse-2", true), wantHit: false},
+		{name: "unrelated invalidation", ttl: time.Minute, after: invalidate("nse-2", false), wantHit: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newFindCache[*registry.NetworkServiceEndpointQuery, *registry.NetworkServiceEndpointResponse](tc.ttl, 0)
+			_, generation, _ := c.load("key")
+			if tc.before != nil {
+				tc.before(c)
+			}
+			c.store(generation, "key", query, []*registry.NetworkServiceEndpointResponse{nseResponse("nse-1")}, map[string]struct{}{"nse-1": {}})
+			if tc.after != nil {
+				tc.after(c)
+			}
+
+			responses, _, ok := c.load("key")
+			if ok != tc.wantHit {
+				t.Fatalf("hit = %v, want %v", ok, tc.wantHit)
+			}
+			if ok && (len(responses) != 1 || responses[0].GetNetworkServiceEndpoint().GetName() != "nse-1") {
+				t.Errorf("unexpected responses %v", responses)
+			}
+		})
+	}
+}
+
+func TestFindCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newFindCache[*registry.NetworkServiceEndpointQuery, *registry.NetworkServiceEndpointResponse](time.Minute, 2)
+	query := &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{}}
+	for _, key := range []string{"a", "b"} {
+		_, generation, _ := c.load(key)
+		c.store(generation, key, query, nil, nil)
+	}
+	// a becomes the most recently used one, so c evicts b
+	c.load("a")
+	_, generation, _ := c.load("c")
+	c.store(generation, "c", query, nil, nil)
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, _, ok := c.load(key); ok != want {
+			t.Errorf("%s cached = %v, want %v", key, ok, want)
+		}
+	}
+}
+
+// countingNSEServer sends one endpoint to every Find and counts the Finds
+type countingNSEServer struct {
+	finds atomic.Int32
+}
+
+func (s *countingNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *countingNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	s.finds.Add(1)
+	if err := server.Send(nseResponse("nse-1")); err != nil {
+		return err
+	}
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *countingNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+func findNSEs(t *testing.T, s registry.NetworkServiceEndpointRegistryServer, query *registry.NetworkServiceEndpointQuery) []string {
+	t.Helper()
+	ch := make(chan *registry.NetworkServiceEndpointResponse, 10)
+	if err := s.Find(query, streamchannel.NewNetworkServiceEndpointFindServer(context.Background(), ch)); err != nil {
+		t.Errorf("find failed: %v", err)
+		return nil
+	}
+	close(ch)
+	var names []string
+	for resp := range ch {
+		names = append(names, resp.GetNetworkServiceEndpoint().GetName())
+	}
+	return names
+}
+
+func TestConcurrentFindsReadOnce(t *testing.T) {
+	counting := new(countingNSEServer)
+	s := NewStorage(storage.New(memory.NewStorage().NetworkServiceRegistryServer(), counting), WithTTL(time.Minute))
+	query := &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{}}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if names := findNSEs(t, s.NetworkServiceEndpointRegistryServer(), query); len(names) != 1 {
+				t.Errorf("found %v, want nse-1", names)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if finds := counting.finds.Load(); finds != 1 {
+		t.Errorf("the storage is read %d times, want once", finds)
+	}
+}
+
+func TestRegisterInvalidatesMatchingQueries(t *testing.T) {
+	s := NewStorage(memory.NewStorage(), WithTTL(time.Minute))
+	server := s.NetworkServiceEndpointRegistryServer()
+	query := &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+		NetworkServiceNames: []string{"ns-1"},
+	}}
+
+	if names := findNSEs(t, server, query); len(names) != 0 {
+		t.Fatalf("found %v in the empty storage", names)
+	}
+	if _, err := server.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+	}); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if names := findNSEs(t, server, query); len(names) != 1 || names[0] != "nse-1" {
+		t.Errorf("found %v after the registration, want nse-1", names)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+)
+
+type cacheNSServer struct {
+	storage registry.NetworkServiceRegistryServer
+	reader  registry.NetworkServiceRegistryServer
+	cache   *findCache[*registry.NetworkServiceQuery, *registry.NetworkServiceResponse]
+}
+
+func (s *cacheNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	defer s.invalidate(ns.Clone())
+	return s.storage.Register(ctx, ns)
+}
+
+func (s *cacheNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	if query.GetWatch() {
+		return s.storage.Find(query, server)
+	}
+	key, ok := queryKey(query)
+	if !ok {
+		return s.storage.Find(query, server)
+	}
+
+	responses, generation, ok := s.cache.load(key)
+	if !ok {
+		var err error
+		responses, err = s.cache.fill(server.Context(), generation, key, query, func() ([]*registry.NetworkServiceResponse, map[string]struct{}, error) {
+			return s.read(server.Context(), query)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, resp := range responses {
+		if err := server.Send(resp); err != nil {
+			return errors.Wrapf(err, "NetworkServiceRegistry find server failed to send a response %s", resp.String())
+		}
+	}
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *cacheNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	defer s.invalidate(ns.Clone())
+	return s.storage.Unregister(ctx, ns)
+}
+
+// read reads the responses from the storage only, the rest of the chain is called by each Find sharing the read
+func (s *cacheNSServer) read(ctx context.Context, query *registry.NetworkServiceQuery) ([]*registry.NetworkServiceResponse, map[string]struct{}, error) {
+	recorder := &nsFindServer{
+		ctx:   context.WithoutCancel(ctx),
+		names: make(map[string]struct{}),
+	}
+	if err := s.reader.Find(proto.Clone(query).(*registry.NetworkServiceQuery), recorder); err != nil {
+		return nil, nil, err
+	}
+	return recorder.responses, recorder.names, nil
+}
+
+func (s *cacheNSServer) invalidate(ns *registry.NetworkService) {
+	s.cache.invalidate(ns.GetName(), func(query *registry.NetworkServiceQuery) bool {
+		return matchutils.MatchNetworkServices(query.GetNetworkService(), ns)
+	})
+}
+
+// nsFindServer records the responses of the storage for caching
+type nsFindServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*registry.NetworkServiceResponse
+	names     map[string]struct{}
+}
+
+func (s *nsFindServer) Send(resp *registry.NetworkServiceResponse) error {
+	s.responses = append(s.responses, resp.Clone())
+	s.names[resp.GetNetworkService().GetName()] = struct{}{}
+	return nil
+}
+
+func (s *nsFindServer) Context() context.Context {
+	return s.ctx
+}
+
+// tailNSServer terminates the read of the storage so it never calls into the rest of the registry chain
+type tailNSServer struct{}
+
+func (t *tailNSServer) Register(_ context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	return ns, nil
+}
+
+func (t *tailNSServer) Find(_ *registry.NetworkServiceQuery, _ registry.NetworkServiceRegistry_FindServer) error {
+	return nil
+}
+
+func (t *tailNSServer) Unregister(_ context.Context, _ *registry.NetworkService) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
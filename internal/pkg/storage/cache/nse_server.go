@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+)
+
+type cacheNSEServer struct {
+	storage registry.NetworkServiceEndpointRegistryServer
+	reader  registry.NetworkServiceEndpointRegistryServer
+	cache   *findCache[*registry.NetworkServiceEndpointQuery, *registry.NetworkServiceEndpointResponse]
+}
+
+func (s *cacheNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	defer s.invalidate(nse.Clone())
+	return s.storage.Register(ctx, nse)
+}
+
+func (s *cacheNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if query.GetWatch() {
+		return s.storage.Find(query, server)
+	}
+	key, ok := queryKey(query)
+	if !ok {
+		return s.storage.Find(query, server)
+	}
+
+	responses, generation, ok := s.cache.load(key)
+	if !ok {
+		var err error
+		responses, err = s.cache.fill(server.Context(), generation, key, query, func() ([]*registry.NetworkServiceEndpointResponse, map[string]struct{}, error) {
+			return s.read(server.Context(), query)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, resp := range responses {
+		if expirationTime := resp.GetNetworkServiceEndpoint().GetExpirationTime(); expirationTime != nil && expirationTime.AsTime().Before(time.Now()) {
+			continue
+		}
+		if err := server.Send(resp); err != nil {
+			return errors.Wrapf(err, "NetworkServiceEndpointRegistry find server failed to send a response %s", resp.String())
+		}
+	}
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *cacheNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	defer s.invalidate(nse.Clone())
+	return s.storage.Unregister(ctx, nse)
+}
+
+// read reads the responses from the storage only, the rest of the chain is called by each Find sharing the read
+func (s *cacheNSEServer) read(ctx context.Context, query *registry.NetworkServiceEndpointQuery) ([]*registry.NetworkServiceEndpointResponse, map[string]struct{}, error) {
+	recorder := &nseFindServer{
+		ctx:   context.WithoutCancel(ctx),
+		names: make(map[string]struct{}),
+	}
+	if err := s.reader.Find(proto.Clone(query).(*registry.NetworkServiceEndpointQuery), recorder); err != nil {
+		return nil, nil, err
+	}
+	return recorder.responses, recorder.names, nil
+}
+
+func (s *cacheNSEServer) invalidate(nse *registry.NetworkServiceEndpoint) {
+	s.cache.invalidate(nse.GetName(), func(query *registry.NetworkServiceEndpointQuery) bool {
+		return matchutils.MatchNetworkServiceEndpoints(query.GetNetworkServiceEndpoint(), nse)
+	})
+}
+
+// nseFindServer records the responses of the storage for caching
+type nseFindServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*registry.NetworkServiceEndpointResponse
+	names     map[string]struct{}
+}
+
+func (s *nseFindServer) Send(resp *registry.NetworkServiceEndpointResponse) error {
+	s.responses = append(s.responses, resp.Clone())
+	s.names[resp.GetNetworkServiceEndpoint().GetName()] = struct{}{}
+	return nil
+}
+
+func (s *nseFindServer) Context() context.Context {
+	return s.ctx
+}
+
+// tailNSEServer terminates the read of the storage so it never calls into the rest of the registry chain
+type tailNSEServer struct{}
+
+func (t *tailNSEServer) Register(_ context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return nse, nil
+}
+
+func (t *tailNSEServer) Find(_ *registry.NetworkServiceEndpointQuery, _ registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return nil
+}
+
+func (t *tailNSEServer) Unregister(_ context.Context, _ *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
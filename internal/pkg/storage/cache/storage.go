@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache provides read-through caching of Find results in front of a storage
+package cache
+
+import (
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+type options struct {
+	ttl        time.Duration
+	maxEntries int
+}
+
+// Option is an option pattern for the cache storage
+type Option func(o *options)
+
+// WithTTL sets how long Find results are served from the cache
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// WithMaxEntries sets the maximum number of cached queries per record kind, 0 means unbounded
+func WithMaxEntries(maxEntries int) Option {
+	return func(o *options) {
+		o.maxEntries = maxEntries
+	}
+}
+
+// NewStorage creates storage.Storage serving non-watch Find queries from a cache in front of s. The cache entries
+// affected by a Register or Unregister passing through the storage are invalidated immediately, other changes of
+// the underlying storage become visible once the entries expire. The concurrent misses of the same query share a
+// single read of s.
+func NewStorage(s storage.Storage, opts ...Option) storage.Storage {
+	o := &options{
+		ttl:        time.Second,
+		maxEntries: 1000,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return storage.New(
+		&cacheNSServer{
+			storage: s.NetworkServiceRegistryServer(),
+			reader:  chain.NewNetworkServiceRegistryServer(s.NetworkServiceRegistryServer(), &tailNSServer{}),
+			cache:   newFindCache[*registry.NetworkServiceQuery, *registry.NetworkServiceResponse](o.ttl, o.maxEntries),
+		},
+		&cacheNSEServer{
+			storage: s.NetworkServiceEndpointRegistryServer(),
+			reader:  chain.NewNetworkServiceEndpointRegistryServer(s.NetworkServiceEndpointRegistryServer(), &tailNSEServer{}),
+			cache:   newFindCache[*registry.NetworkServiceEndpointQuery, *registry.NetworkServiceEndpointResponse](o.ttl, o.maxEntries),
+		},
+	)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package churn
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/events"
+)
+
+const (
+	registeredKind   = "registered"
+	unregisteredKind = "unregistered"
+)
+
+// window counts the churn of a network service since its start
+type window struct {
+	start   time.Time
+	count   int
+	spiking bool
+}
+
+type churnNSEServer struct {
+	storage registry.NetworkServiceEndpointRegistryServer
+	options *options
+	churn   metric.Int64Counter
+	spikes  metric.Int64Counter
+
+	mu        sync.Mutex
+	endpoints map[string][]string
+	windows   map[string]*window
+}
+
+func (s *churnNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	resp, err := s.storage.Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	_, refreshed := s.endpoints[resp.GetName()]
+	s.endpoints[resp.GetName()] = slices.Clone(resp.GetNetworkServiceNames())
+	s.mu.Unlock()
+	if !refreshed {
+		s.count(ctx, resp.GetNetworkServiceNames(), registeredKind)
+	}
+	return resp, nil
+}
+
+func (s *churnNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *churnNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	resp, err := s.storage.Unregister(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	services, ok := s.endpoints[nse.GetName()]
+	delete(s.endpoints, nse.GetName())
+	s.mu.Unlock()
+	if !ok {
+		services = nse.GetNetworkServiceNames()
+	}
+	s.count(ctx, services, unregisteredKind)
+	return resp, nil
+}
+
+// count counts the churn of the network services and warns about the ones starting to spike
+func (s *churnNSEServer) count(ctx context.Context, services []string, kind string) {
+	for _, service := range services {
+		s.churn.Add(ctx, 1, metric.WithAttributes(attribute.String("network_service", service), attribute.String("kind", kind)))
+		if s.options.threshold <= 0 {
+			continue
+		}
+		if count, spiking := s.spiking(service); spiking {
+			s.spikes.Add(ctx, 1, metric.WithAttributes(attribute.String("network_service", service)))
+			log.FromContext(ctx).Warnf("the churn of the network service %s spikes: %d endpoints registered or unregistered within %v", service, count, s.options.window)
+			if s.options.recorder != nil {
+				s.options.recorder.Eventf(events.NSReference(s.options.namespace, service), corev1.EventTypeWarning, events.ChurnSpike,
+					"%d endpoints registered or unregistered within %v", count, s.options.window)
+			}
+		}
+	}
+}
+
+// spiking counts the churn of the network service in its current window and returns true once per window when it
+// exceeds the threshold
+func (s *churnNSEServer) spiking(service string) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	w, ok := s.windows[service]
+	if !ok || now.Sub(w.start) >= s.options.window {
+		w = &window{start: now}
+		s.windows[service] = w
+	}
+	w.count++
+	if w.count <= s.options.threshold || w.spiking {
+		return w.count, false
+	}
+	w.spiking = true
+	return w.count, true
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package churn provides storage tracking the registrations of new endpoints and the unregistrations per network
+// service and warning about the spikes of the churn, the early signal of the endpoints crash-looping
+package churn
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+type options struct {
+	window    time.Duration
+	threshold int
+	recorder  record.EventRecorder
+	namespace string
+}
+
+// Option is an option pattern for the churn storage
+type Option func(o *options)
+
+// WithThreshold sets the number of the registrations of new endpoints and the unregistrations of a network service
+// within the window above which the churn spikes, 0 disables the warnings
+func WithThreshold(window time.Duration, threshold int) Option {
+	return func(o *options) {
+		o.window = window
+		o.threshold = threshold
+	}
+}
+
+// WithEventRecorder records the Warning Events of the churn spikes on the network service custom resources in the
+// namespace
+func WithEventRecorder(recorder record.EventRecorder, namespace string) Option {
+	return func(o *options) {
+		o.recorder = recorder
+		o.namespace = namespace
+	}
+}
+
+// NewStorage creates storage.Storage counting the registrations of new endpoints and the unregistrations of s by
+// network service. The endpoints are tracked in memory from the registrations passing through the storage, so the
+// first refreshes after a restart of the registry count as new registrations.
+func NewStorage(s storage.Storage, opts ...Option) storage.Storage {
+	o := &options{
+		window: time.Minute,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	meter := otel.Meter("")
+	churn, _ := meter.Int64Counter("registry_churn_total",
+		metric.WithDescription("number of the registrations of new endpoints and the unregistrations by network service"))
+	spikes, _ := meter.Int64Counter("registry_churn_spikes_total",
+		metric.WithDescription("number of the windows the churn of a network service exceeded the threshold in"))
+
+	return storage.New(
+		s.NetworkServiceRegistryServer(),
+		&churnNSEServer{
+			storage:   s.NetworkServiceEndpointRegistryServer(),
+			options:   o,
+			churn:     churn,
+			spikes:    spikes,
+			endpoints: make(map[string][]string),
+			windows:   make(map[string]*window),
+		},
+	)
+}
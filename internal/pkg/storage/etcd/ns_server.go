@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+type etcdNSServer struct {
+	client            *clientv3.Client
+	prefix            string
+	annotationsPrefix string
+	pageSize          int64
+}
+
+func (s *etcdNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	if ns.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ns name is empty")
+	}
+	data, err := proto.Marshal(ns)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal ns %s", ns.GetName())
+	}
+	ops, err := putOps(s.prefix+ns.GetName(), s.annotationsPrefix+ns.GetName(), data, storage.AnnotationsFromContext(ctx))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to put ns %s", ns.GetName())
+	}
+	if _, err = s.client.Txn(ctx).Then(ops...).Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to put ns %s", ns.GetName())
+	}
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *etcdNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	ctx := server.Context()
+
+	revision, err := list(ctx, s.client, s.prefix, s.pageSize, func(kv *mvccpb.KeyValue) error {
+		ns := new(registry.NetworkService)
+		if err := proto.Unmarshal(kv.Value, ns); err != nil {
+			log.FromContext(ctx).Warnf("skipping malformed ns record %s: %v", kv.Key, err)
+			return nil
+		}
+		if !matchutils.MatchNetworkServices(query.GetNetworkService(), ns) {
+			return nil
+		}
+		if err := server.Send(&registry.NetworkServiceResponse{NetworkService: ns}); err != nil {
+			return errors.Wrapf(err, "NetworkServiceRegistry find server failed to send a response %s", ns.String())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if query.GetWatch() {
+		if err = s.watch(ctx, revision+1, query, server); err != nil {
+			return err
+		}
+	}
+	return next.NetworkServiceRegistryServer(ctx).Find(query, server)
+}
+
+func (s *etcdNSServer) watch(ctx context.Context, revision int64, query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	watchCh := s.client.Watch(clientv3.WithRequireLeader(ctx), s.prefix, clientv3.WithPrefix(), clientv3.WithRev(revision), clientv3.WithPrevKV())
+	for watchResp := range watchCh {
+		if err := watchResp.Err(); err != nil {
+			return errors.Wrap(err, "failed to watch NetworkServices")
+		}
+		for _, event := range watchResp.Events {
+			deleted := event.Type == clientv3.EventTypeDelete
+			kv := event.Kv
+			if deleted {
+				if kv = event.PrevKv; kv == nil {
+					continue
+				}
+			}
+			ns := new(registry.NetworkService)
+			if err := proto.Unmarshal(kv.Value, ns); err != nil {
+				log.FromContext(ctx).Warnf("skipping malformed ns record %s: %v", kv.Key, err)
+				continue
+			}
+			if !matchutils.MatchNetworkServices(query.GetNetworkService(), ns) {
+				continue
+			}
+			if err := server.Send(&registry.NetworkServiceResponse{NetworkService: ns, Deleted: deleted}); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return errors.Wrapf(err, "NetworkServiceRegistry find server failed to send a response %s", ns.String())
+			}
+		}
+	}
+	return nil
+}
+
+func (s *etcdNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	resp, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.client.Txn(ctx).Then(
+		clientv3.OpDelete(s.prefix+ns.GetName()),
+		clientv3.OpDelete(s.annotationsPrefix+ns.GetName()),
+	).Commit()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to delete ns %s from etcd", ns.GetName())
+	}
+	return resp, nil
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+// maxPutAttempts is the number of times a write of an endpoint is tried while its lease is changed by the concurrent
+// writes
+const maxPutAttempts = 5
+
+type etcdNSEServer struct {
+	client            *clientv3.Client
+	prefix            string
+	annotationsPrefix string
+	pageSize          int64
+}
+
+func (s *etcdNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if nse.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "nse name is empty")
+	}
+	data, err := proto.Marshal(nse)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal nse %s", nse.GetName())
+	}
+	if err = s.put(ctx, nse.GetName(), data, nse.GetExpirationTime(), storage.AnnotationsFromContext(ctx)); err != nil {
+		return nil, errors.Wrapf(err, "failed to put nse %s", nse.GetName())
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+// put writes the record and its annotations attached to a lease making etcd drop them on its own if the registry is
+// not around to unregister them. Every endpoint holds one lease: a refresh renews the lease of the stored record if its
+// TTL still fits the expiration, otherwise the record is moved to a new lease and the old one is revoked. The write
+// only succeeds if the record is still attached to the lease it was read with, so two replicas refreshing the same
+// endpoint at once do not revoke the lease the other one has just attached it to; the loser reads it again and retries.
+func (s *etcdNSEServer) put(ctx context.Context, name string, data []byte, expirationTime *timestamppb.Timestamp, annotations map[string]string) error {
+	key, annotationsKey := s.prefix+name, s.annotationsPrefix+name
+	if expirationTime == nil {
+		_, err := s.commit(ctx, nil, key, annotationsKey, data, annotations)
+		return err
+	}
+	ttl := leaseTTL(time.Until(expirationTime.AsTime()))
+
+	for attempt := 0; attempt < maxPutAttempts; attempt++ {
+		done, err := s.putWithLease(ctx, key, annotationsKey, data, annotations, ttl)
+		if err != nil || done {
+			return err
+		}
+	}
+	return errors.Errorf("the lease of %s is changed by the concurrent writes after %d attempts", key, maxPutAttempts)
+}
+
+// putWithLease writes the record on the lease it is attached to or on a new one, it returns false if the record has
+// been attached to another lease since it was read
+func (s *etcdNSEServer) putWithLease(ctx context.Context, key, annotationsKey string, data []byte, annotations map[string]string, ttl int64) (bool, error) {
+	resp, err := s.client.Get(ctx, key, clientv3.WithKeysOnly())
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get %s", key)
+	}
+	current := clientv3.NoLease
+	if len(resp.Kvs) == 1 {
+		current = clientv3.LeaseID(resp.Kvs[0].Lease)
+	}
+	unchanged := []clientv3.Cmp{clientv3.Compare(clientv3.LeaseValue(key), "=", current)}
+
+	if current != clientv3.NoLease {
+		if keepAlive, keepAliveErr := s.client.KeepAliveOnce(ctx, current); keepAliveErr == nil && reusable(keepAlive.TTL, ttl) {
+			return s.commit(ctx, unchanged, key, annotationsKey, data, annotations, clientv3.WithLease(current))
+		}
+	}
+
+	lease, err := s.client.Grant(ctx, ttl)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to grant a lease")
+	}
+	done, err := s.commit(ctx, unchanged, key, annotationsKey, data, annotations, clientv3.WithLease(lease.ID))
+	if err != nil || !done {
+		s.revoke(ctx, lease.ID)
+		return false, err
+	}
+	if current != clientv3.NoLease {
+		s.revoke(ctx, current)
+	}
+	return true, nil
+}
+
+// commit writes the record and its annotations if the comparisons hold, it returns false if they do not
+func (s *etcdNSEServer) commit(ctx context.Context, cmps []clientv3.Cmp, key, annotationsKey string, data []byte, annotations map[string]string, opts ...clientv3.OpOption) (bool, error) {
+	ops, err := putOps(key, annotationsKey, data, annotations, opts...)
+	if err != nil {
+		return false, err
+	}
+	resp, err := s.client.Txn(ctx).If(cmps...).Then(ops...).Commit()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to commit %s", key)
+	}
+	return resp.Succeeded, nil
+}
+
+// revoke revokes the lease no record is attached to anymore, failures are only logged as the lease expires anyway
+func (s *etcdNSEServer) revoke(ctx context.Context, lease clientv3.LeaseID) {
+	if _, err := s.client.Revoke(ctx, lease); err != nil && !errors.Is(err, rpctypes.ErrLeaseNotFound) {
+		log.FromContext(ctx).Warnf("failed to revoke the lease %x, cause: %v", lease, err.Error())
+	}
+}
+
+// leaseTTL returns the TTL in seconds of a lease outliving the expiration
+func leaseTTL(untilExpiration time.Duration) int64 {
+	return max(int64(math.Ceil(untilExpiration.Seconds())), 1)
+}
+
+// reusable returns true if a lease renewed to the granted TTL keeps the record at least for the TTL, but not for more
+// than twice as long
+func reusable(granted, ttl int64) bool {
+	return granted >= ttl && granted <= 2*ttl
+}
+
+func (s *etcdNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	ctx := server.Context()
+
+	revision, err := list(ctx, s.client, s.prefix, s.pageSize, func(kv *mvccpb.KeyValue) error {
+		nse := new(registry.NetworkServiceEndpoint)
+		if err := proto.Unmarshal(kv.Value, nse); err != nil {
+			log.FromContext(ctx).Warnf("skipping malformed nse record %s: %v", kv.Key, err)
+			return nil
+		}
+		if nse.GetExpirationTime() != nil && nse.GetExpirationTime().AsTime().Before(time.Now()) {
+			return nil
+		}
+		if !matchutils.MatchNetworkServiceEndpoints(query.GetNetworkServiceEndpoint(), nse) {
+			return nil
+		}
+		if err := server.Send(&registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: nse}); err != nil {
+			return errors.Wrapf(err, "NetworkServiceEndpointRegistry find server failed to send a response %s", nse.String())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if query.GetWatch() {
+		if err = s.watch(ctx, revision+1, query, server); err != nil {
+			return err
+		}
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Find(query, server)
+}
+
+func (s *etcdNSEServer) watch(ctx context.Context, revision int64, query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	watchCh := s.client.Watch(clientv3.WithRequireLeader(ctx), s.prefix, clientv3.WithPrefix(), clientv3.WithRev(revision), clientv3.WithPrevKV())
+	for watchResp := range watchCh {
+		if err := watchResp.Err(); err != nil {
+			return errors.Wrap(err, "failed to watch NetworkServiceEndpoints")
+		}
+		for _, event := range watchResp.Events {
+			deleted := event.Type == clientv3.EventTypeDelete
+			kv := event.Kv
+			if deleted {
+				if kv = event.PrevKv; kv == nil {
+					continue
+				}
+			}
+			nse := new(registry.NetworkServiceEndpoint)
+			if err := proto.Unmarshal(kv.Value, nse); err != nil {
+				log.FromContext(ctx).Warnf("skipping malformed nse record %s: %v", kv.Key, err)
+				continue
+			}
+			if !matchutils.MatchNetworkServiceEndpoints(query.GetNetworkServiceEndpoint(), nse) {
+				continue
+			}
+			if err := server.Send(&registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: nse, Deleted: deleted}); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return errors.Wrapf(err, "NetworkServiceEndpointRegistry find server failed to send a response %s", nse.String())
+			}
+		}
+	}
+	return nil
+}
+
+func (s *etcdNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	txnResp, err := s.client.Txn(ctx).Then(
+		clientv3.OpDelete(s.prefix+nse.GetName(), clientv3.WithPrevKV()),
+		clientv3.OpDelete(s.annotationsPrefix+nse.GetName()),
+	).Commit()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to delete nse %s from etcd", nse.GetName())
+	}
+	if prevKV := txnResp.Responses[0].GetResponseDeleteRange().GetPrevKvs(); len(prevKV) == 1 && prevKV[0].Lease != 0 {
+		s.revoke(ctx, clientv3.LeaseID(prevKV[0].Lease))
+	}
+	return resp, nil
+}
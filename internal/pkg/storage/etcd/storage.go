@@ -0,0 +1,206 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package etcd provides storage keeping network services and endpoints directly in a dedicated etcd cluster,
+// bypassing the kube-apiserver
+package etcd
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+const (
+	nsKeyPrefix          = "ns"
+	nseKeyPrefix         = "nse"
+	annotationsKeyPrefix = "annotations"
+)
+
+// Config contains configuration parameters for the etcd storage
+type Config struct {
+	Endpoints   []string      `desc:"etcd endpoints used by the etcd storage" split_words:"true"`
+	Prefix      string        `default:"/nsm/registry" desc:"key prefix for the records stored in etcd" split_words:"true"`
+	PageSize    int64         `default:"500" desc:"number of records read from etcd at once by a Find, 0 reads all of them at once" split_words:"true"`
+	DialTimeout time.Duration `default:"5s" desc:"timeout for establishing a connection to etcd" split_words:"true"`
+	Username    string        `desc:"username for etcd authentication" split_words:"true"`
+	Password    string        `desc:"password for etcd authentication" redact:"true" split_words:"true"`
+	CertFile    string        `desc:"client certificate file for etcd TLS" split_words:"true"`
+	KeyFile     string        `desc:"client key file for etcd TLS" split_words:"true"`
+	CAFile      string        `desc:"CA file used to verify etcd server certificates" split_words:"true"`
+}
+
+// NewClient creates etcd client from the given config
+func NewClient(ctx context.Context, config *Config) (*clientv3.Client, error) {
+	if len(config.Endpoints) == 0 {
+		return nil, errors.New("no etcd endpoints are configured")
+	}
+
+	var tlsConfig *tls.Config
+	if config.CertFile != "" || config.CAFile != "" {
+		tlsInfo := transport.TLSInfo{
+			CertFile:      config.CertFile,
+			KeyFile:       config.KeyFile,
+			TrustedCAFile: config.CAFile,
+		}
+		var err error
+		if tlsConfig, err = tlsInfo.ClientConfig(); err != nil {
+			return nil, errors.Wrap(err, "failed to create etcd TLS config")
+		}
+	}
+
+	client, err := clientv3.New(clientv3.Config{
+		Context:     ctx,
+		Endpoints:   config.Endpoints,
+		DialTimeout: config.DialTimeout,
+		Username:    config.Username,
+		Password:    config.Password,
+		TLS:         tlsConfig,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create etcd client for %v", config.Endpoints)
+	}
+	return client, nil
+}
+
+type options struct {
+	pageSize int64
+}
+
+// Option is an option pattern for the etcd storage
+type Option func(o *options)
+
+// WithPageSize sets the number of records read from etcd at once by a Find, 0 reads all of them at once
+func WithPageSize(pageSize int64) Option {
+	return func(o *options) {
+		o.pageSize = pageSize
+	}
+}
+
+// NewStorage creates storage.Storage keeping network services and endpoints under the given key prefix
+func NewStorage(client *clientv3.Client, prefix string, opts ...Option) storage.Storage {
+	o := &options{
+		pageSize: 500,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	nsServer := &etcdNSServer{
+		client:            client,
+		prefix:            path.Join(prefix, nsKeyPrefix) + "/",
+		annotationsPrefix: path.Join(prefix, annotationsKeyPrefix, nsKeyPrefix) + "/",
+		pageSize:          o.pageSize,
+	}
+	nseServer := &etcdNSEServer{
+		client:            client,
+		prefix:            path.Join(prefix, nseKeyPrefix) + "/",
+		annotationsPrefix: path.Join(prefix, annotationsKeyPrefix, nseKeyPrefix) + "/",
+		pageSize:          o.pageSize,
+	}
+	return &etcdStorage{
+		Storage:   storage.New(nsServer, nseServer),
+		client:    client,
+		nsServer:  nsServer,
+		nseServer: nseServer,
+	}
+}
+
+// etcdStorage is a storage.Annotator keeping the annotations of the records as JSON under their own keys, written and
+// deleted in the same transactions as the records
+type etcdStorage struct {
+	storage.Storage
+	client    *clientv3.Client
+	nsServer  *etcdNSServer
+	nseServer *etcdNSEServer
+}
+
+func (s *etcdStorage) NSAnnotations(ctx context.Context, name string) (map[string]string, error) {
+	return getAnnotations(ctx, s.client, s.nsServer.annotationsPrefix+name)
+}
+
+func (s *etcdStorage) NSEAnnotations(ctx context.Context, name string) (map[string]string, error) {
+	return getAnnotations(ctx, s.client, s.nseServer.annotationsPrefix+name)
+}
+
+func getAnnotations(ctx context.Context, client *clientv3.Client, key string) (map[string]string, error) {
+	resp, err := client.Get(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the annotations %s", key)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	var annotations map[string]string
+	if err = json.Unmarshal(resp.Kvs[0].Value, &annotations); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the annotations %s", key)
+	}
+	return annotations, nil
+}
+
+// putOps returns the operations writing the record and its annotations, a record registered without annotations drops
+// the stale ones
+func putOps(key, annotationsKey string, data []byte, annotations map[string]string, opts ...clientv3.OpOption) ([]clientv3.Op, error) {
+	ops := []clientv3.Op{clientv3.OpPut(key, string(data), opts...)}
+	if len(annotations) == 0 {
+		return append(ops, clientv3.OpDelete(annotationsKey)), nil
+	}
+	value, err := json.Marshal(annotations)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the annotations")
+	}
+	return append(ops, clientv3.OpPut(annotationsKey, string(value), opts...)), nil
+}
+
+// list calls f for the records under the prefix reading them page by page, so a Find never holds all of them in
+// memory. All the pages are read at the revision of the first one, which is returned to start a watch after it.
+func list(ctx context.Context, client *clientv3.Client, prefix string, pageSize int64, f func(kv *mvccpb.KeyValue) error) (int64, error) {
+	end := clientv3.GetPrefixRangeEnd(prefix)
+	key := prefix
+	var revision int64
+	for {
+		getOptions := []clientv3.OpOption{clientv3.WithRange(end), clientv3.WithLimit(pageSize)}
+		if revision != 0 {
+			getOptions = append(getOptions, clientv3.WithRev(revision))
+		}
+		resp, err := client.Get(ctx, key, getOptions...)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to get the records under %s", prefix)
+		}
+		if revision == 0 {
+			revision = resp.Header.GetRevision()
+		}
+		for _, kv := range resp.Kvs {
+			if err = f(kv); err != nil {
+				return 0, err
+			}
+		}
+		if !resp.More || len(resp.Kvs) == 0 {
+			return revision, nil
+		}
+		// The next page starts right after the last key of this one
+		key = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+func TestLeaseTTL(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		untilExpiration time.Duration
+		want            int64
+	}{
+		{name: "whole seconds", untilExpiration: 30 * time.Second, want: 30},
+		{name: "rounded up", untilExpiration: 29*time.Second + time.Millisecond, want: 30},
+		{name: "expired", untilExpiration: -time.Second, want: 1},
+		{name: "under a second", untilExpiration: time.Millisecond, want: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := leaseTTL(tc.untilExpiration); got != tc.want {
+				t.Errorf("leaseTTL(%v) = %d, want %d", tc.untilExpiration, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReusable(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		granted int64
+		ttl     int64
+		want    bool
+	}{
+		{name: "same ttl", granted: 60, ttl: 60, want: true},
+		{name: "refresh a second later", granted: 60, ttl: 59, want: true},
+		{name: "expiration extended", granted: 60, ttl: 120, want: false},
+		{name: "twice as long", granted: 60, ttl: 30, want: true},
+		{name: "expiration shortened", granted: 60, ttl: 10, want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := reusable(tc.granted, tc.ttl); got != tc.want {
+				t.Errorf("reusable(%d, %d) = %v, want %v", tc.granted, tc.ttl, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegisterRejectsEmptyName(t *testing.T) {
+	// the empty names are rejected before etcd is reached, so no client is needed
+	s := NewStorage(nil, "/nsm/registry")
+
+	_, err := s.NetworkServiceEndpointRegistryServer().Register(context.Background(), &registry.NetworkServiceEndpoint{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("nse with an empty name: got %v, want InvalidArgument", err)
+	}
+	_, err = s.NetworkServiceRegistryServer().Register(context.Background(), &registry.NetworkService{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ns with an empty name: got %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package federation
+
+import (
+	"context"
+	"io"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type federationNSServer struct {
+	storage registry.NetworkServiceRegistryServer
+	local   registry.NetworkServiceRegistryServer
+	fleet   *fleet
+}
+
+func (s *federationNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	return s.storage.Register(ctx, ns)
+}
+
+func (s *federationNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	if query.GetWatch() || forwarded(server.Context()) {
+		return s.storage.Find(query, server)
+	}
+
+	recorder := &namesNSFindServer{
+		NetworkServiceRegistry_FindServer: server,
+		names:                             make(map[string]struct{}),
+	}
+	if err := s.local.Find(query, recorder); err != nil {
+		return err
+	}
+
+	results := find(server.Context(), s.fleet, func(ctx context.Context, cc grpc.ClientConnInterface) ([]*registry.NetworkServiceResponse, error) {
+		return findNSs(ctx, cc, query)
+	})
+	for _, responses := range results {
+		for _, resp := range responses {
+			if _, ok := recorder.names[resp.GetNetworkService().GetName()]; ok {
+				continue
+			}
+			if err := recorder.Send(resp); err != nil {
+				return errors.Wrapf(err, "NetworkServiceRegistry find server failed to send a response %s", resp.String())
+			}
+		}
+	}
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *federationNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	return s.storage.Unregister(ctx, ns)
+}
+
+func findNSs(ctx context.Context, cc grpc.ClientConnInterface, query *registry.NetworkServiceQuery) ([]*registry.NetworkServiceResponse, error) {
+	stream, err := registry.NewNetworkServiceRegistryClient(cc).Find(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find the network services")
+	}
+	var responses []*registry.NetworkServiceResponse
+	for {
+		resp, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			return responses, nil
+		}
+		if recvErr != nil {
+			return nil, errors.Wrap(recvErr, "failed to receive a network service")
+		}
+		responses = append(responses, resp)
+	}
+}
+
+// namesNSFindServer records the names of the sent network services
+type namesNSFindServer struct {
+	registry.NetworkServiceRegistry_FindServer
+	names map[string]struct{}
+}
+
+func (s *namesNSFindServer) Send(resp *registry.NetworkServiceResponse) error {
+	s.names[resp.GetNetworkService().GetName()] = struct{}{}
+	return s.NetworkServiceRegistry_FindServer.Send(resp)
+}
+
+// tailNSServer terminates the read of the storage so it never calls into the rest of the registry chain
+type tailNSServer struct{}
+
+func (t *tailNSServer) Register(_ context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	return ns, nil
+}
+
+func (t *tailNSServer) Find(_ *registry.NetworkServiceQuery, _ registry.NetworkServiceRegistry_FindServer) error {
+	return nil
+}
+
+func (t *tailNSServer) Unregister(_ context.Context, _ *registry.NetworkService) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package federation
+
+import (
+	"context"
+	"io"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/replicate"
+)
+
+type federationNSEServer struct {
+	storage registry.NetworkServiceEndpointRegistryServer
+	local   registry.NetworkServiceEndpointRegistryServer
+	fleet   *fleet
+}
+
+func (s *federationNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return s.storage.Register(ctx, nse)
+}
+
+func (s *federationNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if query.GetWatch() || forwarded(server.Context()) {
+		return s.storage.Find(query, server)
+	}
+
+	recorder := &namesNSEFindServer{
+		NetworkServiceEndpointRegistry_FindServer: server,
+		names: make(map[string]struct{}),
+	}
+	if err := s.local.Find(query, recorder); err != nil {
+		return err
+	}
+
+	results := find(server.Context(), s.fleet, func(ctx context.Context, cc grpc.ClientConnInterface) ([]*registry.NetworkServiceEndpointResponse, error) {
+		return findNSEs(ctx, cc, query)
+	})
+	for i, responses := range results {
+		for _, resp := range responses {
+			nse := resp.GetNetworkServiceEndpoint()
+			if _, ok := recorder.names[nse.GetName()]; ok {
+				continue
+			}
+			label(nse, s.fleet.peers[i].cluster)
+			if err := recorder.Send(resp); err != nil {
+				return errors.Wrapf(err, "NetworkServiceEndpointRegistry find server failed to send a response %s", resp.String())
+			}
+		}
+	}
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *federationNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return s.storage.Unregister(ctx, nse)
+}
+
+func findNSEs(ctx context.Context, cc grpc.ClientConnInterface, query *registry.NetworkServiceEndpointQuery) ([]*registry.NetworkServiceEndpointResponse, error) {
+	stream, err := registry.NewNetworkServiceEndpointRegistryClient(cc).Find(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find the network service endpoints")
+	}
+	var responses []*registry.NetworkServiceEndpointResponse
+	for {
+		resp, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			return responses, nil
+		}
+		if recvErr != nil {
+			return nil, errors.Wrap(recvErr, "failed to receive a network service endpoint")
+		}
+		responses = append(responses, resp)
+	}
+}
+
+// label labels the endpoint with the cluster for each of its network services unless it carries the origin already,
+// e.g. replicated from another cluster
+func label(nse *registry.NetworkServiceEndpoint, cluster string) {
+	if nse.NetworkServiceLabels == nil {
+		nse.NetworkServiceLabels = make(map[string]*registry.NetworkServiceLabels)
+	}
+	for _, name := range nse.GetNetworkServiceNames() {
+		labels := nse.NetworkServiceLabels[name]
+		if labels == nil {
+			labels = new(registry.NetworkServiceLabels)
+			nse.NetworkServiceLabels[name] = labels
+		}
+		if labels.Labels == nil {
+			labels.Labels = make(map[string]string)
+		}
+		if _, ok := labels.Labels[replicate.OriginLabel]; !ok {
+			labels.Labels[replicate.OriginLabel] = cluster
+		}
+	}
+}
+
+// namesNSEFindServer records the names of the sent endpoints
+type namesNSEFindServer struct {
+	registry.NetworkServiceEndpointRegistry_FindServer
+	names map[string]struct{}
+}
+
+func (s *namesNSEFindServer) Send(resp *registry.NetworkServiceEndpointResponse) error {
+	s.names[resp.GetNetworkServiceEndpoint().GetName()] = struct{}{}
+	return s.NetworkServiceEndpointRegistry_FindServer.Send(resp)
+}
+
+// tailNSEServer terminates the read of the storage so it never calls into the rest of the registry chain
+type tailNSEServer struct{}
+
+func (t *tailNSEServer) Register(_ context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return nse, nil
+}
+
+func (t *tailNSEServer) Find(_ *registry.NetworkServiceEndpointQuery, _ registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return nil
+}
+
+func (t *tailNSEServer) Unregister(_ context.Context, _ *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
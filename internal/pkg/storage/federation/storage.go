@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package federation provides merging of the Find results of the registries of the peer clusters into the local ones,
+// so the clients discover the endpoints across a fleet from their local registry
+package federation
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/connpool"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/upstream"
+)
+
+const peerAttribute = attribute.Key("peer")
+
+// Peer is the registry of a peer cluster
+type Peer struct {
+	Cluster string
+	URL     *url.URL
+}
+
+// Peers is a comma separated list of the peers as cluster=url
+type Peers []Peer
+
+// Decode decodes the peers from their comma separated list
+func (p *Peers) Decode(value string) error {
+	var decoded Peers
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		cluster, rawURL, ok := strings.Cut(item, "=")
+		if !ok || cluster == "" {
+			return errors.Errorf("invalid peer %s, expected cluster=url", item)
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return errors.Wrapf(err, "invalid url of the peer %s", cluster)
+		}
+		decoded = append(decoded, Peer{Cluster: cluster, URL: u})
+	}
+	*p = decoded
+	return nil
+}
+
+type options struct {
+	timeout time.Duration
+}
+
+// Option is an option pattern for the federation storage
+type Option func(o *options)
+
+// WithTimeout sets how long the Find of each peer may take, the results of the slower peers are left out
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+type peer struct {
+	cluster string
+	target  string
+}
+
+// fleet queries the peers through the connections of the pool
+type fleet struct {
+	peers   []*peer
+	pool    *connpool.Pool
+	timeout time.Duration
+
+	failures metric.Int64Counter
+}
+
+// NewStorage creates storage.Storage merging the results of the non-watch Find queries of the peers into the ones of
+// s. The endpoints of the peers are labeled with their cluster unless they carry the origin already, the records of
+// the peers named as the local ones are left out. The watches and the writes are served by s only.
+func NewStorage(s storage.Storage, peers Peers, pool *connpool.Pool, opts ...Option) storage.Storage {
+	o := &options{
+		timeout: time.Second,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	failures, _ := otel.Meter("").Int64Counter("registry_federation_failures_total",
+		metric.WithDescription("number of the Find queries of the peer registries that failed or timed out"))
+	f := &fleet{
+		pool:     pool,
+		timeout:  o.timeout,
+		failures: failures,
+	}
+	for _, p := range peers {
+		f.peers = append(f.peers, &peer{
+			cluster: p.Cluster,
+			target:  grpcutils.URLToTarget(p.URL),
+		})
+	}
+	sort.Slice(f.peers, func(i, j int) bool { return f.peers[i].cluster < f.peers[j].cluster })
+
+	return storage.New(
+		&federationNSServer{
+			storage: s.NetworkServiceRegistryServer(),
+			local:   chain.NewNetworkServiceRegistryServer(s.NetworkServiceRegistryServer(), &tailNSServer{}),
+			fleet:   f,
+		},
+		&federationNSEServer{
+			storage: s.NetworkServiceEndpointRegistryServer(),
+			local:   chain.NewNetworkServiceEndpointRegistryServer(s.NetworkServiceEndpointRegistryServer(), &tailNSEServer{}),
+			fleet:   f,
+		},
+	)
+}
+
+// forwarded returns true if the query was forwarded by another registry, it is answered from the local storage only
+func forwarded(ctx context.Context) bool {
+	return len(metadata.ValueFromIncomingContext(ctx, upstream.MetadataKey)) > 0
+}
+
+// find calls find for all the peers concurrently and returns their results in the order of the peers, the failed
+// peers are logged and left out
+func find[R any](ctx context.Context, f *fleet, find func(ctx context.Context, cc grpc.ClientConnInterface) ([]R, error)) [][]R {
+	results := make([][]R, len(f.peers))
+	var wg sync.WaitGroup
+	for i, p := range f.peers {
+		wg.Add(1)
+		go func(i int, p *peer) {
+			defer wg.Done()
+			peerCtx, cancel := context.WithTimeout(ctx, f.timeout)
+			defer cancel()
+
+			cc, release, err := f.pool.Get(peerCtx, p.target)
+			if err == nil {
+				defer release()
+				results[i], err = find(metadata.AppendToOutgoingContext(peerCtx, upstream.MetadataKey, "true"), cc)
+			}
+			if err != nil {
+				log.FromContext(ctx).Warnf("failed to find in the peer %s: %s", p.cluster, err.Error())
+				f.failures.Add(ctx, 1, metric.WithAttributes(peerAttribute.String(p.cluster)))
+			}
+		}(i, p)
+	}
+	wg.Wait()
+	return results
+}
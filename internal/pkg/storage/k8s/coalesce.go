@@ -0,0 +1,72 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"time"
+)
+
+// coalesce sends the matching updates to the watch. With a window the updates of the same record received within it
+// are merged into the latest one, so a watch gets the current state of a record refreshed in quick succession
+// instead of every intermediate update. The records are sent in the order of their first update in the window.
+func coalesce[R any](updates <-chan R, window time.Duration, match func(R) bool, name func(R) string, send func(R) error) error {
+	if window <= 0 {
+		for update := range updates {
+			if !match(update) {
+				continue
+			}
+			if err := send(update); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	pending := make(map[string]R)
+	var order []string
+	timer := time.NewTimer(window)
+	timer.Stop()
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			if !match(update) {
+				continue
+			}
+			key := name(update)
+			if _, ok := pending[key]; !ok {
+				if len(order) == 0 {
+					timer.Reset(window)
+				}
+				order = append(order, key)
+			}
+			pending[key] = update
+		case <-timer.C:
+			for _, key := range order {
+				if err := send(pending[key]); err != nil {
+					return err
+				}
+			}
+			clear(pending)
+			order = order[:0]
+		}
+	}
+}
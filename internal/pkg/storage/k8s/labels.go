@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	v1 "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/apis/networkservicemesh.io/v1"
+)
+
+const nseLabelsIndex = "networkServiceLabels"
+
+// nseLabelsIndexFunc indexes the endpoints by every network service label they carry
+func nseLabelsIndexFunc(obj interface{}) ([]string, error) {
+	model, ok := obj.(*v1.NetworkServiceEndpoint)
+	if !ok {
+		return nil, nil
+	}
+	var keys []string
+	for service, serviceLabels := range (*registry.NetworkServiceEndpoint)(&model.Spec).GetNetworkServiceLabels() {
+		for key, value := range serviceLabels.GetLabels() {
+			keys = append(keys, nseLabelsIndexKey(service, key, value))
+		}
+	}
+	return keys, nil
+}
+
+func nseLabelsIndexKey(service, key, value string) string {
+	return service + "/" + key + "=" + value
+}
+
+// nseLabelsIndexKeys returns the index keys of the labels required by the query sorted for a stable lookup order
+func nseLabelsIndexKeys(query *registry.NetworkServiceEndpoint) []string {
+	var keys []string
+	for service, serviceLabels := range query.GetNetworkServiceLabels() {
+		for key, value := range serviceLabels.GetLabels() {
+			keys = append(keys, nseLabelsIndexKey(service, key, value))
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// lookup returns the endpoints from the smallest bucket of the label index among the labels required by the query, so
+// the query still has to be matched by the caller. It returns false if the query requires no labels.
+func lookup(indexer cache.Indexer, query *registry.NetworkServiceEndpoint) ([]*v1.NetworkServiceEndpoint, bool, error) {
+	keys := nseLabelsIndexKeys(query)
+	if len(keys) == 0 {
+		return nil, false, nil
+	}
+	var smallest []interface{}
+	for i, key := range keys {
+		objs, err := indexer.ByIndex(nseLabelsIndex, key)
+		if err != nil {
+			return nil, false, err
+		}
+		if i == 0 || len(objs) < len(smallest) {
+			smallest = objs
+		}
+		if len(smallest) == 0 {
+			break
+		}
+	}
+	models := make([]*v1.NetworkServiceEndpoint, 0, len(smallest))
+	for _, obj := range smallest {
+		if model, ok := obj.(*v1.NetworkServiceEndpoint); ok {
+			models = append(models, model)
+		}
+	}
+	return models, true, nil
+}
+
+// crLabels translates the network service labels of the endpoint into the labels of its custom resource, so the
+// endpoints can be selected with Kubernetes label selectors. The labels which are not valid Kubernetes labels or have
+// different values for different network services are skipped.
+func crLabels(nse *registry.NetworkServiceEndpoint) map[string]string {
+	result := make(map[string]string)
+	conflicts := make(map[string]struct{})
+	for _, serviceLabels := range nse.GetNetworkServiceLabels() {
+		for key, value := range serviceLabels.GetLabels() {
+			if len(validation.IsQualifiedName(key)) != 0 || len(validation.IsValidLabelValue(value)) != 0 {
+				continue
+			}
+			if current, ok := result[key]; ok && current != value {
+				conflicts[key] = struct{}{}
+			}
+			result[key] = value
+		}
+	}
+	for key := range conflicts {
+		delete(result, key)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// nameAnnotation preserves the name of the records stored under a mapped object name
+	nameAnnotation = "registry.networkservicemesh.io/name"
+	// nameHashLength is the length of the hash suffix of the mapped object names
+	nameHashLength = 10
+)
+
+// objectName maps the name of a record to the name of its custom resource. The names which are valid object names are
+// kept, the others, e.g. with uppercase or non-ASCII characters or longer than 253 characters, are lowercased, have the
+// invalid characters replaced with '-', are truncated and suffixed with the hash of the name. The mapping is
+// deterministic, so every replica finds the custom resource of a record by its name.
+func objectName(name string) string {
+	if name == "" || len(validation.IsDNS1123Subdomain(name)) == 0 {
+		return name
+	}
+	var sanitized strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			sanitized.WriteRune(r)
+		} else {
+			sanitized.WriteByte('-')
+		}
+	}
+	sum := sha256.Sum256([]byte(name))
+	hash := hex.EncodeToString(sum[:])[:nameHashLength]
+	prefix := sanitized.String()
+	if maxLength := validation.DNS1123SubdomainMaxLength - nameHashLength - 1; len(prefix) > maxLength {
+		prefix = prefix[:maxLength]
+	}
+	prefix = strings.Trim(prefix, "-")
+	if prefix == "" {
+		return hash
+	}
+	return prefix + "-" + hash
+}
+
+// annotateName preserves the name of the record in the metadata of the custom resource if the object name is mapped
+func annotateName(meta *metav1.ObjectMeta, name string) {
+	if meta.Name == name {
+		return
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = make(map[string]string)
+	}
+	meta.Annotations[nameAnnotation] = name
+}
+
+// recordName returns the name of the record stored in the custom resource without a name in its spec
+func recordName(meta *metav1.ObjectMeta) string {
+	if name, ok := meta.GetAnnotations()[nameAnnotation]; ok {
+		return name
+	}
+	return meta.GetName()
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestObjectName(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		record string
+		mapped bool
+		prefix string
+	}{
+		{name: "valid", record: "nse-1.example"},
+		{name: "empty", record: ""},
+		{name: "uppercase", record: "NSE-1", mapped: true, prefix: "nse-1-"},
+		{name: "underscore", record: "nse_1", mapped: true, prefix: "nse-1-"},
+		{name: "non-ASCII", record: "nsé", mapped: true, prefix: "ns-"},
+		{name: "invalid only", record: "___", mapped: true},
+		{name: "too long", record: strings.Repeat("a", 300), mapped: true, prefix: strings.Repeat("a", 242) + "-"},
+		{name: "interdomain", record: "nse-1@Domain.org", mapped: true, prefix: "nse-1-domain-org-"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := objectName(tc.record)
+			if !tc.mapped {
+				if got != tc.record {
+					t.Errorf("objectName(%q) = %q, want the name kept", tc.record, got)
+				}
+				return
+			}
+			if errs := validation.IsDNS1123Subdomain(got); len(errs) > 0 {
+				t.Errorf("objectName(%q) = %q is not a valid object name: %v", tc.record, got, errs)
+			}
+			if !strings.HasPrefix(got, tc.prefix) {
+				t.Errorf("objectName(%q) = %q, want the prefix %q", tc.record, got, tc.prefix)
+			}
+			if again := objectName(tc.record); again != got {
+				t.Errorf("objectName(%q) is not deterministic: %q and %q", tc.record, got, again)
+			}
+		})
+	}
+}
+
+func TestObjectNamesOfSimilarNamesDiffer(t *testing.T) {
+	if a, b := objectName("NSE_1"), objectName("nse.1"); a == b {
+		t.Errorf("NSE_1 and nse.1 are both mapped to %q", a)
+	}
+	if a, b := objectName("NSE_1"), objectName("nse-1"); a == b {
+		t.Errorf("NSE_1 is mapped to the valid name nse-1")
+	}
+}
+
+func TestRecordName(t *testing.T) {
+	for _, record := range []string{"nse-1", "NSE_1"} {
+		meta := metav1.ObjectMeta{Name: objectName(record)}
+		annotateName(&meta, record)
+		if got := recordName(&meta); got != record {
+			t.Errorf("recordName returned %q for %q", got, record)
+		}
+		if _, ok := meta.Annotations[nameAnnotation]; ok != (meta.Name != record) {
+			t.Errorf("the name annotation of %q is set %v, want it only for the mapped names", record, ok)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+
+	v1 "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/apis/networkservicemesh.io/v1"
+	"github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/clientset/versioned"
+	listers "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/listers/networkservicemesh.io/v1"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/events"
+)
+
+type k8sNSServer struct {
+	chainContext context.Context
+	client       versioned.Interface
+	lister       listers.NetworkServiceLister
+	namespace    string
+	options      *options
+	retry        *conflictRetry
+	writes       *writeTimer
+
+	subscribers *subscribers[*registry.NetworkServiceResponse]
+}
+
+func newNSServer(chainContext context.Context, namespace string, client versioned.Interface, informer cache.SharedIndexInformer, o *options) (*k8sNSServer, error) {
+	s := &k8sNSServer{
+		chainContext: chainContext,
+		client:       client,
+		lister:       listers.NewNetworkServiceLister(informer.GetIndexer()),
+		namespace:    namespace,
+		options:      o,
+		retry:        newConflictRetry(o.conflictBackoff),
+		writes:       newWriteTimer(),
+		subscribers:  newSubscribers[*registry.NetworkServiceResponse](nsKind, o),
+	}
+
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			s.handle(obj, false)
+		},
+		UpdateFunc: func(oldObj, obj interface{}) {
+			if !isResync(oldObj, obj) {
+				s.handle(obj, false)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			s.handle(obj, true)
+		},
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to add NetworkServices event handler")
+	}
+
+	return s, nil
+}
+
+func (s *k8sNSServer) handle(obj interface{}, deleted bool) {
+	model, ok := obj.(*v1.NetworkService)
+	if !ok {
+		log.FromContext(s.chainContext).WithField("k8sNSServer", "handle").Errorf("unexpected object: %v", obj)
+		return
+	}
+	resp := &registry.NetworkServiceResponse{
+		NetworkService: nsFromModel(model),
+		Deleted:        deleted,
+	}
+	s.subscribers.send(resp)
+}
+
+func (s *k8sNSServer) Register(ctx context.Context, request *registry.NetworkService) (*registry.NetworkService, error) {
+	start := time.Now()
+	name, err := s.apply(ctx, request)
+	s.writes.record(ctx, nsKind, applyOp, start, err)
+	if err != nil {
+		s.options.event(events.NSReference(s.namespace, objectName(request.GetName())), corev1.EventTypeWarning, events.RegisterFailed, "failed to register: %v", err)
+		return nil, err
+	}
+	s.options.event(events.NSReference(s.namespace, name), corev1.EventTypeNormal, events.Registered, "registered")
+	if s.options.statusRegistry != "" {
+		s.applyStatus(ctx, name)
+	}
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, request)
+}
+
+// apply server-side applies the custom resource owned by the field manager, only the records without a name are
+// created with a generated one. The names which are not valid object names are mapped to one, see objectName. apply
+// returns the name of the resource.
+func (s *k8sNSServer) apply(ctx context.Context, request *registry.NetworkService) (string, error) {
+	client := s.client.NetworkservicemeshV1().NetworkServices(s.namespace)
+
+	meta := metav1.ObjectMeta{
+		GenerateName: "netsvc-",
+		Name:         objectName(request.GetName()),
+		Namespace:    s.namespace,
+	}
+	s.options.stamp(&meta)
+	annotate(ctx, &meta)
+	annotateName(&meta, request.GetName())
+	if request.GetName() == "" {
+		apiResp, err := client.Create(ctx, nsModel(&meta, request), metav1.CreateOptions{FieldManager: s.options.fieldManager})
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to create a netsvc in a namespace %s", s.namespace)
+		}
+		return apiResp.GetName(), nil
+	}
+
+	data, err := json.Marshal(nsModel(&meta, request))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal a netsvc %s", request.GetName())
+	}
+	force := true
+	err = s.retry.do(ctx, nsKind, func() error {
+		_, patchErr := client.Patch(ctx, meta.Name, types.ApplyPatchType, data, metav1.PatchOptions{
+			FieldManager: s.options.fieldManager,
+			Force:        &force,
+		})
+		return patchErr
+	})
+	return meta.Name, errors.Wrapf(err, "failed to apply a netsvc %s in a namespace %s", request.GetName(), s.namespace)
+}
+
+// applyStatus populates the status subresource of the applied custom resource, failures are only logged as the status
+// is informational
+func (s *k8sNSServer) applyStatus(ctx context.Context, name string) {
+	data, err := statusPatch("NetworkService", name, s.namespace, s.options.statusRegistry, nil, nil)
+	if err == nil {
+		force := true
+		_, err = s.client.NetworkservicemeshV1().NetworkServices(s.namespace).Patch(ctx, name, types.ApplyPatchType, data, metav1.PatchOptions{
+			FieldManager: s.options.fieldManager,
+			Force:        &force,
+		}, "status")
+	}
+	if err != nil {
+		log.FromContext(ctx).Warnf("failed to update the status of a netsvc %s in a namespace %s: %v", name, s.namespace, err)
+	}
+}
+
+func (s *k8sNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	if err := s.sendCurrent(query, server); err != nil {
+		return err
+	}
+	if query.GetWatch() {
+		var watchCtx, cancel = context.WithCancel(server.Context())
+		defer cancel()
+		if err := s.watch(watchCtx, query, server); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	}
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+// sendCurrent sends the network services matching the query
+func (s *k8sNSServer) sendCurrent(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	models, err := s.lister.List(labels.Everything())
+	if err != nil {
+		return errors.Wrap(err, "failed to get a list of NetworkServices")
+	}
+	for _, model := range models {
+		ns := nsFromModel(model)
+		if matchutils.MatchNetworkServices(query.GetNetworkService(), ns) {
+			if err := server.Send(&registry.NetworkServiceResponse{NetworkService: ns}); err != nil {
+				return errors.Wrapf(err, "NetworkServiceRegistry find server failed to send a response %s", ns.String())
+			}
+		}
+	}
+	return nil
+}
+
+func (s *k8sNSServer) Unregister(ctx context.Context, request *registry.NetworkService) (*empty.Empty, error) {
+	resp, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	start := time.Now()
+	err = s.client.NetworkservicemeshV1().NetworkServices(s.namespace).Delete(
+		ctx,
+		objectName(request.GetName()),
+		metav1.DeleteOptions{})
+	s.writes.record(ctx, nsKind, deleteOp, start, err)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to delete a NetworkServices %s in a namespace %s", request.GetName(), s.namespace)
+	}
+	s.options.event(events.NSReference(s.namespace, objectName(request.GetName())), corev1.EventTypeNormal, events.Unregistered, "unregistered")
+	return resp, nil
+}
+
+func (s *k8sNSServer) watch(ctx context.Context, query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return s.subscribers.subscribe(ctx).watch(s.options.watchWindow,
+		func(update *registry.NetworkServiceResponse) bool {
+			return matchutils.MatchNetworkServices(query.GetNetworkService(), update.GetNetworkService())
+		},
+		func(update *registry.NetworkServiceResponse) string {
+			return update.GetNetworkService().GetName()
+		},
+		func() error {
+			return s.sendCurrent(query, server)
+		},
+		server.Send)
+}
+
+// nsFromModel returns a copy of the network service stored in the custom resource, the informer cache must not be modified
+func nsFromModel(model *v1.NetworkService) *registry.NetworkService {
+	ns := (*registry.NetworkService)(&model.Spec).Clone()
+	if ns.GetName() == "" {
+		ns.Name = recordName(&model.ObjectMeta)
+	}
+	return ns
+}
+
+func nsModel(meta *metav1.ObjectMeta, ns *registry.NetworkService) *v1.NetworkService {
+	model := &v1.NetworkService{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       "NetworkService",
+		},
+		ObjectMeta: *meta.DeepCopy(),
+	}
+	proto.Merge((*registry.NetworkService)(&model.Spec), ns)
+	return model
+}
@@ -0,0 +1,547 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/edwarnicke/serialize"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+
+	v1 "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/apis/networkservicemesh.io/v1"
+	"github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/clientset/versioned"
+	listers "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/listers/networkservicemesh.io/v1"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/events"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/identity"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+type k8sNSEServer struct {
+	chainContext   context.Context
+	deleteExecutor serialize.Executor
+	client         versioned.Interface
+	lister         listers.NetworkServiceEndpointLister
+	indexer        cache.Indexer
+	namespace      string
+	options        *options
+	retry          *conflictRetry
+	writes         *writeTimer
+
+	subscribers *subscribers[*registry.NetworkServiceEndpointResponse]
+}
+
+func newNSEServer(chainContext context.Context, namespace string, client versioned.Interface, informer cache.SharedIndexInformer, o *options) (*k8sNSEServer, error) {
+	s := &k8sNSEServer{
+		chainContext: chainContext,
+		client:       client,
+		lister:       listers.NewNetworkServiceEndpointLister(informer.GetIndexer()),
+		indexer:      informer.GetIndexer(),
+		namespace:    namespace,
+		options:      o,
+		retry:        newConflictRetry(o.conflictBackoff),
+		writes:       newWriteTimer(),
+		subscribers:  newSubscribers[*registry.NetworkServiceEndpointResponse](nseKind, o),
+	}
+
+	if err := informer.AddIndexers(cache.Indexers{nseLabelsIndex: nseLabelsIndexFunc}); err != nil {
+		return nil, errors.Wrap(err, "failed to add NetworkServiceEndpoints indexers")
+	}
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			s.handle(obj, false, true)
+		},
+		UpdateFunc: func(oldObj, obj interface{}) {
+			s.handle(obj, false, !isResync(oldObj, obj))
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			s.handle(obj, true, true)
+		},
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to add NetworkServiceEndpoints event handler")
+	}
+
+	return s, nil
+}
+
+// handle notifies the Find watches about the changed endpoint and deletes it if it is expired. Resyncs replaying an
+// unchanged endpoint are only checked for the expiration. An endpoint being deleted is reported as deleted before its
+// finalizer is removed, so the watches see the deletion before the resource vanishes.
+func (s *k8sNSEServer) handle(obj interface{}, deleted, notify bool) {
+	model, ok := obj.(*v1.NetworkServiceEndpoint)
+	if !ok {
+		log.FromContext(s.chainContext).WithField("k8sNSEServer", "handle").Errorf("unexpected object: %v", obj)
+		return
+	}
+	finalizing := !deleted && model.GetDeletionTimestamp() != nil && slices.Contains(model.GetFinalizers(), nseFinalizer)
+	deleted = deleted || finalizing
+	item := nseFromModel(model)
+	resp := &registry.NetworkServiceEndpointResponse{
+		NetworkServiceEndpoint: item,
+		// the soft deleted endpoints are gone for the watches once they expire
+		Deleted: deleted || s.options.softDelete && isExpired(item),
+	}
+	if notify {
+		s.subscribers.send(resp)
+	}
+	if !deleted && s.isExpiredForGood(item) {
+		s.deleteExpired(model)
+	}
+	if finalizing {
+		s.removeFinalizer(model)
+	}
+}
+
+func (s *k8sNSEServer) deleteExpired(model *v1.NetworkServiceEndpoint) {
+	if s.options.readOnly {
+		return
+	}
+	name, version := model.GetName(), model.GetResourceVersion()
+	s.deleteExecutor.AsyncExec(func() {
+		err := s.client.NetworkservicemeshV1().NetworkServiceEndpoints(s.namespace).Delete(s.chainContext, name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{
+				ResourceVersion: &version,
+			},
+		})
+		if err == nil {
+			s.options.event(events.NSEReference(s.namespace, name), corev1.EventTypeNormal, events.Expired, "expired")
+		}
+	})
+}
+
+func (s *k8sNSEServer) removeFinalizer(model *v1.NetworkServiceEndpoint) {
+	if s.options.readOnly {
+		return
+	}
+	name, index := model.GetName(), slices.Index(model.GetFinalizers(), nseFinalizer)
+	s.deleteExecutor.AsyncExec(func() {
+		patch, err := json.Marshal([]map[string]interface{}{
+			{"op": "test", "path": fmt.Sprintf("/metadata/finalizers/%d", index), "value": nseFinalizer},
+			{"op": "remove", "path": fmt.Sprintf("/metadata/finalizers/%d", index)},
+		})
+		if err == nil {
+			_, err = s.client.NetworkservicemeshV1().NetworkServiceEndpoints(s.namespace).Patch(s.chainContext, name, types.JSONPatchType, patch, metav1.PatchOptions{})
+		}
+		// the test operation fails if the finalizer has already been removed
+		if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsInvalid(err) {
+			log.FromContext(s.chainContext).Warnf("failed to remove the finalizer of a nse %s in a namespace %s: %v", name, s.namespace, err)
+		}
+	})
+}
+
+func (s *k8sNSEServer) Register(ctx context.Context, request *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if s.options.skipNoopRefreshes {
+		if model, ok := s.noopRefresh(ctx, request); ok {
+			s.writes.skip(ctx, nseKind)
+			ctx = withNSEVersion(ctx, model.GetResourceVersion())
+			return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, request)
+		}
+	}
+	start := time.Now()
+	apiResp, err := s.apply(ctx, request)
+	s.writes.record(ctx, nseKind, applyOp, start, err)
+	if err != nil {
+		s.options.event(events.NSEReference(s.namespace, objectName(request.GetName())), corev1.EventTypeWarning, events.RegisterFailed, "failed to register: %v", err)
+		return nil, err
+	}
+	s.options.event(events.NSEReference(s.namespace, apiResp.GetName()), corev1.EventTypeNormal, events.Registered, "registered")
+	if s.options.statusRegistry != "" {
+		apiResp = s.applyStatus(ctx, apiResp, request)
+	}
+	if _, ok := apiResp.GetAnnotations()[expiredAnnotation]; ok {
+		s.unmarkExpired(ctx, apiResp)
+	}
+	ctx = withNSEVersion(ctx, apiResp.ResourceVersion)
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, request)
+}
+
+// noopRefresh returns the cached custom resource of the endpoint if the request changes nothing but the expiration and
+// the stored expiration still covers at least half of the requested lifetime
+func (s *k8sNSEServer) noopRefresh(ctx context.Context, request *registry.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, bool) {
+	if request.GetName() == "" || request.GetExpirationTime() == nil {
+		return nil, false
+	}
+	model, err := s.lister.NetworkServiceEndpoints(s.namespace).Get(objectName(request.GetName()))
+	if err != nil || model.GetDeletionTimestamp() != nil {
+		return nil, false
+	}
+	for key, value := range storage.AnnotationsFromContext(ctx) {
+		if model.GetAnnotations()[key] != value {
+			return nil, false
+		}
+	}
+	stored := nseFromModel(model)
+	if stored.GetExpirationTime() == nil {
+		return nil, false
+	}
+	now := time.Now()
+	storedExpiration, requestedExpiration := stored.GetExpirationTime().AsTime(), request.GetExpirationTime().AsTime()
+	if storedExpiration.Sub(now) < requestedExpiration.Sub(now)/2 {
+		return nil, false
+	}
+	requested := request.Clone()
+	requested.ExpirationTime, stored.ExpirationTime = nil, nil
+	return model, proto.Equal(requested, stored)
+}
+
+// apply server-side applies the custom resource owned by the field manager, only the records without a name are
+// created with a generated one. The names which are not valid object names are mapped to one, see objectName.
+func (s *k8sNSEServer) apply(ctx context.Context, request *registry.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error) {
+	client := s.client.NetworkservicemeshV1().NetworkServiceEndpoints(s.namespace)
+
+	meta := metav1.ObjectMeta{
+		GenerateName: "nse-",
+		Name:         objectName(request.GetName()),
+		Namespace:    s.namespace,
+		Labels:       crLabels(request),
+	}
+	s.options.stamp(&meta)
+	annotate(ctx, &meta)
+	annotateName(&meta, request.GetName())
+	if s.options.pods != nil {
+		meta.OwnerReferences = s.podOwners(ctx, meta.Name)
+	}
+	if s.options.finalizers {
+		meta.Finalizers = []string{nseFinalizer}
+	}
+	if request.GetName() == "" {
+		apiResp, err := client.Create(ctx, nseModel(&meta, request), metav1.CreateOptions{FieldManager: s.options.fieldManager})
+		return apiResp, errors.Wrapf(err, "failed to create a nse in a namespace %s", s.namespace)
+	}
+
+	data, err := json.Marshal(nseModel(&meta, request))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal a nse %s", request.GetName())
+	}
+	force := true
+	var apiResp *v1.NetworkServiceEndpoint
+	err = s.retry.do(ctx, nseKind, func() error {
+		var patchErr error
+		apiResp, patchErr = client.Patch(ctx, meta.Name, types.ApplyPatchType, data, metav1.PatchOptions{
+			FieldManager: s.options.fieldManager,
+			Force:        &force,
+		})
+		return patchErr
+	})
+	return apiResp, errors.Wrapf(err, "failed to apply a nse %s in a namespace %s", request.GetName(), s.namespace)
+}
+
+// podOwners returns the owner reference to the pod registering the endpoint. The pod is looked up on the first
+// registration only, the refreshes reuse the reference from the informer cache.
+func (s *k8sNSEServer) podOwners(ctx context.Context, name string) []metav1.OwnerReference {
+	if cached, err := s.lister.NetworkServiceEndpoints(s.namespace).Get(name); err == nil {
+		for i := range cached.OwnerReferences {
+			if cached.OwnerReferences[i].Kind == "Pod" {
+				return []metav1.OwnerReference{cached.OwnerReferences[i]}
+			}
+		}
+	}
+
+	namespace, podName, ok := identity.PodFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	// Kubernetes garbage collector ignores the owners from other namespaces
+	if namespace != s.namespace {
+		return nil
+	}
+	pod, err := s.options.pods.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		log.FromContext(ctx).Warnf("failed to get the pod %s in a namespace %s owning a nse %s: %v", podName, namespace, name, err)
+		return nil
+	}
+	return []metav1.OwnerReference{
+		{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Name:       pod.GetName(),
+			UID:        pod.GetUID(),
+		},
+	}
+}
+
+// applyStatus populates the status subresource of the applied custom resource, failures are only logged as the status
+// is informational
+func (s *k8sNSEServer) applyStatus(ctx context.Context, model *v1.NetworkServiceEndpoint, request *registry.NetworkServiceEndpoint) *v1.NetworkServiceEndpoint {
+	data, err := statusPatch("NetworkServiceEndpoint", model.GetName(), s.namespace, s.options.statusRegistry,
+		request.GetInitialRegistrationTime(), request.GetExpirationTime())
+	if err == nil {
+		force := true
+		var apiResp *v1.NetworkServiceEndpoint
+		apiResp, err = s.client.NetworkservicemeshV1().NetworkServiceEndpoints(s.namespace).Patch(ctx, model.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+			FieldManager: s.options.fieldManager,
+			Force:        &force,
+		}, "status")
+		if err == nil {
+			return apiResp
+		}
+	}
+	log.FromContext(ctx).Warnf("failed to update the status of a nse %s in a namespace %s: %v", model.GetName(), s.namespace, err)
+	return model
+}
+
+func (s *k8sNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if err := s.sendCurrent(query, server); err != nil {
+		return err
+	}
+	if query.GetWatch() {
+		var watchCtx, cancel = context.WithCancel(server.Context())
+		defer cancel()
+		if err := s.watch(watchCtx, query, server); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	}
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+// sendCurrent sends the endpoints matching the query, the expired endpoints are deleted instead
+func (s *k8sNSEServer) sendCurrent(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	models, err := s.list(query.GetNetworkServiceEndpoint())
+	if err != nil {
+		return err
+	}
+	for _, model := range models {
+		if model.GetDeletionTimestamp() != nil {
+			continue
+		}
+		nse := nseFromModel(model)
+		if isExpired(nse) {
+			if s.isExpiredForGood(nse) {
+				s.deleteExpired(model)
+			}
+			continue
+		}
+		if matchutils.MatchNetworkServiceEndpoints(query.GetNetworkServiceEndpoint(), nse) {
+			if err := server.Send(&registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: nse}); err != nil {
+				return errors.Wrapf(err, "NetworkServiceEndpointRegistry find server failed to send a response %s", nse.String())
+			}
+		}
+	}
+	return nil
+}
+
+// list returns the candidate endpoints for the query, the queries with network service labels are served from the
+// label index instead of listing all the endpoints
+func (s *k8sNSEServer) list(query *registry.NetworkServiceEndpoint) ([]*v1.NetworkServiceEndpoint, error) {
+	models, indexed, err := lookup(s.indexer, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to look up NetworkServiceEndpoints by labels")
+	}
+	if indexed {
+		return models, nil
+	}
+	models, err = s.lister.List(labels.Everything())
+	return models, errors.Wrap(err, "failed to get a list of NetworkServiceEndpoints")
+}
+
+func (s *k8sNSEServer) Unregister(ctx context.Context, request *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if s.options.softDelete && isExpired(request) {
+		start := time.Now()
+		err = s.markExpired(ctx, request)
+		s.writes.record(ctx, nseKind, applyOp, start, err)
+		if err != nil {
+			log.FromContext(ctx).Warnf("failed to mark a NetworkServiceEndpoints %s in a namespace %s expired, cause: %v", request.GetName(), s.namespace, err.Error())
+		}
+		return resp, nil
+	}
+	start := time.Now()
+	deleted, err := s.delete(ctx, request)
+	s.writes.record(ctx, nseKind, deleteOp, start, err)
+	if err != nil {
+		log.FromContext(ctx).Warnf("failed to delete a NetworkServiceEndpoints %s in a namespace %s, cause: %v", request.GetName(), s.namespace, err.Error())
+	} else if deleted {
+		s.options.event(events.NSEReference(s.namespace, objectName(request.GetName())), corev1.EventTypeNormal, events.Unregistered, "unregistered")
+	}
+
+	return resp, nil
+}
+
+func (s *k8sNSEServer) watch(ctx context.Context, query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return s.subscribers.subscribe(ctx).watch(s.options.watchWindow,
+		func(update *registry.NetworkServiceEndpointResponse) bool {
+			return matchutils.MatchNetworkServiceEndpoints(query.GetNetworkServiceEndpoint(), update.GetNetworkServiceEndpoint())
+		},
+		func(update *registry.NetworkServiceEndpointResponse) string {
+			return update.GetNetworkServiceEndpoint().GetName()
+		},
+		func() error {
+			return s.sendCurrent(query, server)
+		},
+		server.Send)
+}
+
+// delete deletes the custom resource of the endpoint unless it has been refreshed past the expiration time of the
+// request. The replicas serve the refreshes of a registration independently, so the expiration timer of a replica
+// must not delete an endpoint refreshed through another one since. The check and the delete are made atomic by the
+// resourceVersion precondition. delete returns whether the resource has been deleted.
+func (s *k8sNSEServer) delete(ctx context.Context, request *registry.NetworkServiceEndpoint) (bool, error) {
+	client := s.client.NetworkservicemeshV1().NetworkServiceEndpoints(s.namespace)
+	name := objectName(request.GetName())
+	version, versioned := nseVersionFromContext(ctx)
+
+	model, err := s.lister.NetworkServiceEndpoints(s.namespace).Get(name)
+	if err != nil {
+		model = nil
+	}
+	deleted := false
+	err = s.retry.do(ctx, nseKind, func() error {
+		preconditions := metav1.Preconditions{}
+		switch {
+		case versioned:
+			preconditions.ResourceVersion = &version
+		case model != nil:
+			if refreshed(model, request) {
+				log.FromContext(ctx).Debugf("nse %s has been refreshed since, keeping it", request.GetName())
+				return nil
+			}
+			preconditions.ResourceVersion = &model.ResourceVersion
+		}
+		deleteErr := client.Delete(ctx, name, metav1.DeleteOptions{Preconditions: &preconditions})
+		if apierrors.IsConflict(deleteErr) && !versioned {
+			// the informer cache may be behind, the next attempt checks the current resource
+			current, getErr := client.Get(ctx, name, metav1.GetOptions{})
+			if getErr != nil {
+				return getErr
+			}
+			model = current
+		}
+		deleted = deleteErr == nil
+		return deleteErr
+	})
+	return deleted, err
+}
+
+// markExpired marks the custom resource of the expired endpoint instead of deleting it, the sweeper deletes it once the
+// grace period passes. The mark notifies the Find watches of all the replicas about the expiration.
+func (s *k8sNSEServer) markExpired(ctx context.Context, request *registry.NetworkServiceEndpoint) error {
+	name := objectName(request.GetName())
+	version, versioned := nseVersionFromContext(ctx)
+	if !versioned {
+		model, err := s.lister.NetworkServiceEndpoints(s.namespace).Get(name)
+		if err != nil {
+			return nil
+		}
+		if refreshed(model, request) {
+			log.FromContext(ctx).Debugf("nse %s has been refreshed since, keeping it", request.GetName())
+			return nil
+		}
+		version = model.GetResourceVersion()
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": version,
+			"annotations": map[string]string{
+				expiredAnnotation: request.GetExpirationTime().AsTime().UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal the expiration mark of a nse %s", request.GetName())
+	}
+	_, err = s.client.NetworkservicemeshV1().NetworkServiceEndpoints(s.namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{
+		FieldManager: s.options.fieldManager,
+	})
+	switch {
+	case apierrors.IsNotFound(err) || apierrors.IsConflict(err):
+		// the endpoint has been deleted or refreshed since
+		return nil
+	case err != nil:
+		return errors.Wrapf(err, "failed to mark a nse %s expired", request.GetName())
+	}
+	s.options.event(events.NSEReference(s.namespace, name), corev1.EventTypeNormal, events.Expired, "expired, kept for %v", s.options.sweepGracePeriod)
+	return nil
+}
+
+// unmarkExpired removes the expiration mark of the registered endpoint, failures are only logged as the mark is
+// informational
+func (s *k8sNSEServer) unmarkExpired(ctx context.Context, model *v1.NetworkServiceEndpoint) {
+	patch, err := json.Marshal([]map[string]interface{}{
+		{"op": "remove", "path": "/metadata/annotations/" + strings.ReplaceAll(expiredAnnotation, "/", "~1")},
+	})
+	if err == nil {
+		_, err = s.client.NetworkservicemeshV1().NetworkServiceEndpoints(s.namespace).Patch(ctx, model.GetName(), types.JSONPatchType, patch, metav1.PatchOptions{})
+	}
+	if err != nil && !apierrors.IsInvalid(err) {
+		log.FromContext(ctx).Warnf("failed to remove the expiration mark of a nse %s in a namespace %s: %v", model.GetName(), s.namespace, err)
+	}
+}
+
+// isExpiredForGood returns true if the endpoint is expired and, with the soft delete, kept for longer than the sweep
+// grace period
+func (s *k8sNSEServer) isExpiredForGood(nse *registry.NetworkServiceEndpoint) bool {
+	if !s.options.softDelete {
+		return isExpired(nse)
+	}
+	return nse.GetExpirationTime() != nil && nse.GetExpirationTime().AsTime().Before(time.Now().Add(-s.options.sweepGracePeriod))
+}
+
+// refreshed returns true if the endpoint stored in the custom resource expires later than the request
+func refreshed(model *v1.NetworkServiceEndpoint, request *registry.NetworkServiceEndpoint) bool {
+	expirationTime, storedExpirationTime := request.GetExpirationTime(), (*registry.NetworkServiceEndpoint)(&model.Spec).GetExpirationTime()
+	return expirationTime != nil && storedExpirationTime != nil && storedExpirationTime.AsTime().After(expirationTime.AsTime())
+}
+
+// nseFromModel returns a copy of the endpoint stored in the custom resource, the informer cache must not be modified
+func nseFromModel(model *v1.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	nse := (*registry.NetworkServiceEndpoint)(&model.Spec).Clone()
+	if nse.GetName() == "" {
+		nse.Name = recordName(&model.ObjectMeta)
+	}
+	return nse
+}
+
+func nseModel(meta *metav1.ObjectMeta, nse *registry.NetworkServiceEndpoint) *v1.NetworkServiceEndpoint {
+	model := &v1.NetworkServiceEndpoint{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       "NetworkServiceEndpoint",
+		},
+		ObjectMeta: *meta.DeepCopy(),
+	}
+	proto.Merge((*registry.NetworkServiceEndpoint)(&model.Spec), nse)
+	return model
+}
+
+func isExpired(nse *registry.NetworkServiceEndpoint) bool {
+	return nse.GetExpirationTime() != nil && nse.GetExpirationTime().AsTime().Before(time.Now())
+}
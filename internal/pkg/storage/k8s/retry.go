@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	nsKind  = "ns"
+	nseKind = "nse"
+)
+
+// conflictRetry retries the writes failing on optimistic concurrency conflicts and counts the conflicts
+type conflictRetry struct {
+	backoff   wait.Backoff
+	conflicts metric.Int64Counter
+}
+
+func newConflictRetry(backoff wait.Backoff) *conflictRetry {
+	conflicts, _ := otel.Meter("").Int64Counter("registry_cr_conflict_total",
+		metric.WithDescription("number of custom resource writes failed on an optimistic concurrency conflict"))
+	return &conflictRetry{
+		backoff:   backoff,
+		conflicts: conflicts,
+	}
+}
+
+func (r *conflictRetry) do(ctx context.Context, kind string, fn func() error) error {
+	return retry.OnError(r.backoff, func(err error) bool {
+		if !apierrors.IsConflict(err) {
+			return false
+		}
+		r.conflicts.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", kind)))
+		return ctx.Err() == nil
+	}, fn)
+}
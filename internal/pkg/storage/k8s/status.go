@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"encoding/json"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/apis/networkservicemesh.io/v1"
+)
+
+const registeredCondition = "Registered"
+
+// status is the status block of the custom resources, it requires the status subresource enabled in the CRDs
+type status struct {
+	LastRefreshed metav1.Time        `json:"lastRefreshed"`
+	ExpiresAt     *metav1.Time       `json:"expiresAt,omitempty"`
+	Registry      string             `json:"registry"`
+	Conditions    []metav1.Condition `json:"conditions"`
+}
+
+type statusMeta struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type statusApply struct {
+	metav1.TypeMeta `json:",inline"`
+	Metadata        statusMeta `json:"metadata"`
+	Status          status     `json:"status"`
+}
+
+// statusPatch returns the server-side apply patch of the status subresource for the record registered by the registry
+// at registered and expiring at expires
+func statusPatch(kind, name, namespace, registry string, registered, expires *timestamppb.Timestamp) ([]byte, error) {
+	now := metav1.NewTime(time.Now())
+	transition := now
+	if registered != nil {
+		transition = metav1.NewTime(registered.AsTime())
+	}
+	var expiresAt *metav1.Time
+	if expires != nil {
+		t := metav1.NewTime(expires.AsTime())
+		expiresAt = &t
+	}
+
+	return json.Marshal(&statusApply{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       kind,
+		},
+		Metadata: statusMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Status: status{
+			LastRefreshed: now,
+			ExpiresAt:     expiresAt,
+			Registry:      registry,
+			Conditions: []metav1.Condition{
+				{
+					Type:               registeredCondition,
+					Status:             metav1.ConditionTrue,
+					Reason:             "Refreshed",
+					Message:            "registered by " + registry,
+					LastTransitionTime: transition,
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,397 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package k8s provides storage based on NetworkService and NetworkServiceEndpoint custom resources
+package k8s
+
+import (
+	"context"
+	"io"
+	"maps"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/clientset/versioned"
+	"github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/informers/externalversions"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/errcode"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+const (
+	nseFinalizer = "registry.networkservicemesh.io/unregister"
+	// expiredAnnotation marks the endpoint custom resources kept after they expired with their expiration time
+	expiredAnnotation = "registry.networkservicemesh.io/expired"
+)
+
+type options struct {
+	fieldManager string
+	resyncPeriod time.Duration
+	resyncJitter float64
+
+	conflictBackoff wait.Backoff
+
+	statusRegistry string
+
+	pods       kubernetes.Interface
+	finalizers bool
+
+	sweepInterval    time.Duration
+	sweepGracePeriod time.Duration
+	softDelete       bool
+
+	skipNoopRefreshes bool
+
+	recorder record.EventRecorder
+
+	labels      map[string]string
+	annotations map[string]string
+
+	watchWindow    time.Duration
+	watchQueueSize int
+	watchOverflow  OverflowPolicy
+
+	readOnly bool
+
+	syncTimeout time.Duration
+}
+
+func (o *options) event(ref *corev1.ObjectReference, eventtype, reason, messageFmt string, args ...interface{}) {
+	if o.recorder != nil {
+		o.recorder.Eventf(ref, eventtype, reason, messageFmt, args...)
+	}
+}
+
+// stamp adds the configured labels and annotations to the metadata of a custom resource, the configured labels take
+// precedence over the labels derived from the record
+func (o *options) stamp(meta *metav1.ObjectMeta) {
+	for key, value := range o.labels {
+		if meta.Labels == nil {
+			meta.Labels = make(map[string]string)
+		}
+		meta.Labels[key] = value
+	}
+	for key, value := range o.annotations {
+		if meta.Annotations == nil {
+			meta.Annotations = make(map[string]string)
+		}
+		meta.Annotations[key] = value
+	}
+}
+
+// annotate adds the annotations of the registration carried by ctx to the metadata of a custom resource
+func annotate(ctx context.Context, meta *metav1.ObjectMeta) {
+	for key, value := range storage.AnnotationsFromContext(ctx) {
+		if meta.Annotations == nil {
+			meta.Annotations = make(map[string]string)
+		}
+		meta.Annotations[key] = value
+	}
+}
+
+// Option is an option pattern for the k8s storage
+type Option func(o *options)
+
+// WithFieldManager sets the field manager owning the custom resource fields written by the storage
+func WithFieldManager(fieldManager string) Option {
+	return func(o *options) {
+		o.fieldManager = fieldManager
+	}
+}
+
+// WithResyncPeriod sets the period of the informer resyncs replaying the cached custom resources, 0 disables resyncs
+func WithResyncPeriod(resyncPeriod time.Duration) Option {
+	return func(o *options) {
+		o.resyncPeriod = resyncPeriod
+	}
+}
+
+// WithResyncJitter sets the maximum fraction of the resync period randomly added to it, so the registry replicas
+// don't resync in lockstep
+func WithResyncJitter(resyncJitter float64) Option {
+	return func(o *options) {
+		o.resyncJitter = resyncJitter
+	}
+}
+
+// WithConflictRetry sets how many times and with what initial backoff and jitter the writes failing on optimistic
+// concurrency conflicts are attempted, the backoff doubles with each retry
+func WithConflictRetry(steps int, backoff time.Duration, jitter float64) Option {
+	return func(o *options) {
+		o.conflictBackoff = wait.Backoff{
+			Steps:    steps,
+			Duration: backoff,
+			Factor:   2,
+			Jitter:   jitter,
+		}
+	}
+}
+
+// WithStatus enables populating the status subresource of the custom resources, registry identifies this registry
+// instance as the owner of the registrations. The CRDs need to have the status subresource enabled.
+func WithStatus(registry string) Option {
+	return func(o *options) {
+		o.statusRegistry = registry
+	}
+}
+
+// WithPodOwners makes the pods registering the endpoints the owners of their custom resources, so Kubernetes garbage
+// collects the resources of force deleted pods. Only the pods from the storage namespace can be owners.
+func WithPodOwners(client kubernetes.Interface) Option {
+	return func(o *options) {
+		o.pods = client
+	}
+}
+
+// WithFinalizers adds a finalizer to the endpoint custom resources, so their deletion is reported to the Find watches
+// before the resources vanish. The finalizers are removed from the resources being deleted even without this option.
+func WithFinalizers(finalizers bool) Option {
+	return func(o *options) {
+		o.finalizers = finalizers
+	}
+}
+
+// WithSweeper enables the periodic deletion of the endpoints expired for longer than the grace period, 0 interval
+// disables the sweeper
+func WithSweeper(interval, gracePeriod time.Duration) Option {
+	return func(o *options) {
+		o.sweepInterval = interval
+		o.sweepGracePeriod = gracePeriod
+	}
+}
+
+// WithSoftDelete keeps the custom resources of the expired endpoints for the sweep grace period instead of deleting them
+// once they expire, so the flapping endpoints can be inspected. The kept endpoints are marked with an annotation,
+// reported as deleted to the Find watches and left out of the Find results.
+func WithSoftDelete(softDelete bool) Option {
+	return func(o *options) {
+		o.softDelete = softDelete
+	}
+}
+
+// WithSkipNoopRefreshes skips writing the refreshes of the endpoints changing nothing but the expiration while the
+// stored expiration covers at least half of the requested lifetime. The endpoints refreshing more often than every half
+// of their lifetime are written about once per half of it, the endpoints stopping the refreshes expire up to half of
+// their lifetime earlier.
+func WithSkipNoopRefreshes(skip bool) Option {
+	return func(o *options) {
+		o.skipNoopRefreshes = skip
+	}
+}
+
+// WithEventRecorder sets the recorder of the Kubernetes Events on the custom resources for the registrations,
+// unregistrations and expirations
+func WithEventRecorder(recorder record.EventRecorder) Option {
+	return func(o *options) {
+		o.recorder = recorder
+	}
+}
+
+// WithLabels sets the labels added to every custom resource written by the storage
+func WithLabels(labels map[string]string) Option {
+	return func(o *options) {
+		o.labels = labels
+	}
+}
+
+// WithAnnotations sets the annotations added to every custom resource written by the storage
+func WithAnnotations(annotations map[string]string) Option {
+	return func(o *options) {
+		o.annotations = annotations
+	}
+}
+
+// WithWatchWindow merges the updates of the same record received by a Find watch within the window into the latest
+// one, 0 sends every update
+func WithWatchWindow(window time.Duration) Option {
+	return func(o *options) {
+		o.watchWindow = window
+	}
+}
+
+// WithWatchQueue sets how many updates are queued for each Find watch and what happens to the watches falling further
+// behind, so a slow watch doesn't hold up the updates of the others
+func WithWatchQueue(size int, policy OverflowPolicy) Option {
+	return func(o *options) {
+		o.watchQueueSize = size
+		o.watchOverflow = policy
+	}
+}
+
+// WithReadOnly leaves the deletion of the expired endpoints and the removal of the finalizers to the writable replicas,
+// the sweeper is not started either
+func WithReadOnly(readOnly bool) Option {
+	return func(o *options) {
+		o.readOnly = readOnly
+	}
+}
+
+// WithSyncTimeout sets how long NewStorage waits for the informer caches to sync, 0 waits until ctx is done
+func WithSyncTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.syncTimeout = timeout
+	}
+}
+
+// NewStorage creates storage.Storage keeping network services and endpoints as custom resources in the given namespace.
+// Reads are served from shared informer caches, so NewStorage blocks until the caches are synced to not answer the
+// first queries from an incomplete view. Writes use server-side apply, so registries with distinct field managers
+// can co-own the same resources without read-modify-write conflicts.
+func NewStorage(ctx context.Context, namespace string, client versioned.Interface, opts ...Option) (storage.Storage, error) {
+	o := &options{
+		fieldManager: "registry-k8s",
+		conflictBackoff: wait.Backoff{
+			Steps:    5,
+			Duration: 10 * time.Millisecond,
+			Factor:   2,
+			Jitter:   0.1,
+		},
+		watchQueueSize: 64,
+		watchOverflow:  Disconnect,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	resyncPeriod := o.resyncPeriod
+	if resyncPeriod > 0 && o.resyncJitter > 0 {
+		resyncPeriod = wait.Jitter(resyncPeriod, o.resyncJitter)
+	}
+	factory := externalversions.NewSharedInformerFactoryWithOptions(client, resyncPeriod,
+		externalversions.WithTweakListOptions(tweakListOptions))
+	informers := factory.Networkservicemesh().V1()
+
+	nsInformer := informers.NetworkServices().Informer()
+	nseInformer := informers.NetworkServiceEndpoints().Informer()
+	if err := nsInformer.SetWatchErrorHandler(watchErrorHandler(ctx, "NetworkServices")); err != nil {
+		return nil, errors.Wrap(err, "failed to set NetworkServices watch error handler")
+	}
+	if err := nseInformer.SetWatchErrorHandler(watchErrorHandler(ctx, "NetworkServiceEndpoints")); err != nil {
+		return nil, errors.Wrap(err, "failed to set NetworkServiceEndpoints watch error handler")
+	}
+
+	nsServer, err := newNSServer(ctx, namespace, client, nsInformer, o)
+	if err != nil {
+		return nil, err
+	}
+	nseServer, err := newNSEServer(ctx, namespace, client, nseInformer, o)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.Start(ctx.Done())
+
+	log.FromContext(ctx).Info("waiting for the informer caches to sync")
+	syncCtx, cancelSync := ctx, context.CancelFunc(func() {})
+	if o.syncTimeout > 0 {
+		syncCtx, cancelSync = context.WithTimeout(ctx, o.syncTimeout)
+	}
+	defer cancelSync()
+	for informerType, synced := range factory.WaitForCacheSync(syncCtx.Done()) {
+		if !synced {
+			return nil, errors.Errorf("failed to sync the informer cache for %v", informerType)
+		}
+	}
+
+	if o.sweepInterval > 0 && !o.readOnly {
+		go nseServer.sweep(ctx, o.sweepInterval, o.sweepGracePeriod)
+	}
+
+	return &k8sStorage{
+		Storage:   storage.New(nsServer, nseServer),
+		nsServer:  nsServer,
+		nseServer: nseServer,
+	}, nil
+}
+
+// k8sStorage is a storage.Annotator keeping the annotations of the registrations in the annotations of the custom
+// resources
+type k8sStorage struct {
+	storage.Storage
+	nsServer  *k8sNSServer
+	nseServer *k8sNSEServer
+}
+
+func (s *k8sStorage) NSAnnotations(_ context.Context, name string) (map[string]string, error) {
+	model, err := s.nsServer.lister.NetworkServices(s.nsServer.namespace).Get(objectName(name))
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get a netsvc %s", name)
+	}
+	return maps.Clone(model.GetAnnotations()), nil
+}
+
+func (s *k8sStorage) NSEAnnotations(_ context.Context, name string) (map[string]string, error) {
+	model, err := s.nseServer.lister.NetworkServiceEndpoints(s.nseServer.namespace).Get(objectName(name))
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get a nse %s", name)
+	}
+	// the expired endpoints kept by the soft delete are not stored for the clients
+	if _, ok := model.GetAnnotations()[expiredAnnotation]; ok {
+		return nil, nil
+	}
+	return maps.Clone(model.GetAnnotations()), nil
+}
+
+// tweakListOptions requests bookmarks for the watches, so the informers keep a fresh resourceVersion and resume
+// without a relist after a timeout, and makes the relists not go back in time compared to the last seen version
+func tweakListOptions(options *metav1.ListOptions) {
+	if options.AllowWatchBookmarks || options.Watch {
+		options.AllowWatchBookmarks = true
+		return
+	}
+	if options.Continue == "" && options.ResourceVersion != "" && options.ResourceVersion != "0" {
+		options.ResourceVersionMatch = metav1.ResourceVersionMatchNotOlderThan
+	}
+}
+
+// watchErrorHandler logs the watch failures. The informers relist on their own when the resourceVersion they watch
+// from has been compacted (410 Gone) and deliver the changes missed in between to the Find watches, deletions included.
+func watchErrorHandler(ctx context.Context, resource string) cache.WatchErrorHandler {
+	logger := log.FromContext(ctx).WithField("k8s", "watchErrorHandler")
+	return func(_ *cache.Reflector, err error) {
+		switch {
+		case errors.Is(err, io.EOF):
+			// watch closed normally
+		case apierrors.IsResourceExpired(err) || apierrors.IsGone(err):
+			logger.Infof("watch of %s expired, relisting: %v", resource, err)
+		default:
+			logger.WithField(errcode.LogField, string(errcode.Of(err))).Warnf("watch of %s failed: %v", resource, err)
+		}
+	}
+}
+
+// isResync returns true for the updates replaying an unchanged object on the informer resyncs and relists
+func isResync(oldObj, obj interface{}) bool {
+	oldModel, oldOk := oldObj.(metav1.Object)
+	model, ok := obj.(metav1.Object)
+	return oldOk && ok && oldModel.GetResourceVersion() == model.GetResourceVersion()
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	v1 "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/apis/networkservicemesh.io/v1"
+	versionedfake "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/clientset/versioned/fake"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/fakek8s"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/registrytest"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+const testNamespace = "default"
+
+func newTestStorage(t *testing.T, client *versionedfake.Clientset, opts ...Option) storage.Storage {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	s, err := NewStorage(ctx, testNamespace, client, opts...)
+	if err != nil {
+		t.Fatalf("failed to create the storage: %v", err)
+	}
+	return s
+}
+
+func register(t *testing.T, s storage.Storage, nse *registry.NetworkServiceEndpoint) {
+	t.Helper()
+	if _, err := s.NetworkServiceEndpointRegistryServer().Register(context.Background(), nse.Clone()); err != nil {
+		t.Fatalf("failed to register %s: %v", nse.GetName(), err)
+	}
+}
+
+func unregister(t *testing.T, s storage.Storage, nse *registry.NetworkServiceEndpoint) {
+	t.Helper()
+	if _, err := s.NetworkServiceEndpointRegistryServer().Unregister(context.Background(), nse.Clone()); err != nil {
+		t.Fatalf("failed to unregister %s: %v", nse.GetName(), err)
+	}
+}
+
+// getNSE returns the custom resource of the endpoint from the apiserver or nil if it does not exist
+func getNSE(t *testing.T, client *versionedfake.Clientset, name string) *v1.NetworkServiceEndpoint {
+	t.Helper()
+	model, err := client.NetworkservicemeshV1().NetworkServiceEndpoints(testNamespace).Get(context.Background(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("failed to get the nse %s: %v", name, err)
+	}
+	return model
+}
+
+// waitCached waits for the informer cache of the storage to have the version of the endpoint
+func waitCached(t *testing.T, s storage.Storage, model *v1.NetworkServiceEndpoint) {
+	t.Helper()
+	lister := s.(*k8sStorage).nseServer.lister.NetworkServiceEndpoints(testNamespace)
+	registrytest.Eventually(t, func() bool {
+		cached, err := lister.Get(model.GetName())
+		return err == nil && cached.GetResourceVersion() == model.GetResourceVersion()
+	}, "the informer cache does not get the nse %s", model.GetName())
+}
+
+// watchNSEs starts a Find watch of all the endpoints and returns its updates once it is subscribed
+func watchNSEs(t *testing.T, s storage.Storage) <-chan *registry.NetworkServiceEndpointResponse {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	updates, _ := registrytest.WatchNSEs(ctx, s.NetworkServiceEndpointRegistryServer(), &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
+		Watch:                  true,
+	}, 100)
+
+	probe := &registry.NetworkServiceEndpoint{Name: "probe"}
+	registrytest.WaitSubscribed(t, updates, func() string {
+		register(t, s, probe)
+		return probe.GetName()
+	})
+	return updates
+}
+
+// waitUpdate waits for the watch to receive the update of the endpoint
+func waitUpdate(t *testing.T, updates <-chan *registry.NetworkServiceEndpointResponse, name string, deleted bool) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case resp := <-updates:
+			if resp.GetNetworkServiceEndpoint().GetName() == name && resp.GetDeleted() == deleted {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("the watch does not receive the update of the nse %s with deleted %v", name, deleted)
+		}
+	}
+}
+
+func TestRegisterRetriesConflicts(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		conflicts int32
+		steps     int
+		wantErr   bool
+	}{
+		{name: "retried", conflicts: 2, steps: 3},
+		{name: "exhausted", conflicts: 3, steps: 3, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakek8s.NewVersionedClient()
+			var attempts atomic.Int32
+			client.PrependReactor("patch", "networkserviceendpoints", func(k8stesting.Action) (bool, runtime.Object, error) {
+				if attempts.Add(1) <= tc.conflicts {
+					return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "networkserviceendpoints"}, "nse-1", errors.New("modified"))
+				}
+				return false, nil, nil
+			})
+			s := newTestStorage(t, client, WithConflictRetry(tc.steps, time.Millisecond, 0))
+
+			_, err := s.NetworkServiceEndpointRegistryServer().Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+			if got := attempts.Load(); got != int32(tc.steps) {
+				t.Errorf("got %d attempts, want %d", got, tc.steps)
+			}
+			if tc.wantErr {
+				if !apierrors.IsConflict(err) {
+					t.Errorf("got %v, want a conflict once the retries are exhausted", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("the registration is not retried: %v", err)
+			}
+			if getNSE(t, client, "nse-1") == nil {
+				t.Error("the retried registration is not stored")
+			}
+		})
+	}
+}
+
+func TestUnregisterKeepsEndpointRefreshedThroughAnotherReplica(t *testing.T) {
+	client := fakek8s.NewVersionedClient()
+	first, second := newTestStorage(t, client), newTestStorage(t, client)
+
+	nse := &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      timestamppb.New(time.Now().Add(time.Minute)),
+	}
+	register(t, first, nse)
+	waitCached(t, first, getNSE(t, client, "nse-1"))
+	refreshed := nse.Clone()
+	refreshed.ExpirationTime = timestamppb.New(time.Now().Add(2 * time.Minute))
+	register(t, second, refreshed)
+
+	// the expiration of the first replica fires for the expiration it served, its cache may be behind the refresh
+	unregister(t, first, nse)
+	if getNSE(t, client, "nse-1") == nil {
+		t.Fatal("the nse refreshed through another replica is deleted")
+	}
+
+	unregister(t, second, refreshed)
+	if getNSE(t, client, "nse-1") != nil {
+		t.Error("the nse is not deleted once it is not refreshed")
+	}
+}
+
+func TestUnregisterPreconditionKeepsRefreshedEndpoint(t *testing.T) {
+	client := fakek8s.NewVersionedClient()
+	s := newTestStorage(t, client, WithConflictRetry(2, time.Millisecond, 0))
+
+	nse := &registry.NetworkServiceEndpoint{Name: "nse-1", ExpirationTime: timestamppb.New(time.Now().Add(time.Minute))}
+	register(t, s, nse)
+	stale := getNSE(t, client, "nse-1").GetResourceVersion()
+	nse.ExpirationTime = timestamppb.New(time.Now().Add(2 * time.Minute))
+	register(t, s, nse)
+
+	// the version of the registration served by the chain is carried by the context
+	ctx := withNSEVersion(context.Background(), stale)
+	if _, err := s.NetworkServiceEndpointRegistryServer().Unregister(ctx, nse.Clone()); err != nil {
+		t.Fatalf("unregister failed: %v", err)
+	}
+	if getNSE(t, client, "nse-1") == nil {
+		t.Error("the nse is deleted by the unregistration of its stale version")
+	}
+}
+
+func TestFinalizersReportDeletionBeforeRemoval(t *testing.T) {
+	client := fakek8s.NewVersionedClient()
+	s := newTestStorage(t, client, WithFinalizers(true))
+	updates := watchNSEs(t, s)
+
+	nse := &registry.NetworkServiceEndpoint{Name: "nse-1", NetworkServiceNames: []string{"ns-1"}}
+	register(t, s, nse)
+	model := getNSE(t, client, "nse-1")
+	if len(model.GetFinalizers()) != 1 || model.GetFinalizers()[0] != nseFinalizer {
+		t.Fatalf("got the finalizers %v, want %s", model.GetFinalizers(), nseFinalizer)
+	}
+	waitUpdate(t, updates, "nse-1", false)
+	waitCached(t, s, model)
+
+	unregister(t, s, nse)
+	// the finalizer keeps the resource until the watches are notified
+	waitUpdate(t, updates, "nse-1", true)
+	registrytest.Eventually(t, func() bool {
+		return getNSE(t, client, "nse-1") == nil
+	}, "the finalizer of the deleted nse is not removed")
+}
+
+func TestFinalizersAreLeftToWritableReplicas(t *testing.T) {
+	client := fakek8s.NewVersionedClient()
+	readOnly := newTestStorage(t, client, WithFinalizers(true), WithReadOnly(true))
+
+	register(t, readOnly, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	if err := client.NetworkservicemeshV1().NetworkServiceEndpoints(testNamespace).Delete(context.Background(), "nse-1", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	waitCached(t, readOnly, getNSE(t, client, "nse-1"))
+	time.Sleep(100 * time.Millisecond)
+	if getNSE(t, client, "nse-1") == nil {
+		t.Fatal("the finalizer is removed by the read-only replica")
+	}
+
+	newTestStorage(t, client, WithFinalizers(true))
+	registrytest.Eventually(t, func() bool {
+		return getNSE(t, client, "nse-1") == nil
+	}, "the finalizer of the deleted nse is not removed by the writable replica")
+}
+
+func TestSoftDeleteKeepsExpiredEndpoint(t *testing.T) {
+	client := fakek8s.NewVersionedClient()
+	s := newTestStorage(t, client, WithSoftDelete(true), WithSweeper(0, time.Hour))
+	updates := watchNSEs(t, s)
+
+	nse := &registry.NetworkServiceEndpoint{Name: "nse-1", ExpirationTime: timestamppb.New(time.Now().Add(-time.Second))}
+	register(t, s, nse)
+	waitUpdate(t, updates, "nse-1", true)
+	waitCached(t, s, getNSE(t, client, "nse-1"))
+
+	unregister(t, s, nse)
+	model := getNSE(t, client, "nse-1")
+	if model == nil {
+		t.Fatal("the expired nse is deleted instead of being kept")
+	}
+	if _, ok := model.GetAnnotations()[expiredAnnotation]; !ok {
+		t.Fatalf("the expired nse is not marked, got the annotations %v", model.GetAnnotations())
+	}
+	// the mark notifies the watches about the expiration
+	waitUpdate(t, updates, "nse-1", true)
+	waitCached(t, s, model)
+	query := &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "nse-1"}}
+	if names := registrytest.FindNSENames(context.Background(), t, s.NetworkServiceEndpointRegistryServer(), query); len(names) != 0 {
+		t.Errorf("Find returns the expired nse: %v", names)
+	}
+	if annotations, err := s.(storage.Annotator).NSEAnnotations(context.Background(), "nse-1"); err != nil || annotations != nil {
+		t.Errorf("got the annotations %v, %v of the expired nse, want none", annotations, err)
+	}
+
+	nse.ExpirationTime = timestamppb.New(time.Now().Add(time.Minute))
+	register(t, s, nse)
+	model = getNSE(t, client, "nse-1")
+	if _, ok := model.GetAnnotations()[expiredAnnotation]; ok {
+		t.Fatal("the mark of the registered nse is not removed")
+	}
+	waitCached(t, s, model)
+	if names := registrytest.FindNSENames(context.Background(), t, s.NetworkServiceEndpointRegistryServer(), query); len(names) != 1 {
+		t.Errorf("got %v, want the registered nse", names)
+	}
+}
+
+func TestSweeperDeletesEndpointsExpiredForGood(t *testing.T) {
+	client := fakek8s.NewVersionedClient()
+	s := newTestStorage(t, client, WithSoftDelete(true), WithSweeper(10*time.Millisecond, 500*time.Millisecond))
+
+	nse := &registry.NetworkServiceEndpoint{Name: "nse-1", ExpirationTime: timestamppb.New(time.Now())}
+	register(t, s, nse)
+	waitCached(t, s, getNSE(t, client, "nse-1"))
+	unregister(t, s, nse)
+	if model := getNSE(t, client, "nse-1"); model == nil || model.GetAnnotations()[expiredAnnotation] == "" {
+		t.Fatalf("the expired nse is not kept for the grace period, got %v", model)
+	}
+
+	registrytest.Eventually(t, func() bool {
+		return getNSE(t, client, "nse-1") == nil
+	}, "the sweeper does not delete the nse once the grace period passes")
+}
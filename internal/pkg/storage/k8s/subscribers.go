@@ -0,0 +1,190 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"container/list"
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/edwarnicke/serialize"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// OverflowPolicy decides what happens to a Find watch whose queue of updates is full
+type OverflowPolicy string
+
+// The overflow policies
+const (
+	// DropOldest drops the oldest queued update and resends the current state of the matching records to the watch
+	// before its next update
+	DropOldest OverflowPolicy = "drop-oldest"
+	// Disconnect ends the watch with ResourceExhausted, so the client starts a new one
+	Disconnect OverflowPolicy = "disconnect"
+)
+
+// Decode implements envconfig.Decoder
+func (p *OverflowPolicy) Decode(value string) error {
+	switch policy := OverflowPolicy(value); policy {
+	case DropOldest, Disconnect:
+		*p = policy
+		return nil
+	default:
+		return errors.Errorf("unknown overflow policy %s, expected drop-oldest or disconnect", value)
+	}
+}
+
+// subscriber is a Find watch receiving the updates through a bounded queue
+type subscriber[R any] struct {
+	updates chan R
+	// resync is set once an update is dropped, so the watch resends the current state
+	resync atomic.Bool
+	// overflowed and closed are only changed by the executor, overflowed is read after updates is closed
+	overflowed bool
+	closed     bool
+}
+
+// err returns the error ending the watch once updates is closed
+func (s *subscriber[R]) err() error {
+	if s.overflowed {
+		return grpcstatus.Error(codes.ResourceExhausted, "the watch fell behind the updates")
+	}
+	return nil
+}
+
+// watch sends the matching updates to the watch until the subscription ends. Once updates were dropped, resend sends
+// the current state of the matching records before the next update, the deletions dropped meanwhile are not resent.
+func (s *subscriber[R]) watch(window time.Duration, match func(R) bool, name func(R) string, resend func() error, send func(R) error) error {
+	err := coalesce(s.updates, window,
+		func(update R) bool {
+			return s.resync.Load() || match(update)
+		},
+		name,
+		func(update R) error {
+			if s.resync.Swap(false) {
+				if err := resend(); err != nil {
+					return err
+				}
+			}
+			if !match(update) {
+				return nil
+			}
+			return send(update)
+		})
+	if err != nil {
+		return err
+	}
+	return s.err()
+}
+
+// subscribers fans the updates out to the Find watches without blocking on a stalled one
+type subscribers[R any] struct {
+	kind      string
+	size      int
+	policy    OverflowPolicy
+	overflows metric.Int64Counter
+
+	executor serialize.Executor
+	list     *list.List
+}
+
+func newSubscribers[R any](kind string, o *options) *subscribers[R] {
+	overflows, _ := otel.Meter("").Int64Counter("registry_watch_overflows_total",
+		metric.WithDescription("number of the updates that did not fit into the queue of a Find watch by the overflow policy"))
+	return &subscribers[R]{
+		kind:      kind,
+		size:      o.watchQueueSize,
+		policy:    o.watchOverflow,
+		overflows: overflows,
+		list:      list.New(),
+	}
+}
+
+// subscribe adds a watch receiving the updates until ctx is done
+func (s *subscribers[R]) subscribe(ctx context.Context) *subscriber[R] {
+	sub := &subscriber[R]{
+		updates: make(chan R, s.size),
+	}
+	var node *list.Element
+
+	s.executor.AsyncExec(func() {
+		node = s.list.PushBack(sub)
+	})
+
+	go func() {
+		<-ctx.Done()
+
+		s.executor.AsyncExec(func() {
+			s.remove(node)
+		})
+	}()
+
+	return sub
+}
+
+// send queues the update for every watch
+func (s *subscribers[R]) send(update R) {
+	s.executor.AsyncExec(func() {
+		for curr := s.list.Front(); curr != nil; {
+			node := curr
+			curr = curr.Next()
+			s.push(node, update)
+		}
+	})
+}
+
+func (s *subscribers[R]) push(node *list.Element, update R) {
+	sub := node.Value.(*subscriber[R])
+	select {
+	case sub.updates <- update:
+		return
+	default:
+	}
+
+	s.overflows.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("kind", s.kind), attribute.String("policy", string(s.policy))))
+	if s.policy == Disconnect {
+		sub.overflowed = true
+		s.remove(node)
+		return
+	}
+	// Only the executor adds the updates, so the queue has room once the oldest one is dropped
+	select {
+	case <-sub.updates:
+	default:
+	}
+	sub.resync.Store(true)
+	sub.updates <- update
+}
+
+func (s *subscribers[R]) remove(node *list.Element) {
+	sub := node.Value.(*subscriber[R])
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	s.list.Remove(node)
+	close(sub.updates)
+}
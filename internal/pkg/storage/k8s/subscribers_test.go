@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+func TestSubscribersOverflow(t *testing.T) {
+	for _, tc := range []struct {
+		policy     OverflowPolicy
+		wantSent   []string
+		wantResend int
+		wantCode   codes.Code
+	}{
+		{policy: Disconnect, wantSent: []string{"1"}, wantCode: codes.ResourceExhausted},
+		{policy: DropOldest, wantSent: []string{"3"}, wantResend: 1, wantCode: codes.OK},
+	} {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			s := newSubscribers[string](nseKind, &options{watchQueueSize: 1, watchOverflow: tc.policy})
+			ctx, cancel := context.WithCancel(context.Background())
+			sub := s.subscribe(ctx)
+			// the watch is stalled until the updates overflow its queue
+			for _, update := range []string{"1", "2", "3"} {
+				s.send(update)
+			}
+			<-s.executor.AsyncExec(func() {})
+			cancel()
+
+			var sent []string
+			resend := 0
+			err := sub.watch(0,
+				func(string) bool { return true },
+				func(update string) string { return update },
+				func() error {
+					resend++
+					return nil
+				},
+				func(update string) error {
+					sent = append(sent, update)
+					return nil
+				})
+			if code := grpcstatus.Code(err); code != tc.wantCode {
+				t.Errorf("the watch ended with %v, want %v", err, tc.wantCode)
+			}
+			if !slices.Equal(sent, tc.wantSent) {
+				t.Errorf("got the updates %v, want %v", sent, tc.wantSent)
+			}
+			if resend != tc.wantResend {
+				t.Errorf("the current state is resent %d times, want %d", resend, tc.wantResend)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/errcode"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/events"
+)
+
+// sweep periodically deletes the endpoints expired for longer than the grace period. The expired endpoints are
+// otherwise deleted only when a Find or a change of the endpoint touches them, so the endpoints expired while the
+// registry was down stay around until then.
+func (s *k8sNSEServer) sweep(ctx context.Context, interval, grace time.Duration) {
+	swept, _ := otel.Meter("").Int64Counter("registry_swept_total",
+		metric.WithDescription("number of expired custom resources deleted by the sweeper"))
+	logger := log.FromContext(ctx).WithField("k8sNSEServer", "sweep")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		models, err := s.lister.NetworkServiceEndpoints(s.namespace).List(labels.Everything())
+		if err != nil {
+			logger.Warnf("failed to get a list of NetworkServiceEndpoints: %v", err)
+		}
+		deadline := time.Now().Add(-grace)
+		for _, model := range models {
+			expirationTime := (*registry.NetworkServiceEndpoint)(&model.Spec).GetExpirationTime()
+			if model.GetDeletionTimestamp() != nil || expirationTime == nil || expirationTime.AsTime().After(deadline) {
+				continue
+			}
+			version := model.GetResourceVersion()
+			deleteErr := s.client.NetworkservicemeshV1().NetworkServiceEndpoints(s.namespace).Delete(ctx, model.GetName(), metav1.DeleteOptions{
+				Preconditions: &metav1.Preconditions{
+					ResourceVersion: &version,
+				},
+			})
+			switch {
+			case deleteErr == nil:
+				logger.Infof("swept a nse %s in a namespace %s expired at %v", model.GetName(), s.namespace, expirationTime.AsTime())
+				swept.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", nseKind)))
+				s.options.event(events.NSEReference(s.namespace, model.GetName()), corev1.EventTypeNormal, events.Expired, "expired at %v", expirationTime.AsTime())
+			case !apierrors.IsNotFound(deleteErr) && !apierrors.IsConflict(deleteErr):
+				logger.WithField(errcode.LogField, string(errcode.Of(deleteErr))).Warnf("failed to sweep a nse %s in a namespace %s: %v", model.GetName(), s.namespace, deleteErr)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
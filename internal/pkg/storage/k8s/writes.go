@@ -0,0 +1,68 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/latency"
+)
+
+const (
+	applyOp  = "apply"
+	deleteOp = "delete"
+)
+
+// writeTimer records the duration of the custom resource writes to the apiserver, including the conflict retries, and
+// the skipped writes
+type writeTimer struct {
+	writes  metric.Float64Histogram
+	skipped metric.Int64Counter
+}
+
+func newWriteTimer() *writeTimer {
+	writes, _ := otel.Meter("").Float64Histogram("registry_k8s_write_duration_seconds",
+		metric.WithDescription("duration of the custom resource writes to the apiserver including the conflict retries"), metric.WithUnit("s"))
+	skipped, _ := otel.Meter("").Int64Counter("registry_k8s_writes_skipped_total",
+		metric.WithDescription("number of the refreshes not written to the apiserver as they change nothing but the expiration"))
+	return &writeTimer{
+		writes:  writes,
+		skipped: skipped,
+	}
+}
+
+func (t *writeTimer) skip(ctx context.Context, kind string) {
+	t.skipped.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", kind)))
+}
+
+func (t *writeTimer) record(ctx context.Context, kind, op string, start time.Time, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	duration := time.Since(start)
+	latency.Observe(ctx, "k8s."+op, duration)
+	t.writes.Record(ctx, duration.Seconds(), metric.WithAttributes(
+		attribute.String("kind", kind), attribute.String("op", op), attribute.String("outcome", outcome)))
+}
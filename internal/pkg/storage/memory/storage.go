@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memory provides storage keeping network services and endpoints in the registry process memory
+package memory
+
+import (
+	"context"
+	"maps"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+type memoryStorage struct {
+	storage.Storage
+	nsAnnotations  *genericsync.Map[string, map[string]string]
+	nseAnnotations *genericsync.Map[string, map[string]string]
+}
+
+// NewStorage creates storage.Storage keeping network services and endpoints in memory, the same way cmd-registry-memory
+// does. The storage is a storage.Annotator.
+func NewStorage() storage.Storage {
+	s := &memoryStorage{
+		nsAnnotations:  new(genericsync.Map[string, map[string]string]),
+		nseAnnotations: new(genericsync.Map[string, map[string]string]),
+	}
+	s.Storage = storage.New(
+		chain.NewNetworkServiceRegistryServer(
+			memory.NewNetworkServiceRegistryServer(),
+			&annotationsNSServer{annotations: s.nsAnnotations},
+		),
+		chain.NewNetworkServiceEndpointRegistryServer(
+			memory.NewNetworkServiceEndpointRegistryServer(),
+			&annotationsNSEServer{annotations: s.nseAnnotations},
+		),
+	)
+	return s
+}
+
+func (s *memoryStorage) NSAnnotations(_ context.Context, name string) (map[string]string, error) {
+	annotations, _ := s.nsAnnotations.Load(name)
+	return maps.Clone(annotations), nil
+}
+
+func (s *memoryStorage) NSEAnnotations(_ context.Context, name string) (map[string]string, error) {
+	annotations, _ := s.nseAnnotations.Load(name)
+	return maps.Clone(annotations), nil
+}
+
+// annotationsNSServer keeps the annotations of the network services stored by the memory server before it
+type annotationsNSServer struct {
+	annotations *genericsync.Map[string, map[string]string]
+}
+
+func (s *annotationsNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	s.annotations.Store(ns.GetName(), maps.Clone(storage.AnnotationsFromContext(ctx)))
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *annotationsNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *annotationsNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	s.annotations.Delete(ns.GetName())
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
+
+// annotationsNSEServer keeps the annotations of the endpoints stored by the memory server before it
+type annotationsNSEServer struct {
+	annotations *genericsync.Map[string, map[string]string]
+}
+
+func (s *annotationsNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	s.annotations.Store(nse.GetName(), maps.Clone(storage.AnnotationsFromContext(ctx)))
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *annotationsNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *annotationsNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	s.annotations.Delete(nse.GetName())
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/identity"
+)
+
+const (
+	// anonymous is the identity the endpoints registered without a SPIFFE ID are counted in, they share its quota
+	anonymous = "anonymous"
+	// other is the identity the usage of the identities beyond the maxObservedIdentities biggest ones is reported as
+	other = "other"
+	// maxObservedIdentities is the number of the identities with the most endpoints whose usage is reported on its own
+	maxObservedIdentities = 100
+)
+
+// owner is the workload that has registered an endpoint
+type owner struct {
+	namespace string
+	identity  string
+}
+
+type quotaNSEServer struct {
+	storage  registry.NetworkServiceEndpointRegistryServer
+	options  *options
+	exceeded metric.Int64Counter
+	usage    metric.Int64ObservableGauge
+
+	mu         sync.Mutex
+	owners     map[string]owner
+	namespaces map[string]int
+	identities map[string]int
+}
+
+func (s *quotaNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	o := owner{identity: anonymous}
+	if id, ok := identity.SpiffeIDFromContext(ctx); ok {
+		o.identity = id.String()
+	}
+	o.namespace, _, _ = identity.PodFromContext(ctx)
+
+	reserved, err := s.reserve(ctx, nse.GetName(), o)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.storage.Register(ctx, nse)
+	if err != nil {
+		if reserved {
+			s.release(o)
+		}
+		return nil, err
+	}
+	if reserved {
+		s.commit(resp.GetName(), o)
+	}
+	return resp, nil
+}
+
+func (s *quotaNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *quotaNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	resp, err := s.storage.Unregister(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	s.remove(nse.GetName())
+	return resp, nil
+}
+
+// reserve counts the endpoint registered by the owner in its quotas before it is stored, so the concurrent
+// registrations can't exceed them. It fails if registering a new endpoint by the owner exceeds a quota and returns
+// false for the refreshes, they are always allowed and need no reservation.
+func (s *quotaNSEServer) reserve(ctx context.Context, name string, o owner) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, ok := s.owners[name]; ok && current == o {
+		return false, nil
+	}
+	if o.namespace != "" && s.options.maxPerNamespace > 0 && s.namespaces[o.namespace] >= s.options.maxPerNamespace {
+		s.exceeded.Add(ctx, 1, metric.WithAttributes(attribute.String("quota", "namespace")))
+		return false, status.Errorf(codes.ResourceExhausted, "namespace %s exceeded the quota of %d endpoints", o.namespace, s.options.maxPerNamespace)
+	}
+	if s.options.maxPerIdentity > 0 && s.identities[o.identity] >= s.options.maxPerIdentity {
+		s.exceeded.Add(ctx, 1, metric.WithAttributes(attribute.String("quota", "identity")))
+		return false, status.Errorf(codes.ResourceExhausted, "%s exceeded the quota of %d endpoints", o.identity, s.options.maxPerIdentity)
+	}
+	s.count(o, 1)
+	return true, nil
+}
+
+// release returns the reservation of the endpoint that failed to be stored
+func (s *quotaNSEServer) release(o owner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.count(o, -1)
+}
+
+// commit makes the owner's reservation the endpoint's one, replacing the one of its previous owner
+func (s *quotaNSEServer) commit(name string, o owner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeLocked(name)
+	s.owners[name] = o
+}
+
+func (s *quotaNSEServer) remove(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeLocked(name)
+}
+
+func (s *quotaNSEServer) removeLocked(name string) {
+	o, ok := s.owners[name]
+	if !ok {
+		return
+	}
+	delete(s.owners, name)
+	s.count(o, -1)
+}
+
+func (s *quotaNSEServer) count(o owner, delta int) {
+	if o.namespace != "" {
+		if s.namespaces[o.namespace] += delta; s.namespaces[o.namespace] == 0 {
+			delete(s.namespaces, o.namespace)
+		}
+	}
+	if s.identities[o.identity] += delta; s.identities[o.identity] == 0 {
+		delete(s.identities, o.identity)
+	}
+}
+
+// observe reports the number of the endpoints registered and being registered by the maxObservedIdentities SPIFFE IDs
+// with the most endpoints, the usage of the rest is summed up, so the number of the reported series is bounded
+func (s *quotaNSEServer) observe(_ context.Context, observer metric.Observer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.identities))
+	for id := range s.identities {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b string) int {
+		return cmp.Or(cmp.Compare(s.identities[b], s.identities[a]), cmp.Compare(a, b))
+	})
+	var rest int
+	for i, id := range ids {
+		if i >= maxObservedIdentities {
+			rest += s.identities[id]
+			continue
+		}
+		observer.ObserveInt64(s.usage, int64(s.identities[id]), metric.WithAttributes(attribute.String("identity", id)))
+	}
+	if rest > 0 {
+		observer.ObserveInt64(s.usage, int64(rest), metric.WithAttributes(attribute.String("identity", other)))
+	}
+	return nil
+}
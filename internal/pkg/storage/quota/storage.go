@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quota provides storage limiting the number of endpoints registered per namespace and per SPIFFE ID
+package quota
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+type options struct {
+	maxPerNamespace int
+	maxPerIdentity  int
+}
+
+// Option is an option pattern for the quota storage
+type Option func(o *options)
+
+// WithMaxPerNamespace sets the maximum number of endpoints registered from a namespace, 0 means unlimited
+func WithMaxPerNamespace(maxPerNamespace int) Option {
+	return func(o *options) {
+		o.maxPerNamespace = maxPerNamespace
+	}
+}
+
+// WithMaxPerIdentity sets the maximum number of endpoints registered by a SPIFFE ID, 0 means unlimited
+func WithMaxPerIdentity(maxPerIdentity int) Option {
+	return func(o *options) {
+		o.maxPerIdentity = maxPerIdentity
+	}
+}
+
+// NewStorage creates storage.Storage rejecting the registrations of new endpoints exceeding the quotas with
+// codes.ResourceExhausted. The endpoints registered without a SPIFFE ID share one identity quota. The usage is tracked
+// in memory from the registrations passing through the storage, so the quotas are enforced by each replica on its own,
+// N replicas accept up to N times the quota, and the usage is rebuilt by the refreshes after a restart of the
+// registry. The usage of the SPIFFE IDs with the most endpoints is exported as a gauge.
+func NewStorage(s storage.Storage, opts ...Option) storage.Storage {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	meter := otel.Meter("")
+	exceeded, _ := meter.Int64Counter("registry_quota_exceeded_total",
+		metric.WithDescription("number of endpoint registrations rejected for exceeding a quota"))
+	usage, _ := meter.Int64ObservableGauge("registry_quota_identity_endpoints",
+		metric.WithDescription("number of endpoints registered by a SPIFFE ID counted in its quota, the ones of the SPIFFE IDs beyond the 100 with the most endpoints are summed up as other"))
+
+	nseServer := &quotaNSEServer{
+		storage:    s.NetworkServiceEndpointRegistryServer(),
+		options:    o,
+		exceeded:   exceeded,
+		usage:      usage,
+		owners:     make(map[string]owner),
+		namespaces: make(map[string]int),
+		identities: make(map[string]int),
+	}
+	_, _ = meter.RegisterCallback(nseServer.observe, usage)
+
+	return storage.New(s.NetworkServiceRegistryServer(), nseServer)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/registrytest"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/memory"
+)
+
+func TestQuotas(t *testing.T) {
+	type registration struct {
+		namespace, pod, name string
+		exhausted            bool
+	}
+	for _, tc := range []struct {
+		name          string
+		opts          []Option
+		registrations []registration
+	}{
+		{
+			name: "namespace quota",
+			opts: []Option{WithMaxPerNamespace(2)},
+			registrations: []registration{
+				{namespace: "a", pod: "pod-1", name: "nse-1"},
+				{namespace: "a", pod: "pod-2", name: "nse-2"},
+				{namespace: "a", pod: "pod-3", name: "nse-3", exhausted: true},
+				{namespace: "b", pod: "pod-1", name: "nse-4"},
+			},
+		},
+		{
+			name: "identity quota",
+			opts: []Option{WithMaxPerIdentity(1)},
+			registrations: []registration{
+				{namespace: "a", pod: "pod-1", name: "nse-1"},
+				{namespace: "a", pod: "pod-1", name: "nse-2", exhausted: true},
+				{namespace: "a", pod: "pod-2", name: "nse-3"},
+			},
+		},
+		{
+			name: "refresh at the quota",
+			opts: []Option{WithMaxPerIdentity(1)},
+			registrations: []registration{
+				{namespace: "a", pod: "pod-1", name: "nse-1"},
+				{namespace: "a", pod: "pod-1", name: "nse-1"},
+			},
+		},
+		{
+			name: "endpoint taken over by another identity",
+			opts: []Option{WithMaxPerIdentity(1)},
+			registrations: []registration{
+				{namespace: "a", pod: "pod-1", name: "nse-1"},
+				{namespace: "a", pod: "pod-2", name: "nse-1"},
+				{namespace: "a", pod: "pod-1", name: "nse-2"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewStorage(memory.NewStorage(), tc.opts...).NetworkServiceEndpointRegistryServer()
+			for _, r := range tc.registrations {
+				_, err := s.Register(registrytest.PodContext(t, r.namespace, r.pod), &registry.NetworkServiceEndpoint{Name: r.name})
+				if exhausted := status.Code(err) == codes.ResourceExhausted; exhausted != r.exhausted {
+					t.Errorf("registration of %s by %s/%s returned %v", r.name, r.namespace, r.pod, err)
+				}
+			}
+		})
+	}
+}
+
+func TestUnregisterFreesQuota(t *testing.T) {
+	s := NewStorage(memory.NewStorage(), WithMaxPerIdentity(1)).NetworkServiceEndpointRegistryServer()
+	ctx := registrytest.PodContext(t, "a", "pod-1")
+
+	if _, err := s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1"}); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if _, err := s.Unregister(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1"}); err != nil {
+		t.Fatalf("unregister failed: %v", err)
+	}
+	if _, err := s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-2"}); err != nil {
+		t.Errorf("register after the unregistration failed: %v", err)
+	}
+}
+
+func TestConcurrentRegistrationsReserveQuota(t *testing.T) {
+	memoryStorage := memory.NewStorage()
+	backend := registrytest.NewBlockingNSEServer(memoryStorage.NetworkServiceEndpointRegistryServer())
+	s := NewStorage(storage.New(memoryStorage.NetworkServiceRegistryServer(), backend), WithMaxPerIdentity(1)).NetworkServiceEndpointRegistryServer()
+	ctx := registrytest.PodContext(t, "a", "pod-1")
+
+	done := make(chan error)
+	go func() {
+		_, err := s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+		done <- err
+	}()
+	<-backend.Started
+
+	// nse-1 is not stored yet, but it already holds the only slot of the quota
+	if _, err := s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-2"}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("concurrent registration returned %v, want ResourceExhausted", err)
+	}
+
+	backend.Release <- errors.New("failed")
+	if err := <-done; err == nil {
+		t.Fatal("register succeeded with the storage failing")
+	}
+
+	// The failed registration releases its slot
+	go func() {
+		<-backend.Started
+		backend.Release <- nil
+	}()
+	if _, err := s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-2"}); err != nil {
+		t.Errorf("register after the failed registration failed: %v", err)
+	}
+}
+
+func TestAnonymousRegistrationsShareQuota(t *testing.T) {
+	s := NewStorage(memory.NewStorage(), WithMaxPerIdentity(1)).NetworkServiceEndpointRegistryServer()
+
+	if _, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"}); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if _, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-2"}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("second registration without a SPIFFE ID returned %v, want ResourceExhausted", err)
+	}
+	if _, err := s.Register(registrytest.PodContext(t, "a", "pod-1"), &registry.NetworkServiceEndpoint{Name: "nse-3"}); err != nil {
+		t.Errorf("registration with a SPIFFE ID failed: %v", err)
+	}
+}
+
+// recordingObserver keeps the observed usage by identity
+type recordingObserver struct {
+	metric.Observer
+	usage map[string]int64
+}
+
+func (r *recordingObserver) ObserveInt64(_ metric.Int64Observable, value int64, opts ...metric.ObserveOption) {
+	id, _ := metric.NewObserveConfig(opts).Attributes().Value("identity")
+	r.usage[id.AsString()] = value
+}
+
+func TestObservedIdentitiesAreCapped(t *testing.T) {
+	s := &quotaNSEServer{identities: make(map[string]int)}
+	for i := 0; i < maxObservedIdentities+10; i++ {
+		// the first 10 identities have the fewest endpoints
+		s.identities[fmt.Sprintf("spiffe://example.org/ns/a/pod/pod-%d", i)] = i + 1
+	}
+
+	observer := &recordingObserver{usage: make(map[string]int64)}
+	if err := s.observe(context.Background(), observer); err != nil {
+		t.Fatalf("observe failed: %v", err)
+	}
+	if len(observer.usage) != maxObservedIdentities+1 {
+		t.Errorf("observed %d identities, want %d", len(observer.usage), maxObservedIdentities+1)
+	}
+	if _, ok := observer.usage["spiffe://example.org/ns/a/pod/pod-9"]; ok {
+		t.Error("an identity beyond the cap is observed on its own")
+	}
+	if got := observer.usage[other]; got != 55 {
+		t.Errorf("observed %d endpoints of the other identities, want 55", got)
+	}
+}
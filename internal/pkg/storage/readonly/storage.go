@@ -0,0 +1,81 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package readonly provides storage serving only the Find queries
+package readonly
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+// NewStorage creates storage.Storage rejecting the writes with codes.Unavailable, so the clients retry them until they
+// reach a writable replica. Find queries are served by s.
+func NewStorage(s storage.Storage) storage.Storage {
+	return storage.New(
+		&readOnlyNSServer{
+			storage: s.NetworkServiceRegistryServer(),
+		},
+		&readOnlyNSEServer{
+			storage: s.NetworkServiceEndpointRegistryServer(),
+		},
+	)
+}
+
+func readOnly() error {
+	return status.Error(codes.Unavailable, "registry is read-only")
+}
+
+type readOnlyNSServer struct {
+	storage registry.NetworkServiceRegistryServer
+}
+
+func (s *readOnlyNSServer) Register(context.Context, *registry.NetworkService) (*registry.NetworkService, error) {
+	return nil, readOnly()
+}
+
+func (s *readOnlyNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *readOnlyNSServer) Unregister(context.Context, *registry.NetworkService) (*empty.Empty, error) {
+	return nil, readOnly()
+}
+
+type readOnlyNSEServer struct {
+	storage registry.NetworkServiceEndpointRegistryServer
+}
+
+func (s *readOnlyNSEServer) Register(context.Context, *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return nil, readOnly()
+}
+
+func (s *readOnlyNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return s.storage.Find(query, server)
+}
+
+func (s *readOnlyNSEServer) Unregister(context.Context, *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return nil, readOnly()
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadow
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+type shadowNSServer struct {
+	primary registry.NetworkServiceRegistryServer
+	shadow  registry.NetworkServiceRegistryServer
+	metrics *instruments
+}
+
+func (s *shadowNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	resp, err := s.primary.Register(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	if _, shadowErr := s.shadow.Register(ctx, resp.Clone()); shadowErr != nil {
+		s.metrics.failed(ctx, nsKind, "register", resp.GetName(), shadowErr)
+	}
+	return resp, nil
+}
+
+func (s *shadowNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	if query.GetWatch() {
+		return s.primary.Find(query, server)
+	}
+
+	recorder := &nsFindServer{
+		ServerStream: server,
+		ctx:          server.Context(),
+		send:         server.Send,
+		names:        make(map[string]struct{}),
+	}
+	if err := s.primary.Find(query, recorder); err != nil {
+		return err
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(server.Context()), compareTimeout)
+		defer cancel()
+
+		collector := &nsFindServer{
+			ctx:   ctx,
+			names: make(map[string]struct{}),
+		}
+		if err := s.shadow.Find(proto.Clone(query).(*registry.NetworkServiceQuery), collector); err != nil {
+			s.metrics.failed(ctx, nsKind, "find", query.GetNetworkService().GetName(), err)
+			return
+		}
+		s.metrics.compare(ctx, nsKind, recorder.names, collector.names)
+	}()
+
+	return nil
+}
+
+func (s *shadowNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	resp, err := s.primary.Unregister(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	if _, shadowErr := s.shadow.Unregister(ctx, ns.Clone()); shadowErr != nil {
+		s.metrics.failed(ctx, nsKind, "unregister", ns.GetName(), shadowErr)
+	}
+	return resp, nil
+}
+
+// nsFindServer records the names of the sent network services, forwarding them to send if it is set
+type nsFindServer struct {
+	grpc.ServerStream
+	ctx   context.Context
+	send  func(*registry.NetworkServiceResponse) error
+	names map[string]struct{}
+}
+
+func (s *nsFindServer) Send(resp *registry.NetworkServiceResponse) error {
+	if s.send != nil {
+		if err := s.send(resp); err != nil {
+			return err
+		}
+	}
+	s.names[resp.GetNetworkService().GetName()] = struct{}{}
+	return nil
+}
+
+func (s *nsFindServer) Context() context.Context {
+	return s.ctx
+}
+
+// tailNSServer terminates the shadow chain so the shadow storage never calls into the rest of the registry chain
+type tailNSServer struct{}
+
+func (t *tailNSServer) Register(_ context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	return ns, nil
+}
+
+func (t *tailNSServer) Find(_ *registry.NetworkServiceQuery, _ registry.NetworkServiceRegistry_FindServer) error {
+	return nil
+}
+
+func (t *tailNSServer) Unregister(_ context.Context, _ *registry.NetworkService) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadow
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+type shadowNSEServer struct {
+	primary registry.NetworkServiceEndpointRegistryServer
+	shadow  registry.NetworkServiceEndpointRegistryServer
+	metrics *instruments
+}
+
+func (s *shadowNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	resp, err := s.primary.Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	if _, shadowErr := s.shadow.Register(ctx, resp.Clone()); shadowErr != nil {
+		s.metrics.failed(ctx, nseKind, "register", resp.GetName(), shadowErr)
+	}
+	return resp, nil
+}
+
+func (s *shadowNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if query.GetWatch() {
+		return s.primary.Find(query, server)
+	}
+
+	recorder := &nseFindServer{
+		ServerStream: server,
+		ctx:          server.Context(),
+		send:         server.Send,
+		names:        make(map[string]struct{}),
+	}
+	if err := s.primary.Find(query, recorder); err != nil {
+		return err
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(server.Context()), compareTimeout)
+		defer cancel()
+
+		collector := &nseFindServer{
+			ctx:   ctx,
+			names: make(map[string]struct{}),
+		}
+		if err := s.shadow.Find(proto.Clone(query).(*registry.NetworkServiceEndpointQuery), collector); err != nil {
+			s.metrics.failed(ctx, nseKind, "find", query.GetNetworkServiceEndpoint().GetName(), err)
+			return
+		}
+		s.metrics.compare(ctx, nseKind, recorder.names, collector.names)
+	}()
+
+	return nil
+}
+
+func (s *shadowNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	resp, err := s.primary.Unregister(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	if _, shadowErr := s.shadow.Unregister(ctx, nse.Clone()); shadowErr != nil {
+		s.metrics.failed(ctx, nseKind, "unregister", nse.GetName(), shadowErr)
+	}
+	return resp, nil
+}
+
+// nseFindServer records the names of the sent endpoints, forwarding them to send if it is set
+type nseFindServer struct {
+	grpc.ServerStream
+	ctx   context.Context
+	send  func(*registry.NetworkServiceEndpointResponse) error
+	names map[string]struct{}
+}
+
+func (s *nseFindServer) Send(resp *registry.NetworkServiceEndpointResponse) error {
+	if s.send != nil {
+		if err := s.send(resp); err != nil {
+			return err
+		}
+	}
+	s.names[resp.GetNetworkServiceEndpoint().GetName()] = struct{}{}
+	return nil
+}
+
+func (s *nseFindServer) Context() context.Context {
+	return s.ctx
+}
+
+// tailNSEServer terminates the shadow chain so the shadow storage never calls into the rest of the registry chain
+type tailNSEServer struct{}
+
+func (t *tailNSEServer) Register(_ context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return nse, nil
+}
+
+func (t *tailNSEServer) Find(_ *registry.NetworkServiceEndpointQuery, _ registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return nil
+}
+
+func (t *tailNSEServer) Unregister(_ context.Context, _ *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
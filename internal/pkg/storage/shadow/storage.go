@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shadow provides storage that duplicates writes to a secondary storage and compares read results,
+// reporting the divergence between them. It is intended to de-risk migrations between storage implementations.
+package shadow
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+const (
+	compareTimeout = 5 * time.Second
+
+	nsKind  = "ns"
+	nseKind = "nse"
+)
+
+type instruments struct {
+	failures   metric.Int64Counter
+	divergence metric.Int64Counter
+}
+
+func newInstruments() *instruments {
+	meter := otel.Meter("")
+	failures, _ := meter.Int64Counter("registry_shadow_errors_total",
+		metric.WithDescription("number of operations that succeeded in the primary storage but failed in the shadow storage"))
+	divergence, _ := meter.Int64Counter("registry_shadow_divergence_total",
+		metric.WithDescription("number of records found in only one of the primary and shadow storages"))
+	return &instruments{
+		failures:   failures,
+		divergence: divergence,
+	}
+}
+
+func (i *instruments) failed(ctx context.Context, kind, op, name string, err error) {
+	log.FromContext(ctx).Warnf("shadow storage failed to %s %s %s: %v", op, kind, name, err)
+	i.failures.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", kind), attribute.String("op", op)))
+}
+
+// compare reports the names found only in primary as missing and the names found only in shadow as unexpected
+func (i *instruments) compare(ctx context.Context, kind string, primary, shadow map[string]struct{}) {
+	var missing, unexpected int64
+	for name := range primary {
+		if _, ok := shadow[name]; !ok {
+			missing++
+		}
+	}
+	for name := range shadow {
+		if _, ok := primary[name]; !ok {
+			unexpected++
+		}
+	}
+	if missing == 0 && unexpected == 0 {
+		return
+	}
+	log.FromContext(ctx).Warnf("shadow storage diverged for %s: %d missing, %d unexpected", kind, missing, unexpected)
+	i.divergence.Add(ctx, missing, metric.WithAttributes(attribute.String("kind", kind), attribute.String("reason", "missing")))
+	i.divergence.Add(ctx, unexpected, metric.WithAttributes(attribute.String("kind", kind), attribute.String("reason", "unexpected")))
+}
+
+// NewStorage creates storage.Storage serving from primary and duplicating every write to shadow. Primary stays the
+// source of truth: shadow failures are logged and counted but never returned to the client.
+func NewStorage(primary, shadow storage.Storage) storage.Storage {
+	i := newInstruments()
+	return storage.New(
+		&shadowNSServer{
+			primary: primary.NetworkServiceRegistryServer(),
+			shadow:  chain.NewNetworkServiceRegistryServer(shadow.NetworkServiceRegistryServer(), &tailNSServer{}),
+			metrics: i,
+		},
+		&shadowNSEServer{
+			primary: primary.NetworkServiceEndpointRegistryServer(),
+			shadow:  chain.NewNetworkServiceEndpointRegistryServer(shadow.NetworkServiceEndpointRegistryServer(), &tailNSEServer{}),
+			metrics: i,
+		},
+	)
+}
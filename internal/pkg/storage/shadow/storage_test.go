@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/memory"
+)
+
+// failingNSEServer fails every request
+type failingNSEServer struct{}
+
+func (f *failingNSEServer) Register(context.Context, *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return nil, errors.New("failed")
+}
+
+func (f *failingNSEServer) Find(*registry.NetworkServiceEndpointQuery, registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return errors.New("failed")
+}
+
+func (f *failingNSEServer) Unregister(context.Context, *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return nil, errors.New("failed")
+}
+
+func findNSEs(t *testing.T, s registry.NetworkServiceEndpointRegistryServer) []string {
+	t.Helper()
+	ch := make(chan *registry.NetworkServiceEndpointResponse, 10)
+	query := &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{}}
+	if err := s.Find(query, streamchannel.NewNetworkServiceEndpointFindServer(context.Background(), ch)); err != nil {
+		t.Fatalf("find failed: %v", err)
+	}
+	close(ch)
+	var names []string
+	for resp := range ch {
+		names = append(names, resp.GetNetworkServiceEndpoint().GetName())
+	}
+	return names
+}
+
+func TestWritesReachBothStorages(t *testing.T) {
+	primary, secondary := memory.NewStorage(), memory.NewStorage()
+	s := NewStorage(primary, secondary).NetworkServiceEndpointRegistryServer()
+	nse := &registry.NetworkServiceEndpoint{Name: "nse-1"}
+
+	if _, err := s.Register(context.Background(), nse.Clone()); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	for name, st := range map[string]storage.Storage{"primary": primary, "shadow": secondary} {
+		if names := findNSEs(t, st.NetworkServiceEndpointRegistryServer()); len(names) != 1 || names[0] != "nse-1" {
+			t.Errorf("%s storage has %v after the registration, want nse-1", name, names)
+		}
+	}
+
+	if _, err := s.Unregister(context.Background(), nse.Clone()); err != nil {
+		t.Fatalf("unregister failed: %v", err)
+	}
+	for name, st := range map[string]storage.Storage{"primary": primary, "shadow": secondary} {
+		if names := findNSEs(t, st.NetworkServiceEndpointRegistryServer()); len(names) != 0 {
+			t.Errorf("%s storage has %v after the unregistration", name, names)
+		}
+	}
+}
+
+func TestShadowFailuresAreNotReturned(t *testing.T) {
+	primary := memory.NewStorage()
+	secondary := storage.New(primary.NetworkServiceRegistryServer(), &failingNSEServer{})
+	s := NewStorage(primary, secondary).NetworkServiceEndpointRegistryServer()
+	nse := &registry.NetworkServiceEndpoint{Name: "nse-1"}
+
+	if _, err := s.Register(context.Background(), nse.Clone()); err != nil {
+		t.Errorf("register failed: %v", err)
+	}
+	if names := findNSEs(t, s); len(names) != 1 || names[0] != "nse-1" {
+		t.Errorf("found %v, want nse-1 from the primary storage", names)
+	}
+	if _, err := s.Unregister(context.Background(), nse.Clone()); err != nil {
+		t.Errorf("unregister failed: %v", err)
+	}
+}
+
+func TestPrimaryFailuresAreReturned(t *testing.T) {
+	primary := storage.New(memory.NewStorage().NetworkServiceRegistryServer(), &failingNSEServer{})
+	secondary := memory.NewStorage()
+	s := NewStorage(primary, secondary).NetworkServiceEndpointRegistryServer()
+
+	if _, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"}); err == nil {
+		t.Fatal("register succeeded with the primary storage failing")
+	}
+	if names := findNSEs(t, secondary.NetworkServiceEndpointRegistryServer()); len(names) != 0 {
+		t.Errorf("shadow storage has %v written after the primary storage failed", names)
+	}
+}
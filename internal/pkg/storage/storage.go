@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package storage defines the backend used by the registry to persist network services and network service endpoints
+package storage
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// Storage provides the last registry chain elements that persist network services and network service endpoints
+type Storage interface {
+	// NetworkServiceRegistryServer returns network service storage chain element
+	NetworkServiceRegistryServer() registry.NetworkServiceRegistryServer
+	// NetworkServiceEndpointRegistryServer returns network service endpoint storage chain element
+	NetworkServiceEndpointRegistryServer() registry.NetworkServiceEndpointRegistryServer
+}
+
+type storageImpl struct {
+	nsServer  registry.NetworkServiceRegistryServer
+	nseServer registry.NetworkServiceEndpointRegistryServer
+}
+
+func (s *storageImpl) NetworkServiceRegistryServer() registry.NetworkServiceRegistryServer {
+	return s.nsServer
+}
+
+func (s *storageImpl) NetworkServiceEndpointRegistryServer() registry.NetworkServiceEndpointRegistryServer {
+	return s.nseServer
+}
+
+// New creates Storage from the given network service and network service endpoint chain elements
+func New(nsServer registry.NetworkServiceRegistryServer, nseServer registry.NetworkServiceEndpointRegistryServer) Storage {
+	return &storageImpl{
+		nsServer:  nsServer,
+		nseServer: nseServer,
+	}
+}
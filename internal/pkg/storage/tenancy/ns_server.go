@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenancy
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/identity"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+type tenancyNSServer struct {
+	storage registry.NetworkServiceRegistryServer
+	options *options
+}
+
+func (s *tenancyNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	namespace, _, ok := identity.PodFromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "the namespace of the workload registering ns %s is unknown", ns.GetName())
+	}
+	return s.storage.Register(storage.WithAnnotations(ctx, map[string]string{s.options.label: namespace}), ns)
+}
+
+func (s *tenancyNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	// The workloads of unknown namespace only see the network services of the shared namespaces
+	namespace, proxy, ok := s.options.clientNamespace(server.Context())
+	if ok && proxy {
+		return s.storage.Find(query, server)
+	}
+	return s.storage.Find(query, &nsFindServer{
+		NetworkServiceRegistry_FindServer: server,
+		options:                           s.options,
+		namespace:                         namespace,
+	})
+}
+
+func (s *tenancyNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	return s.storage.Unregister(ctx, ns)
+}
+
+// nsFindServer drops the network services not visible from the namespace
+type nsFindServer struct {
+	registry.NetworkServiceRegistry_FindServer
+	options   *options
+	namespace string
+}
+
+func (s *nsFindServer) Send(resp *registry.NetworkServiceResponse) error {
+	namespace, err := s.options.nsNamespace(s.Context(), resp.GetNetworkService().GetName())
+	if err != nil {
+		return err
+	}
+	if !s.options.visible(namespace, s.namespace) {
+		return nil
+	}
+	return s.NetworkServiceRegistry_FindServer.Send(resp)
+}
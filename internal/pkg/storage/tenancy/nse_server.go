@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenancy
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/identity"
+)
+
+type tenancyNSEServer struct {
+	storage registry.NetworkServiceEndpointRegistryServer
+	options *options
+}
+
+func (s *tenancyNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	namespace, _, ok := identity.PodFromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "the namespace of the workload registering nse %s is unknown", nse.GetName())
+	}
+
+	// The namespace label is only stamped by the registry, the one sent by the client is dropped
+	nse = s.options.withoutLabel(nse)
+	if nse.GetNetworkServiceLabels() == nil {
+		nse.NetworkServiceLabels = make(map[string]*registry.NetworkServiceLabels)
+	}
+	for _, service := range nse.GetNetworkServiceNames() {
+		labels := nse.GetNetworkServiceLabels()[service]
+		if labels == nil {
+			labels = new(registry.NetworkServiceLabels)
+			nse.NetworkServiceLabels[service] = labels
+		}
+		if labels.GetLabels() == nil {
+			labels.Labels = make(map[string]string)
+		}
+		labels.Labels[s.options.label] = namespace
+	}
+	resp, err := s.storage.Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	return s.options.withoutLabel(resp), nil
+}
+
+func (s *tenancyNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	namespaces, err := s.namespaces(query, server)
+	if err != nil {
+		return err
+	}
+	return s.storage.Find(query, &nseFindServer{
+		NetworkServiceEndpointRegistry_FindServer: server,
+		options:    s.options,
+		namespaces: namespaces,
+	})
+}
+
+// namespaces returns the namespaces the endpoints found by the query must be registered from besides the shared ones.
+// The Find queries from the proxies are isolated to the namespaces of the queried network services, as the proxies
+// send them on behalf of the clients of these namespaces. The workloads of unknown namespace and the proxies querying
+// no network service of a known namespace only see the endpoints of the shared namespaces.
+func (s *tenancyNSEServer) namespaces(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) ([]string, error) {
+	namespace, proxy, ok := s.options.clientNamespace(server.Context())
+	if !ok {
+		return nil, nil
+	}
+	if !proxy {
+		return []string{namespace}, nil
+	}
+
+	var namespaces []string
+	for _, service := range query.GetNetworkServiceEndpoint().GetNetworkServiceNames() {
+		namespace, err := s.options.nsNamespace(server.Context(), service)
+		if err != nil {
+			return nil, err
+		}
+		if namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces, nil
+}
+
+func (s *tenancyNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return s.storage.Unregister(ctx, nse)
+}
+
+// nseFindServer drops the endpoints not visible from the namespaces and the namespace label of the visible ones
+type nseFindServer struct {
+	registry.NetworkServiceEndpointRegistry_FindServer
+	options    *options
+	namespaces []string
+}
+
+func (s *nseFindServer) Send(resp *registry.NetworkServiceEndpointResponse) error {
+	if !s.options.visible(s.namespace(resp.GetNetworkServiceEndpoint()), s.namespaces...) {
+		return nil
+	}
+	return s.NetworkServiceEndpointRegistry_FindServer.Send(&registry.NetworkServiceEndpointResponse{
+		NetworkServiceEndpoint: s.options.withoutLabel(resp.GetNetworkServiceEndpoint()),
+		Deleted:                resp.GetDeleted(),
+	})
+}
+
+func (s *nseFindServer) namespace(nse *registry.NetworkServiceEndpoint) string {
+	for _, labels := range nse.GetNetworkServiceLabels() {
+		if namespace, ok := labels.GetLabels()[s.options.label]; ok {
+			return namespace
+		}
+	}
+	return ""
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tenancy provides storage isolating the records of different namespaces from each other. The namespace of
+// a workload is derived from its SPIFFE ID, the records are only visible to the Find queries from the namespace they
+// have been registered from. The workloads of unknown namespace can not register and only see the records of the
+// shared namespaces.
+package tenancy
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/identity"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+)
+
+type options struct {
+	label            string
+	sharedNamespaces map[string]struct{}
+	proxyNamespaces  map[string]struct{}
+	annotator        storage.Annotator
+}
+
+// Option is an option pattern for the tenancy storage
+type Option func(o *options)
+
+// WithLabel sets the network service label the namespace of the registering workload is stored in on the endpoints
+// and the annotation it is stored in with the network services
+func WithLabel(label string) Option {
+	return func(o *options) {
+		o.label = label
+	}
+}
+
+// WithSharedNamespaces sets the namespaces whose records are visible from every namespace
+func WithSharedNamespaces(namespaces ...string) Option {
+	return func(o *options) {
+		for _, namespace := range namespaces {
+			o.sharedNamespaces[namespace] = struct{}{}
+		}
+	}
+}
+
+// WithProxyNamespaces sets the namespaces of the workloads sending the Find queries on behalf of the clients of other
+// namespaces, e.g. the NSMgrs. They see every network service, but only the endpoints registered from the shared
+// namespaces or from the namespaces the queried network services have been registered from.
+func WithProxyNamespaces(namespaces ...string) Option {
+	return func(o *options) {
+		for _, namespace := range namespaces {
+			o.proxyNamespaces[namespace] = struct{}{}
+		}
+	}
+}
+
+// WithAnnotator sets the storage keeping the namespaces of the network services along with them, usually the backend
+// of the storage wrapped by the tenancy storage. Without it the network services are not isolated.
+func WithAnnotator(annotator storage.Annotator) Option {
+	return func(o *options) {
+		o.annotator = annotator
+	}
+}
+
+// clientNamespace returns the namespace of the workload sending the request and whether it is a proxy
+func (o *options) clientNamespace(ctx context.Context) (namespace string, proxy, ok bool) {
+	namespace, _, ok = identity.PodFromContext(ctx)
+	if !ok {
+		return "", false, false
+	}
+	_, proxy = o.proxyNamespaces[namespace]
+	return namespace, proxy, true
+}
+
+// visible returns true if the record registered from the namespace can be seen from the namespaces. The records
+// stored without a namespace, e.g. before the isolation was enabled, are not isolated.
+func (o *options) visible(namespace string, namespaces ...string) bool {
+	if namespace == "" {
+		return true
+	}
+	if _, ok := o.sharedNamespaces[namespace]; ok {
+		return true
+	}
+	for _, n := range namespaces {
+		if n == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutLabel returns the copy of the endpoint without the namespace label, which is set by the registry only
+func (o *options) withoutLabel(nse *registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	if nse == nil {
+		return nil
+	}
+	nse = nse.Clone()
+	for _, labels := range nse.GetNetworkServiceLabels() {
+		delete(labels.GetLabels(), o.label)
+	}
+	return nse
+}
+
+// nsNamespace returns the namespace the network service has been registered from, "" if it is unknown
+func (o *options) nsNamespace(ctx context.Context, name string) (string, error) {
+	if o.annotator == nil {
+		return "", nil
+	}
+	annotations, err := o.annotator.NSAnnotations(ctx, name)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get the namespace of ns %s", name)
+	}
+	return annotations[o.label], nil
+}
+
+// NewStorage creates storage.Storage isolating the records of s by the namespace of the registering workloads. The
+// namespace of the endpoints is stored in their network service labels and the namespace of the network services is
+// stored in their annotations, so both are preserved by s across the restarts and shared by the replicas.
+func NewStorage(s storage.Storage, opts ...Option) storage.Storage {
+	o := &options{
+		label:            "nsm.networkservicemesh.io/namespace",
+		sharedNamespaces: make(map[string]struct{}),
+		proxyNamespaces:  make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return storage.New(
+		&tenancyNSServer{
+			storage: s.NetworkServiceRegistryServer(),
+			options: o,
+		},
+		&tenancyNSEServer{
+			storage: s.NetworkServiceEndpointRegistryServer(),
+			options: o,
+		},
+	)
+}
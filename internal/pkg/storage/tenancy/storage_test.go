@@ -0,0 +1,207 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenancy
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/registrytest"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/memory"
+)
+
+// podContext returns the context of the request originated by a pod of the namespace, "" stands for an unknown
+// workload
+func podContext(t *testing.T, namespace string) context.Context {
+	t.Helper()
+	return registrytest.PodContext(t, namespace, "pod-1")
+}
+
+func newTenancyStorage(backend storage.Storage) storage.Storage {
+	return NewStorage(backend,
+		WithSharedNamespaces("shared"),
+		WithProxyNamespaces("nsm-system"),
+		WithAnnotator(backend.(storage.Annotator)))
+}
+
+func registerNSs(t *testing.T, s storage.Storage, owners map[string]string) {
+	t.Helper()
+	for name, namespace := range owners {
+		if _, err := s.NetworkServiceRegistryServer().Register(podContext(t, namespace), &registry.NetworkService{Name: name}); err != nil {
+			t.Fatalf("failed to register ns %s: %v", name, err)
+		}
+	}
+}
+
+func findNSs(t *testing.T, s storage.Storage, namespace string) []string {
+	t.Helper()
+	query := &registry.NetworkServiceQuery{NetworkService: &registry.NetworkService{}}
+	return registrytest.FindNSs(podContext(t, namespace), t, s.NetworkServiceRegistryServer(), query)
+}
+
+func findNSEs(t *testing.T, s storage.Storage, namespace string, services ...string) []string {
+	t.Helper()
+	query := &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{NetworkServiceNames: services},
+	}
+	return registrytest.FindNSENames(podContext(t, namespace), t, s.NetworkServiceEndpointRegistryServer(), query)
+}
+
+func TestNSVisibility(t *testing.T) {
+	backend := memory.NewStorage()
+	s := newTenancyStorage(backend)
+	registerNSs(t, s, map[string]string{"ns-a": "a", "ns-b": "b", "ns-shared": "shared"})
+	// A network service stored before the isolation was enabled
+	registerNSs(t, backend, map[string]string{"ns-any": ""})
+
+	for _, tc := range []struct {
+		name      string
+		namespace string
+		want      []string
+	}{
+		{name: "tenant", namespace: "a", want: []string{"ns-a", "ns-any", "ns-shared"}},
+		{name: "other tenant", namespace: "b", want: []string{"ns-any", "ns-b", "ns-shared"}},
+		{name: "proxy", namespace: "nsm-system", want: []string{"ns-a", "ns-any", "ns-b", "ns-shared"}},
+		{name: "unknown workload", namespace: "", want: []string{"ns-any", "ns-shared"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := findNSs(t, s, tc.namespace); !slices.Equal(got, tc.want) {
+				t.Errorf("found %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNSNamespaceIsKeptByTheStorage(t *testing.T) {
+	backend := memory.NewStorage()
+	registerNSs(t, newTenancyStorage(backend), map[string]string{"ns-a": "a"})
+
+	// Another replica or the restarted registry sharing the backend
+	s := newTenancyStorage(backend)
+	if got := findNSs(t, s, "b"); len(got) != 0 {
+		t.Errorf("found %v from another namespace", got)
+	}
+	if got := findNSs(t, s, "a"); !slices.Equal(got, []string{"ns-a"}) {
+		t.Errorf("found %v, want [ns-a]", got)
+	}
+}
+
+func TestNSEVisibility(t *testing.T) {
+	backend := memory.NewStorage()
+	s := newTenancyStorage(backend)
+	registerNSs(t, s, map[string]string{"svc-a": "a", "svc-b": "b"})
+	registerNSs(t, backend, map[string]string{"svc-any": ""})
+	for _, nse := range []struct {
+		name      string
+		namespace string
+		services  []string
+	}{
+		{name: "nse-a", namespace: "a", services: []string{"svc-a", "svc-any"}},
+		{name: "nse-b", namespace: "b", services: []string{"svc-b", "svc-any"}},
+		{name: "nse-shared", namespace: "shared", services: []string{"svc-a", "svc-b"}},
+	} {
+		_, err := s.NetworkServiceEndpointRegistryServer().Register(podContext(t, nse.namespace), &registry.NetworkServiceEndpoint{
+			Name:                nse.name,
+			NetworkServiceNames: nse.services,
+		})
+		if err != nil {
+			t.Fatalf("failed to register nse %s: %v", nse.name, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name      string
+		namespace string
+		services  []string
+		want      []string
+	}{
+		{name: "tenant", namespace: "a", want: []string{"nse-a", "nse-shared"}},
+		{name: "tenant querying other tenant service", namespace: "a", services: []string{"svc-b"}, want: []string{"nse-shared"}},
+		{name: "proxy querying tenant service", namespace: "nsm-system", services: []string{"svc-a"}, want: []string{"nse-a", "nse-shared"}},
+		{name: "proxy querying other tenant service", namespace: "nsm-system", services: []string{"svc-b"}, want: []string{"nse-b", "nse-shared"}},
+		{name: "proxy querying service of unknown namespace", namespace: "nsm-system", services: []string{"svc-any"}, want: []string{"nse-shared"}},
+		{name: "proxy querying every service", namespace: "nsm-system", want: []string{"nse-shared"}},
+		{name: "unknown workload", namespace: "", want: []string{"nse-shared"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := findNSEs(t, s, tc.namespace, tc.services...); !slices.Equal(got, tc.want) {
+				t.Errorf("found %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegisterRejectsUnknownWorkload(t *testing.T) {
+	s := newTenancyStorage(memory.NewStorage())
+
+	_, err := s.NetworkServiceRegistryServer().Register(podContext(t, ""), &registry.NetworkService{Name: "ns-1"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ns: got %v, want PermissionDenied", err)
+	}
+	_, err = s.NetworkServiceEndpointRegistryServer().Register(podContext(t, ""), &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("nse: got %v, want PermissionDenied", err)
+	}
+}
+
+func TestNSENamespaceLabelIsSetByTheRegistry(t *testing.T) {
+	const label = "nsm.networkservicemesh.io/namespace"
+	s := newTenancyStorage(memory.NewStorage())
+	registerNSs(t, s, map[string]string{"svc-a": "a", "svc-b": "b"})
+
+	// The tenant of a claims to register the endpoint from b
+	resp, err := s.NetworkServiceEndpointRegistryServer().Register(podContext(t, "a"), &registry.NetworkServiceEndpoint{
+		Name:                "nse-a",
+		NetworkServiceNames: []string{"svc-b"},
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			"svc-b": {Labels: map[string]string{label: "b", "app": "nse"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to register nse-a: %v", err)
+	}
+	if _, ok := resp.GetNetworkServiceLabels()["svc-b"].GetLabels()[label]; ok {
+		t.Errorf("the registered nse is returned with the namespace label: %v", resp.GetNetworkServiceLabels())
+	}
+
+	if got := findNSEs(t, s, "b"); len(got) != 0 {
+		t.Errorf("found %v from the claimed namespace", got)
+	}
+	if got := findNSEs(t, s, "nsm-system", "svc-b"); len(got) != 0 {
+		t.Errorf("found %v by the proxy querying the service of the claimed namespace", got)
+	}
+
+	query := &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{}}
+	found := registrytest.FindNSEs(podContext(t, "a"), t, s.NetworkServiceEndpointRegistryServer(), query)
+	if len(found) != 1 || found[0].GetName() != "nse-a" {
+		t.Fatalf("found %v, want nse-a", found)
+	}
+	labels := found[0].GetNetworkServiceLabels()["svc-b"].GetLabels()
+	if _, ok := labels[label]; ok || labels["app"] != "nse" {
+		t.Errorf("found nse-a with the labels %v, want only the labels of the client", labels)
+	}
+}
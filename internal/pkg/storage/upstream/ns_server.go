@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upstream
+
+import (
+	"context"
+	"io"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/connpool"
+)
+
+type upstreamNSServer struct {
+	storage  registry.NetworkServiceRegistryServer
+	local    registry.NetworkServiceRegistryServer
+	upstream registry.NetworkServiceRegistryServer
+}
+
+func (s *upstreamNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	return s.storage.Register(ctx, ns)
+}
+
+func (s *upstreamNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	if query.GetWatch() || forwarded(server.Context()) {
+		return s.storage.Find(query, server)
+	}
+
+	counter := &countingNSFindServer{NetworkServiceRegistry_FindServer: server}
+	if err := s.local.Find(query, counter); err != nil {
+		return err
+	}
+	if counter.count == 0 {
+		if err := s.upstream.Find(query, server); err != nil {
+			return err
+		}
+	}
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *upstreamNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	return s.storage.Unregister(ctx, ns)
+}
+
+// clientNSServer finds the network services in the upstream registry, it is only read
+type clientNSServer struct {
+	pool   *connpool.Pool
+	target string
+}
+
+func (s *clientNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *clientNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	ctx := server.Context()
+	cc, release, err := s.pool.Get(ctx, s.target)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	stream, err := registry.NewNetworkServiceRegistryClient(cc).Find(metadata.AppendToOutgoingContext(ctx, MetadataKey, "true"), query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the network services in %s", s.target)
+	}
+	for {
+		resp, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		if recvErr != nil {
+			return errors.Wrapf(recvErr, "failed to receive a network service from %s", s.target)
+		}
+		if err = server.Send(resp); err != nil {
+			return errors.Wrapf(err, "NetworkServiceRegistry find server failed to send a response %s", resp.String())
+		}
+	}
+	return next.NetworkServiceRegistryServer(ctx).Find(query, server)
+}
+
+func (s *clientNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
+
+// countingNSFindServer counts the responses of the storage
+type countingNSFindServer struct {
+	registry.NetworkServiceRegistry_FindServer
+	count int
+}
+
+func (s *countingNSFindServer) Send(resp *registry.NetworkServiceResponse) error {
+	s.count++
+	return s.NetworkServiceRegistry_FindServer.Send(resp)
+}
+
+// tailNSServer terminates the reads so they never call into the rest of the registry chain
+type tailNSServer struct{}
+
+func (t *tailNSServer) Register(_ context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	return ns, nil
+}
+
+func (t *tailNSServer) Find(_ *registry.NetworkServiceQuery, _ registry.NetworkServiceRegistry_FindServer) error {
+	return nil
+}
+
+func (t *tailNSServer) Unregister(_ context.Context, _ *registry.NetworkService) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
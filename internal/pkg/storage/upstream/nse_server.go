@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upstream
+
+import (
+	"context"
+	"io"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/connpool"
+)
+
+type upstreamNSEServer struct {
+	storage  registry.NetworkServiceEndpointRegistryServer
+	local    registry.NetworkServiceEndpointRegistryServer
+	upstream registry.NetworkServiceEndpointRegistryServer
+}
+
+func (s *upstreamNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return s.storage.Register(ctx, nse)
+}
+
+func (s *upstreamNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if query.GetWatch() || forwarded(server.Context()) {
+		return s.storage.Find(query, server)
+	}
+
+	counter := &countingNSEFindServer{NetworkServiceEndpointRegistry_FindServer: server}
+	if err := s.local.Find(query, counter); err != nil {
+		return err
+	}
+	if counter.count == 0 {
+		if err := s.upstream.Find(query, server); err != nil {
+			return err
+		}
+	}
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *upstreamNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return s.storage.Unregister(ctx, nse)
+}
+
+// clientNSEServer finds the endpoints in the upstream registry, it is only read
+type clientNSEServer struct {
+	pool   *connpool.Pool
+	target string
+}
+
+func (s *clientNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *clientNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	ctx := server.Context()
+	cc, release, err := s.pool.Get(ctx, s.target)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	stream, err := registry.NewNetworkServiceEndpointRegistryClient(cc).Find(metadata.AppendToOutgoingContext(ctx, MetadataKey, "true"), query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the network service endpoints in %s", s.target)
+	}
+	for {
+		resp, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		if recvErr != nil {
+			return errors.Wrapf(recvErr, "failed to receive a network service endpoint from %s", s.target)
+		}
+		if err = server.Send(resp); err != nil {
+			return errors.Wrapf(err, "NetworkServiceEndpointRegistry find server failed to send a response %s", resp.String())
+		}
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Find(query, server)
+}
+
+func (s *clientNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+// countingNSEFindServer counts the responses of the storage
+type countingNSEFindServer struct {
+	registry.NetworkServiceEndpointRegistry_FindServer
+	count int
+}
+
+func (s *countingNSEFindServer) Send(resp *registry.NetworkServiceEndpointResponse) error {
+	s.count++
+	return s.NetworkServiceEndpointRegistry_FindServer.Send(resp)
+}
+
+// tailNSEServer terminates the reads so they never call into the rest of the registry chain
+type tailNSEServer struct{}
+
+func (t *tailNSEServer) Register(_ context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return nse, nil
+}
+
+func (t *tailNSEServer) Find(_ *registry.NetworkServiceEndpointQuery, _ registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return nil
+}
+
+func (t *tailNSEServer) Unregister(_ context.Context, _ *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
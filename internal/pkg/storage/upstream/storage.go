@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package upstream provides forwarding of the Find queries missing in a storage to an upstream registry, e.g. the
+// registry of the other cluster in a simple two-cluster setup without a proxy registry
+package upstream
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/connpool"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/cache"
+)
+
+// MetadataKey is the gRPC metadata key marking the forwarded Find queries, the upstream registry doesn't forward them
+// any further, so two registries can forward to each other
+const MetadataKey = "x-registry-forwarded"
+
+type options struct {
+	cacheTTL        time.Duration
+	cacheMaxEntries int
+}
+
+// Option is an option pattern for the upstream storage
+type Option func(o *options)
+
+// WithCacheTTL sets how long the results of the upstream registry are cached, 0 disables the cache
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.cacheTTL = ttl
+	}
+}
+
+// WithCacheMaxEntries sets the maximum number of cached queries per record kind
+func WithCacheMaxEntries(maxEntries int) Option {
+	return func(o *options) {
+		o.cacheMaxEntries = maxEntries
+	}
+}
+
+// NewStorage creates storage.Storage forwarding the non-watch Find queries without any result in s to the registry at
+// u through the connections of pool. The watches and the writes are served by s only.
+func NewStorage(s storage.Storage, u *url.URL, pool *connpool.Pool, opts ...Option) storage.Storage {
+	o := &options{
+		cacheTTL:        30 * time.Second,
+		cacheMaxEntries: 1000,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	target := grpcutils.URLToTarget(u)
+	var upstream storage.Storage = storage.New(
+		&clientNSServer{pool: pool, target: target},
+		&clientNSEServer{pool: pool, target: target},
+	)
+	if o.cacheTTL > 0 {
+		upstream = cache.NewStorage(upstream, cache.WithTTL(o.cacheTTL), cache.WithMaxEntries(o.cacheMaxEntries))
+	}
+
+	return storage.New(
+		&upstreamNSServer{
+			storage:  s.NetworkServiceRegistryServer(),
+			local:    chain.NewNetworkServiceRegistryServer(s.NetworkServiceRegistryServer(), &tailNSServer{}),
+			upstream: chain.NewNetworkServiceRegistryServer(upstream.NetworkServiceRegistryServer(), &tailNSServer{}),
+		},
+		&upstreamNSEServer{
+			storage:  s.NetworkServiceEndpointRegistryServer(),
+			local:    chain.NewNetworkServiceEndpointRegistryServer(s.NetworkServiceEndpointRegistryServer(), &tailNSEServer{}),
+			upstream: chain.NewNetworkServiceEndpointRegistryServer(upstream.NetworkServiceEndpointRegistryServer(), &tailNSEServer{}),
+		},
+	)
+}
+
+// forwarded returns true if the query was forwarded by another registry
+func forwarded(ctx context.Context) bool {
+	return len(metadata.ValueFromIncomingContext(ctx, MetadataKey)) > 0
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tombstone
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+// retention is how long the reasons of the local unregistrations are kept for the deletions the watches receive later
+const retention = time.Minute
+
+type unregistration struct {
+	reason Reason
+	time   time.Time
+}
+
+type tombstoneNSEServer struct {
+	mu              sync.Mutex
+	unregistrations map[string]unregistration
+}
+
+// NewNetworkServiceEndpointRegistryServer creates the chain element labeling the endpoints reported as deleted to the
+// Find watches with the reason they are gone for. The expired endpoints are told by their expiration time, the reasons
+// of the other unregistrations are known only to the replica serving them, the other replicas report them as
+// unregistered.
+func NewNetworkServiceEndpointRegistryServer() registry.NetworkServiceEndpointRegistryServer {
+	return &tombstoneNSEServer{
+		unregistrations: make(map[string]unregistration),
+	}
+}
+
+func (s *tombstoneNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *tombstoneNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if query.GetWatch() {
+		server = &tombstoneNSEFindServer{
+			NetworkServiceEndpointRegistry_FindServer: server,
+			s: s,
+		}
+	}
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *tombstoneNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	if reason, ok := FromContext(ctx); ok {
+		now := time.Now()
+		s.mu.Lock()
+		for name, u := range s.unregistrations {
+			if now.Sub(u.time) > retention {
+				delete(s.unregistrations, name)
+			}
+		}
+		s.unregistrations[nse.GetName()] = unregistration{reason: reason, time: now}
+		s.mu.Unlock()
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+// reason returns the reason the deleted endpoint is gone for
+func (s *tombstoneNSEServer) reason(nse *registry.NetworkServiceEndpoint) Reason {
+	if expirationTime := nse.GetExpirationTime(); expirationTime != nil && !expirationTime.AsTime().After(time.Now()) {
+		return Expired
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u, ok := s.unregistrations[nse.GetName()]; ok && time.Since(u.time) <= retention {
+		return u.reason
+	}
+	return Unregistered
+}
+
+type tombstoneNSEFindServer struct {
+	registry.NetworkServiceEndpointRegistry_FindServer
+	s *tombstoneNSEServer
+}
+
+func (f *tombstoneNSEFindServer) Send(resp *registry.NetworkServiceEndpointResponse) error {
+	if !resp.GetDeleted() || Of(resp.GetNetworkServiceEndpoint()) != "" {
+		return f.NetworkServiceEndpointRegistry_FindServer.Send(resp)
+	}
+	// the responses may be shared between the watches, so the labeled endpoint is a copy
+	return f.NetworkServiceEndpointRegistry_FindServer.Send(&registry.NetworkServiceEndpointResponse{
+		NetworkServiceEndpoint: mark(resp.GetNetworkServiceEndpoint(), f.s.reason(resp.GetNetworkServiceEndpoint())),
+		Deleted:                true,
+	})
+}
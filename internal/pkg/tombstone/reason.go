@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tombstone provides the reasons the endpoints reported as deleted to the Find watches are gone for, so the
+// clients can tell the graceful removals from the failures
+package tombstone
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// Label is the network service label of the deleted endpoints naming the reason they are gone for
+const Label = "registry.networkservicemesh.io/deletion-reason"
+
+// Reason is the reason an endpoint is gone for
+type Reason string
+
+// The reasons
+const (
+	// Unregistered endpoints are unregistered by themselves
+	Unregistered Reason = "unregistered"
+	// Expired endpoints are not refreshed in time
+	Expired Reason = "expired"
+	// Evicted endpoints are unregistered by the registry, e.g. after failing the liveness probes
+	Evicted Reason = "evicted"
+)
+
+type reasonKey struct{}
+
+// WithReason returns the context of the unregistration of the endpoints for the reason
+func WithReason(ctx context.Context, reason Reason) context.Context {
+	return context.WithValue(ctx, reasonKey{}, reason)
+}
+
+// FromContext returns the reason of the unregistration from the context
+func FromContext(ctx context.Context) (Reason, bool) {
+	reason, ok := ctx.Value(reasonKey{}).(Reason)
+	return reason, ok
+}
+
+// Of returns the reason the deleted endpoint is gone for or the empty reason if it is unknown
+func Of(nse *registry.NetworkServiceEndpoint) Reason {
+	for _, labels := range nse.GetNetworkServiceLabels() {
+		if reason, ok := labels.GetLabels()[Label]; ok {
+			return Reason(reason)
+		}
+	}
+	return ""
+}
+
+// mark returns a copy of the endpoint with the reason in the labels of all its network services
+func mark(nse *registry.NetworkServiceEndpoint, reason Reason) *registry.NetworkServiceEndpoint {
+	nse = nse.Clone()
+	if nse.NetworkServiceLabels == nil {
+		nse.NetworkServiceLabels = make(map[string]*registry.NetworkServiceLabels)
+	}
+	for _, service := range nse.GetNetworkServiceNames() {
+		labels, ok := nse.GetNetworkServiceLabels()[service]
+		if !ok || labels.GetLabels() == nil {
+			labels = &registry.NetworkServiceLabels{Labels: make(map[string]string)}
+			nse.NetworkServiceLabels[service] = labels
+		}
+		labels.Labels[Label] = string(reason)
+	}
+	return nse
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package traces provides the tracer provider sampling a ratio of the traces, so the collector is not overwhelmed
+// during refresh storms
+package traces
+
+import (
+	"context"
+	"io"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type tracerProvider struct {
+	ctx      context.Context
+	provider *sdktrace.TracerProvider
+}
+
+func (t *tracerProvider) Close() error {
+	if err := t.provider.Shutdown(t.ctx); err != nil {
+		log.FromContext(t.ctx).Errorf("failed to shutdown the tracer provider: %v", err)
+	}
+	return nil
+}
+
+// Init sets the global tracer provider exporting the sampled spans to the exporter. The root spans are sampled with
+// the ratio, the other spans follow the sampling decision of their parent, so the traces are never sampled partially.
+func Init(ctx context.Context, res *resource.Resource, exporter sdktrace.SpanExporter, ratio float64) io.Closer {
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(res),
+		sdktrace.WithSpanProcessor(sdktrace.NewBatchSpanProcessor(exporter)),
+	)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}))
+	otel.SetTracerProvider(provider)
+
+	return &tracerProvider{
+		ctx:      ctx,
+		provider: provider,
+	}
+}
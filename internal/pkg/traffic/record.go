@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/rotate"
+)
+
+// Recorder writes the registry requests to the record file as JSON lines
+type Recorder struct {
+	mu sync.Mutex
+	w  io.WriteCloser
+}
+
+// Open opens the record file, it returns nil if the recording is disabled
+func Open(config *Config) (*Recorder, error) {
+	if config.RecordFile == "" {
+		return nil, nil
+	}
+	w, err := rotate.Open(config.RecordFile, rotate.WithMaxSize(config.RecordMaxSize), rotate.WithMaxBackups(config.RecordMaxBackups))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open the traffic record file %s", config.RecordFile)
+	}
+	return &Recorder{w: w}, nil
+}
+
+// Close closes the record file
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.w.Close()
+}
+
+// ServerOptions returns the interceptors recording the requests of the registry services as they arrive, the Find
+// queries are recorded once their query is received
+func ServerOptions(r *Recorder) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			r.record(ctx, info.FullMethod, req)
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, &recordServerStream{ServerStream: ss, recorder: r, method: info.FullMethod})
+		}),
+	}
+}
+
+func (r *Recorder) record(ctx context.Context, method string, req interface{}) {
+	request, ok := req.(proto.Message)
+	if _, recorded := newRequest(method); !ok || !recorded {
+		return
+	}
+	raw, err := protojson.Marshal(sanitize(request))
+	if err != nil {
+		log.FromContext(ctx).Warnf("failed to record the request of %s: %v", method, err)
+		return
+	}
+	line, err := json.Marshal(&entry{
+		Time:    time.Now(),
+		Method:  method,
+		Request: raw,
+	})
+	if err != nil {
+		log.FromContext(ctx).Warnf("failed to record the request of %s: %v", method, err)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err = r.w.Write(append(line, '\n')); err != nil {
+		log.FromContext(ctx).Warnf("failed to write the traffic record: %v", err)
+	}
+}
+
+// recordServerStream records the first message received by the stream
+type recordServerStream struct {
+	grpc.ServerStream
+	recorder *Recorder
+	method   string
+	received bool
+}
+
+func (s *recordServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && !s.received {
+		s.received = true
+		s.recorder.record(s.Context(), s.method, m)
+	}
+	return err
+}
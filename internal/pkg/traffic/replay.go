@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// maxLineSize bounds the size of a recorded request
+const maxLineSize = 4 << 20
+
+// Stats are the numbers of the replayed and the failed requests
+type Stats struct {
+	Replayed int64
+	Failed   int64
+}
+
+// Replay sends the requests recorded in r through cc keeping their original intervals divided by the speed, e.g. 10
+// replays them 10 times faster. Every request is sent at its time regardless of the ones still in flight, so their
+// interleaving is reproduced. The expiration times of the endpoints are shifted to the time of the replay. The watching
+// Find queries are kept open until the last request completes.
+func Replay(ctx context.Context, cc grpc.ClientConnInterface, r io.Reader, speed float64) (*Stats, error) {
+	if speed <= 0 {
+		return nil, errors.Errorf("invalid replay speed %v, expected a positive number", speed)
+	}
+	watchCtx, cancelWatches := context.WithCancel(ctx)
+	defer cancelWatches()
+
+	stats := new(Stats)
+	var replayed, failed atomic.Int64
+	var wg, watches sync.WaitGroup
+	var first time.Time
+	start := time.Now()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		var e entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, errors.Wrapf(err, "invalid traffic record at line %d", line)
+		}
+		request, ok := newRequest(e.Method)
+		if !ok {
+			return nil, errors.Errorf("unknown method %s at line %d", e.Method, line)
+		}
+		if err := protojson.Unmarshal(e.Request, request); err != nil {
+			return nil, errors.Wrapf(err, "invalid request at line %d", line)
+		}
+
+		if first.IsZero() {
+			first = e.Time
+		}
+		delay := time.Until(start.Add(time.Duration(float64(e.Time.Sub(first)) / speed)))
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				wg.Wait()
+				return nil, ctx.Err()
+			}
+		}
+
+		shiftExpiration(request, e.Time)
+
+		group, sendCtx := &wg, ctx
+		if isWatch(request) {
+			group, sendCtx = &watches, watchCtx
+		}
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			replayed.Add(1)
+			if err := send(sendCtx, cc, e.Method, request); err != nil && sendCtx.Err() == nil {
+				failed.Add(1)
+				log.FromContext(ctx).Warnf("replayed %s failed: %v", e.Method, err)
+			}
+		}()
+	}
+	wg.Wait()
+	cancelWatches()
+	watches.Wait()
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read the traffic record")
+	}
+	stats.Replayed, stats.Failed = replayed.Load(), failed.Load()
+	return stats, nil
+}
+
+// shiftExpiration keeps the lifetime the endpoint registered at the recorded time had left, so it does not expire
+// right away when it is replayed
+func shiftExpiration(request proto.Message, recorded time.Time) {
+	if nse, ok := request.(*registry.NetworkServiceEndpoint); ok && nse.GetExpirationTime() != nil {
+		nse.ExpirationTime = timestamppb.New(time.Now().Add(nse.GetExpirationTime().AsTime().Sub(recorded)))
+	}
+}
+
+func isWatch(request proto.Message) bool {
+	type watcher interface{ GetWatch() bool }
+	w, ok := request.(watcher)
+	return ok && w.GetWatch()
+}
+
+// send sends the request of the method, the Find responses are read until the end of the stream
+func send(ctx context.Context, cc grpc.ClientConnInterface, method string, request proto.Message) error {
+	if method != nsFind && method != nseFind {
+		return cc.Invoke(ctx, method, request, newResponse(method))
+	}
+	stream, err := cc.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, method)
+	if err != nil {
+		return err
+	}
+	if err = stream.SendMsg(request); err != nil {
+		return err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return err
+	}
+	for {
+		var response proto.Message = new(registry.NetworkServiceEndpointResponse)
+		if method == nsFind {
+			response = new(registry.NetworkServiceResponse)
+		}
+		if err = stream.RecvMsg(response); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package traffic provides the recording of the registry requests to a file and their replay against a registry at
+// the original or an accelerated speed, so the registration races seen in production can be reproduced locally
+package traffic
+
+import (
+	"encoding/json"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// The recorded methods of the registry services
+const (
+	nsRegister    = "/registry.NetworkServiceRegistry/Register"
+	nsFind        = "/registry.NetworkServiceRegistry/Find"
+	nsUnregister  = "/registry.NetworkServiceRegistry/Unregister"
+	nseRegister   = "/registry.NetworkServiceEndpointRegistry/Register"
+	nseFind       = "/registry.NetworkServiceEndpointRegistry/Find"
+	nseUnregister = "/registry.NetworkServiceEndpointRegistry/Unregister"
+)
+
+// Config contains configuration parameters for the traffic recording
+type Config struct {
+	RecordFile       string `desc:"file the registry requests are recorded to for a later replay, empty disables the recording" split_words:"true"`
+	RecordMaxSize    int64  `default:"104857600" desc:"size in bytes at which the traffic record file is rotated" split_words:"true"`
+	RecordMaxBackups int    `default:"3" desc:"number of the rotated traffic record files kept next to the current one" split_words:"true"`
+}
+
+// entry is a recorded request, one JSON line in the file
+type entry struct {
+	Time    time.Time       `json:"time"`
+	Method  string          `json:"method"`
+	Request json.RawMessage `json:"request"`
+}
+
+// newRequest returns an empty request of the method or false if the method is not recorded
+func newRequest(method string) (proto.Message, bool) {
+	switch method {
+	case nsRegister, nsUnregister:
+		return new(registry.NetworkService), true
+	case nsFind:
+		return new(registry.NetworkServiceQuery), true
+	case nseRegister, nseUnregister:
+		return new(registry.NetworkServiceEndpoint), true
+	case nseFind:
+		return new(registry.NetworkServiceEndpointQuery), true
+	default:
+		return nil, false
+	}
+}
+
+// newResponse returns an empty response of the unary method
+func newResponse(method string) proto.Message {
+	switch method {
+	case nsRegister:
+		return new(registry.NetworkService)
+	case nseRegister:
+		return new(registry.NetworkServiceEndpoint)
+	default:
+		return new(emptypb.Empty)
+	}
+}
+
+// sanitize returns a copy of the request without the SPIFFE IDs of the path, the tokens and the peer identities are
+// in the gRPC metadata that is not recorded at all
+func sanitize(request proto.Message) proto.Message {
+	request = proto.Clone(request)
+	switch r := request.(type) {
+	case *registry.NetworkService:
+		r.PathIds = nil
+	case *registry.NetworkServiceEndpoint:
+		r.PathIds = nil
+	case *registry.NetworkServiceQuery:
+		if r.GetNetworkService() != nil {
+			r.NetworkService.PathIds = nil
+		}
+	case *registry.NetworkServiceEndpointQuery:
+		if r.GetNetworkServiceEndpoint() != nil {
+			r.NetworkServiceEndpoint.PathIds = nil
+		}
+	}
+	return request
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type validateNSServer struct {
+	options *options
+}
+
+// NewNetworkServiceRegistryServer creates the chain element validating the registered network services, only the label
+// limits apply to them
+func NewNetworkServiceRegistryServer(opts ...Option) registry.NetworkServiceRegistryServer {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &validateNSServer{
+		options: o,
+	}
+}
+
+func (s *validateNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	if err := s.validateLabels(ns); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid labels of a ns %s: %v", ns.GetName(), err)
+	}
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *validateNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *validateNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
+
+// validateLabels checks the source and the destination selectors and the metadata labels of the matches
+func (s *validateNSServer) validateLabels(ns *registry.NetworkService) error {
+	var count int
+	for i, match := range ns.GetMatches() {
+		selectors := map[string]map[string]string{
+			fmt.Sprintf("the source selector of the match %d", i): match.GetSourceSelector(),
+			fmt.Sprintf("the metadata of the match %d", i):        match.GetMetadata().GetLabels(),
+		}
+		for j, route := range match.GetRoutes() {
+			selectors[fmt.Sprintf("the destination selector of the route %d of the match %d", j, i)] = route.GetDestinationSelector()
+		}
+		for owner, labels := range selectors {
+			if err := s.options.validateLabels(owner, labels); err != nil {
+				return err
+			}
+			count += len(labels)
+		}
+	}
+	return s.options.validateCount(count)
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validate provides the registry chain elements rejecting the registrations of the malformed or oversized
+// network services and endpoints with InvalidArgument, instead of storing them and failing the clients at connect time
+// or bloating the storage
+package validate
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type options struct {
+	urlSchemes          []string
+	maxLabels           int
+	maxLabelKeyLength   int
+	maxLabelValueLength int
+}
+
+// Option is an option pattern for the validation
+type Option func(o *options)
+
+// WithURLSchemes sets the schemes of the URLs the endpoints may register with, e.g. tcp, unix or vfio, empty disables
+// the validation of the URLs
+func WithURLSchemes(schemes ...string) Option {
+	return func(o *options) {
+		o.urlSchemes = schemes
+	}
+}
+
+// WithLabelLimits sets the maximum count of the labels of an endpoint summed over its network services or of a network
+// service summed over its match selectors and metadata, and the maximum lengths of their keys and values, 0 disables
+// the limit
+func WithLabelLimits(maxLabels, maxKeyLength, maxValueLength int) Option {
+	return func(o *options) {
+		o.maxLabels = maxLabels
+		o.maxLabelKeyLength = maxKeyLength
+		o.maxLabelValueLength = maxValueLength
+	}
+}
+
+type validateNSEServer struct {
+	options *options
+}
+
+// NewNetworkServiceEndpointRegistryServer creates the chain element validating the registered endpoints
+func NewNetworkServiceEndpointRegistryServer(opts ...Option) registry.NetworkServiceEndpointRegistryServer {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &validateNSEServer{
+		options: o,
+	}
+}
+
+func (s *validateNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if len(s.options.urlSchemes) > 0 {
+		if err := validateURL(nse.GetUrl(), s.options.urlSchemes); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid url of a nse %s: %v", nse.GetName(), err)
+		}
+	}
+	if err := s.validateLabels(nse); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid labels of a nse %s: %v", nse.GetName(), err)
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *validateNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *validateNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+// validateURL checks the URL has one of the schemes and addresses the endpoint, the tcp URLs need a host and a port
+// and the unix URLs a path
+func validateURL(rawURL string, schemes []string) error {
+	if rawURL == "" {
+		return errors.New("the url is empty")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse %s", rawURL)
+	}
+	if !slices.Contains(schemes, u.Scheme) {
+		return errors.Errorf("the scheme of %s is not one of %v", rawURL, schemes)
+	}
+	switch u.Scheme {
+	case "tcp":
+		host, port, splitErr := net.SplitHostPort(u.Host)
+		if splitErr != nil {
+			return errors.Wrapf(splitErr, "invalid address of %s", rawURL)
+		}
+		if number, atoiErr := strconv.Atoi(port); host == "" || atoiErr != nil || number <= 0 || number > 65535 {
+			return errors.Errorf("invalid address of %s, expected host:port", rawURL)
+		}
+	case "unix":
+		if u.Path == "" {
+			return errors.Errorf("%s has no path", rawURL)
+		}
+	default:
+		if u.Host == "" && u.Path == "" && u.Opaque == "" {
+			return errors.Errorf("%s has no address", rawURL)
+		}
+	}
+	return nil
+}
+
+func (s *validateNSEServer) validateLabels(nse *registry.NetworkServiceEndpoint) error {
+	var count int
+	for service, labels := range nse.GetNetworkServiceLabels() {
+		if err := s.options.validateLabels(service, labels.GetLabels()); err != nil {
+			return err
+		}
+		count += len(labels.GetLabels())
+	}
+	return s.options.validateCount(count)
+}
+
+// validateLabels checks the lengths of the keys and the values of the labels of the owner
+func (o *options) validateLabels(owner string, labels map[string]string) error {
+	for key, value := range labels {
+		if o.maxLabelKeyLength > 0 && len(key) > o.maxLabelKeyLength {
+			return errors.Errorf("a key of the labels of %s is longer than %d", owner, o.maxLabelKeyLength)
+		}
+		if o.maxLabelValueLength > 0 && len(value) > o.maxLabelValueLength {
+			return errors.Errorf("the value of the label %s of %s is longer than %d", key, owner, o.maxLabelValueLength)
+		}
+	}
+	return nil
+}
+
+// validateCount checks the count of the labels of a record
+func (o *options) validateCount(count int) error {
+	if o.maxLabels > 0 && count > o.maxLabels {
+		return errors.Errorf("%d labels are more than %d", count, o.maxLabels)
+	}
+	return nil
+}
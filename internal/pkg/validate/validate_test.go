@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+func TestValidateURL(t *testing.T) {
+	for _, tc := range []struct {
+		url   string
+		valid bool
+	}{
+		{url: "tcp://10.0.0.1:5001", valid: true},
+		{url: "tcp://nse.example.org:5001", valid: true},
+		{url: "unix:///var/lib/networkservicemesh/nse.sock", valid: true},
+		{url: "vfio:///dev/vfio/12", valid: true},
+		{url: ""},
+		{url: "tcp://10.0.0.1"},
+		{url: "tcp://:5001"},
+		{url: "tcp://10.0.0.1:70000"},
+		{url: "unix://"},
+		{url: "http://10.0.0.1:80"},
+		{url: "vfio:"},
+	} {
+		t.Run(tc.url, func(t *testing.T) {
+			if err := validateURL(tc.url, []string{"tcp", "unix", "vfio"}); (err == nil) != tc.valid {
+				t.Errorf("validateURL returned %v, want valid %v", err, tc.valid)
+			}
+		})
+	}
+}
+
+func TestValidateNSELabels(t *testing.T) {
+	s := NewNetworkServiceEndpointRegistryServer(WithLabelLimits(3, 10, 10))
+	for _, tc := range []struct {
+		name   string
+		labels map[string]*registry.NetworkServiceLabels
+		valid  bool
+	}{
+		{name: "no labels", valid: true},
+		{
+			name: "within the limits",
+			labels: map[string]*registry.NetworkServiceLabels{
+				"ns-1": {Labels: map[string]string{"app": "nse", "zone": "a"}},
+				"ns-2": {Labels: map[string]string{"app": "nse"}},
+			},
+			valid: true,
+		},
+		{
+			name: "too many labels over the network services",
+			labels: map[string]*registry.NetworkServiceLabels{
+				"ns-1": {Labels: map[string]string{"app": "nse", "zone": "a"}},
+				"ns-2": {Labels: map[string]string{"app": "nse", "zone": "a"}},
+			},
+		},
+		{
+			name:   "too long key",
+			labels: map[string]*registry.NetworkServiceLabels{"ns-1": {Labels: map[string]string{strings.Repeat("k", 11): "v"}}},
+		},
+		{
+			name:   "too long value",
+			labels: map[string]*registry.NetworkServiceLabels{"ns-1": {Labels: map[string]string{"k": strings.Repeat("v", 11)}}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1", NetworkServiceLabels: tc.labels})
+			if tc.valid && err != nil {
+				t.Errorf("register failed: %v", err)
+			}
+			if !tc.valid && status.Code(err) != codes.InvalidArgument {
+				t.Errorf("register returned %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestValidateNSLabels(t *testing.T) {
+	s := NewNetworkServiceRegistryServer(WithLabelLimits(3, 10, 10))
+	for _, tc := range []struct {
+		name    string
+		matches []*registry.Match
+		valid   bool
+	}{
+		{name: "no matches", valid: true},
+		{
+			name: "within the limits",
+			matches: []*registry.Match{{
+				SourceSelector: map[string]string{"app": "nsc"},
+				Routes:         []*registry.Destination{{DestinationSelector: map[string]string{"app": "nse"}}},
+				Metadata:       &registry.Metadata{Labels: map[string]string{"zone": "a"}},
+			}},
+			valid: true,
+		},
+		{
+			name: "too many labels over the matches",
+			matches: []*registry.Match{
+				{SourceSelector: map[string]string{"app": "nsc", "zone": "a"}},
+				{Routes: []*registry.Destination{{DestinationSelector: map[string]string{"app": "nse", "zone": "a"}}}},
+			},
+		},
+		{
+			name:    "too long source selector key",
+			matches: []*registry.Match{{SourceSelector: map[string]string{strings.Repeat("k", 11): "v"}}},
+		},
+		{
+			name:    "too long destination selector value",
+			matches: []*registry.Match{{Routes: []*registry.Destination{{DestinationSelector: map[string]string{"k": strings.Repeat("v", 11)}}}}},
+		},
+		{
+			name:    "too long metadata value",
+			matches: []*registry.Match{{Metadata: &registry.Metadata{Labels: map[string]string{"k": strings.Repeat("v", 11)}}}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.Register(context.Background(), &registry.NetworkService{Name: "ns-1", Matches: tc.matches})
+			if tc.valid && err != nil {
+				t.Errorf("register failed: %v", err)
+			}
+			if !tc.valid && status.Code(err) != codes.InvalidArgument {
+				t.Errorf("register returned %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
@@ -1,6 +1,6 @@
 // Copyright (c) 2020-2025 Doc.ai and/or its affiliates.
 //
-// Copyright (c) 2023-2025 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -24,18 +24,83 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"flag"
+	"io"
+	"maps"
 	"net/url"
 	"os"
 	"os/signal"
+	"slices"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/edwarnicke/grpcfd"
 
-	"github.com/bszirtes/sdk-k8s/pkg/registry/chains/registryk8s"
 	"github.com/bszirtes/sdk-k8s/pkg/tools/k8s"
+	"github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/clientset/versioned"
 
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/admin"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/audit"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/backup"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/bench"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/bulk"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/compression"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/connlimit"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/connpool"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/crds"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/deadline"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/doctor"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/errcode"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/events"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/expirepolicy"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/fakek8s"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/faults"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/health"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/inspect"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/leader"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/listeners"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/liveness"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/localsvid"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/logging"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/maintenance"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/maxprocs"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/memlimit"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/metrics"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/migrate"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/nameconflict"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/otlp"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/population"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/profiling"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/ratelimit"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/redact"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/registrychain"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/replicate"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/requestid"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/requestlog"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/retry"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/rotate"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/shard"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/cache"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/churn"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/etcd"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/federation"
+	k8sstorage "github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/k8s"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/memory"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/quota"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/readonly"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/shadow"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/tenancy"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/upstream"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/traces"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/traffic"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/validate"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	registryserver "github.com/networkservicemesh/sdk/pkg/registry"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
 	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
@@ -43,38 +108,179 @@ import (
 
 	nested "github.com/antonfisher/nested-logrus-formatter"
 	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	"github.com/spiffe/go-spiffe/v2/workloadapi"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"google.golang.org/grpc"
+	channelzservice "google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/debug"
-	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
 	"github.com/networkservicemesh/sdk/pkg/tools/pprofutils"
 )
 
-// Config is configuration for cmd-registry-memory
+// Config is configuration for cmd-registry-k8s
 type Config struct {
-	registryk8s.Config
-	ListenOn               []url.URL     `default:"unix:///listen.on.socket" desc:"url to listen on." split_words:"true"`
-	MaxTokenLifetime       time.Duration `default:"10m" desc:"maximum lifetime of tokens" split_words:"true"`
-	RegistryServerPolicies []string      `default:"etc/nsm/opa/common/.*.rego,etc/nsm/opa/registry/.*.rego,etc/nsm/opa/server/.*.rego" desc:"paths to files and directories that contain registry server policies" split_words:"true"`
-	RegistryClientPolicies []string      `default:"etc/nsm/opa/common/.*.rego,etc/nsm/opa/registry/.*.rego,etc/nsm/opa/client/.*.rego" desc:"paths to files and directories that contain registry client policies" split_words:"true"`
-	LogLevel               string        `default:"INFO" desc:"Log level" split_words:"true"`
-	OpenTelemetryEndpoint  string        `default:"otel-collector.observability.svc.cluster.local:4317" desc:"OpenTelemetry Collector Endpoint" split_words:"true"`
-	MetricsExportInterval  time.Duration `default:"10s" desc:"interval between mertics exports" split_words:"true"`
-	PprofEnabled           bool          `default:"false" desc:"is pprof enabled" split_words:"true"`
-	PprofListenOn          string        `default:"localhost:6060" desc:"pprof URL to ListenAndServe" split_words:"true"`
+	Namespace                     string                    `default:"default" desc:"namespace where is deployed registry-k8s instance" split_words:"true"`
+	FieldManager                  string                    `default:"registry-k8s" desc:"field manager used for server-side apply of the custom resources" split_words:"true"`
+	ManageCRDs                    bool                      `default:"false" desc:"create or update the NetworkService and NetworkServiceEndpoint CRDs at startup, otherwise the startup fails if they are missing" envconfig:"MANAGE_CRDS"`
+	K8sFake                       bool                      `default:"false" desc:"back the registry with fake in-memory Kubernetes clients instead of a cluster, for local development only" split_words:"true"`
+	K8sFakeFile                   string                    `desc:"file the custom resources of the fake Kubernetes clients are loaded from and saved to, empty keeps them only in memory" split_words:"true"`
+	K8sFakeIdentity               bool                      `default:"false" desc:"with NSM_K8S_FAKE, serve a self-signed SVID instead of the one of the SPIRE agent, the peers have to skip its verification" split_words:"true"`
+	InformerResyncPeriod          time.Duration             `default:"0" desc:"period of the informer resyncs replaying the custom resources to clean up expired ones, 0 disables resyncs" split_words:"true"`
+	InformerResyncJitter          float64                   `default:"0.1" desc:"maximum fraction of the informer resync period randomly added to it" split_words:"true"`
+	ConflictRetrySteps            int                       `default:"5" desc:"number of attempts of the custom resource writes failing on conflicts" split_words:"true"`
+	ConflictRetryBackoff          time.Duration             `default:"10ms" desc:"initial backoff between the attempts of the custom resource writes failing on conflicts, doubled with each retry" split_words:"true"`
+	ConflictRetryJitter           float64                   `default:"0.1" desc:"maximum fraction of the conflict retry backoff randomly added to it" split_words:"true"`
+	CRStatusEnabled               bool                      `default:"false" desc:"populate the status subresource of the custom resources, requires the status subresource enabled in the CRDs" split_words:"true"`
+	PodOwners                     bool                      `default:"false" desc:"make the pods registering the endpoints the owners of their custom resources, only the pods from the registry namespace can be owners" split_words:"true"`
+	CRFinalizers                  bool                      `default:"false" desc:"add a finalizer to the endpoint custom resources, so their deletion is reported to the watches before they vanish" split_words:"true"`
+	CRLabels                      map[string]string         `desc:"labels added to every custom resource written by the registry, e.g. team:nsm,backup:enabled" split_words:"true"`
+	CRAnnotations                 map[string]string         `desc:"annotations added to every custom resource written by the registry, e.g. owner:platform" split_words:"true"`
+	SweepInterval                 time.Duration             `default:"1m" desc:"interval of deleting the endpoint custom resources expired for longer than the grace period, 0 disables the sweeper" split_words:"true"`
+	SweepGracePeriod              time.Duration             `default:"30s" desc:"how long expired endpoint custom resources are kept before being swept" split_words:"true"`
+	SoftDeleteExpired             bool                      `default:"false" desc:"keep the custom resources of the expired NSEs marked with an annotation for the sweep grace period instead of deleting them right away, they are left out of the Find results meanwhile" split_words:"true"`
+	SkipNoopRefreshes             bool                      `default:"false" desc:"skip writing the refreshes of the NSEs changing nothing but the expiration while the stored expiration covers at least half of the requested lifetime, the NSEs stopping the refreshes expire up to half of their lifetime earlier" split_words:"true"`
+	WatchCoalesceWindow           time.Duration             `default:"0" desc:"window within which the updates of the same record are merged into the latest one for each Find watch, 0 sends every update" split_words:"true"`
+	WatchQueueSize                int                       `default:"64" desc:"number of the updates queued for each Find watch" split_words:"true"`
+	WatchOverflowPolicy           k8sstorage.OverflowPolicy `default:"disconnect" desc:"what happens to a Find watch whose queue is full: disconnect ends it with ResourceExhausted, drop-oldest drops the oldest update and resends the current state before the next one" split_words:"true"`
+	EventsEnabled                 bool                      `default:"false" desc:"record Kubernetes Events on the custom resources for the registrations, unregistrations, expirations, authorization denials and churn spikes" split_words:"true"`
+	Tenancy                       bool                      `default:"false" desc:"isolate the records registered from different namespaces, the namespaces are derived from the pod SPIFFE IDs and the workloads without one can not register" split_words:"true"`
+	TenancyLabel                  string                    `default:"nsm.networkservicemesh.io/namespace" desc:"network service label storing the namespace an endpoint is registered from and record annotation storing the one of a network service, the endpoint gauges are broken down by it" split_words:"true"`
+	TenancySharedNamespaces       []string                  `desc:"namespaces whose records are visible from every namespace in addition to the registry namespace" split_words:"true"`
+	TenancyProxyNamespaces        []string                  `desc:"namespaces of the NSMgrs sending the Find queries on behalf of the clients in addition to the registry namespace, they see the endpoints of the namespaces the queried network services are registered from" split_words:"true"`
+	QuotaMaxEndpointsPerNamespace int                       `default:"0" desc:"maximum number of endpoints registered from a namespace through a replica, 0 means unlimited" split_words:"true"`
+	QuotaMaxEndpointsPerIdentity  int                       `default:"0" desc:"maximum number of endpoints registered by a SPIFFE ID through a replica, the ones without a SPIFFE ID share one quota, 0 means unlimited" split_words:"true"`
+	ChurnWindow                   time.Duration             `default:"1m" desc:"window the churn of the NSEs of a network service is counted within" split_words:"true"`
+	ChurnThreshold                int                       `default:"0" desc:"number of the NSEs of a network service registered or unregistered within the churn window above which a warning is logged and recorded as an Event, 0 disables the warnings" split_words:"true"`
+	ReadOnly                      bool                      `default:"false" desc:"serve only the Find queries and reject the registrations with UNAVAILABLE, so the clients retry them against a writable replica" split_words:"true"`
+	MaintenanceEnabled            bool                      `default:"false" desc:"start in maintenance rejecting the registrations with UNAVAILABLE and a retry delay while serving the Find queries, the maintenance is turned on or off by the admin service" split_words:"true"`
+	MaintenanceRetryDelay         time.Duration             `default:"30s" desc:"delay the clients are asked to retry the registrations rejected in maintenance after" split_words:"true"`
+	ShardCount                    int                       `default:"0" desc:"number of shards the registrations are split into by the network service name, each replica serves the registrations of one shard, 0 disables sharding" split_words:"true"`
+	ShardIndex                    int                       `default:"-1" desc:"shard served by the replica, -1 takes the ordinal suffix of the hostname as in a StatefulSet" split_words:"true"`
+	ShardMapName                  string                    `default:"registry-k8s-shards" desc:"name of the ConfigMap advertising the replicas serving the shards" split_words:"true"`
+	LeaderElection                bool                      `default:"false" desc:"run in active-passive mode, only the replica holding the Lease serves registrations" split_words:"true"`
+	LeaderElectionLeaseName       string                    `default:"registry-k8s" desc:"name of the Lease used for leader election" split_words:"true"`
+	LeaderElectionLeaseDuration   time.Duration             `default:"15s" desc:"how long the followers wait before taking over a Lease that is not renewed" split_words:"true"`
+	LeaderElectionRenewDeadline   time.Duration             `default:"10s" desc:"how long the leader retries to renew the Lease before giving up the leadership" split_words:"true"`
+	LeaderElectionRetryPeriod     time.Duration             `default:"2s" desc:"interval between the attempts to acquire or renew the Lease" split_words:"true"`
+	ProxyRegistryURL              *url.URL                  `desc:"url to the proxy registry that handles this domain" split_words:"true"`
+	ClientIdleTimeout             time.Duration             `default:"5m" desc:"how long an unused connection to the proxy registry is kept open for reuse" split_words:"true"`
+	ClientHealthCheckInterval     time.Duration             `default:"10s" desc:"interval of closing the failed and idle connections to the proxy registry" split_words:"true"`
+	ClientRetryCodes              retry.Codes               `default:"UNAVAILABLE" desc:"comma separated status codes of the calls to the proxy registry that are retried, e.g. UNAVAILABLE,RESOURCE_EXHAUSTED" split_words:"true"`
+	ClientRetryMaxAttempts        int                       `default:"5" desc:"maximum number of attempts of a call to the proxy registry, 1 disables retries" split_words:"true"`
+	ClientRetryBackoff            time.Duration             `default:"100ms" desc:"initial backoff between the attempts of a call to the proxy registry, doubled with each retry" split_words:"true"`
+	UpstreamRegistryURL           *url.URL                  `desc:"url of the registry the Find queries without a local result are forwarded to, e.g. the registry of the other cluster" split_words:"true"`
+	UpstreamCacheTTL              time.Duration             `default:"30s" desc:"how long the results of the upstream registry are cached, 0 disables the cache" split_words:"true"`
+	FederationPeers               federation.Peers          `desc:"comma separated registries of the peer clusters whose Find results are merged into the local ones as cluster=url, e.g. west=tcp://registry.west:5002" split_words:"true"`
+	FederationTimeout             time.Duration             `default:"1s" desc:"how long the Find of each federation peer may take, the results of the slower peers are left out" split_words:"true"`
+	ReplicationPeers              []url.URL                 `desc:"urls of the registries of the peer clusters the registrations are replicated to" split_words:"true"`
+	ReplicationCluster            string                    `desc:"name of the cluster the replicated endpoints are labeled with as their origin, required with the replication peers" split_words:"true"`
+	ReplicationSelector           map[string]string         `desc:"labels of the endpoints replicated to the peers, e.g. app:payments,dr:enabled, all the endpoints are replicated if empty" split_words:"true"`
+	ReplicationQueueSize          int                       `default:"1024" desc:"number of the replications waiting for a peer, the further ones are dropped until the peer catches up" split_words:"true"`
+	ExpirePeriod                  time.Duration             `default:"1m" desc:"default expiration of the NSEs, the earlier expiration of their tokens wins, 0 leaves it to the tokens" split_words:"true"`
+	MaxExpiration                 time.Duration             `default:"0" desc:"maximum expiration of the NSEs, the longer expirations requested by the NSEs are shortened to it, 0 disables it" split_words:"true"`
+	ExpirationPolicies            expirepolicy.Policies     `desc:"comma separated expirations of the NSEs of the network services matched by name or by label as service=expiration or key:value=expiration, e.g. test-ns=30s,env:test=10s, the shortest matching one wins" split_words:"true"`
+	ExpirationPoliciesConfigMap   string                    `desc:"name of the ConfigMap in the registry namespace whose values are further expiration policies in the same format, reloaded on change, empty disables it" split_words:"true"`
+	LivenessProbe                 liveness.Probe            `desc:"probe of the URLs of the registered NSEs unregistering the unreachable ones before they expire: grpc checks grpc.health.v1.Health, tcp connects, empty disables it" split_words:"true"`
+	LivenessProbeInterval         time.Duration             `default:"10s" desc:"interval between the liveness probes of the NSEs" split_words:"true"`
+	LivenessProbeTimeout          time.Duration             `default:"1s" desc:"timeout of a single liveness probe of a NSE" split_words:"true"`
+	LivenessProbeFailureThreshold int                       `default:"3" desc:"number of the consecutive failed liveness probes after which a NSE is unregistered" split_words:"true"`
+	PodEviction                   bool                      `default:"false" desc:"unregister the NSEs as soon as the pods registering them are deleted, the pods are watched in all the namespaces" split_words:"true"`
+	PodEvictionLabel              string                    `desc:"network service label of the NSEs naming their pods as name or namespace/name, the NSEs without it are matched to the pods by the SPIFFE IDs" split_words:"true"`
+	NameConflictStrategy          nameconflict.Strategy     `default:"last-writer-wins" desc:"what happens to the registration of a NSE with the name registered by another SPIFFE ID: reject rejects it with AlreadyExists, last-writer-wins overwrites the NSE, rename registers it under the name suffixed with the hash of the SPIFFE ID" split_words:"true"`
+	NSEURLSchemes                 []string                  `desc:"schemes of the URLs the NSEs may register with, e.g. tcp,unix,vfio, the NSEs with other or malformed URLs are rejected with INVALID_ARGUMENT, empty disables the validation" envconfig:"NSE_URL_SCHEMES"`
+	MaxLabels                     int                       `default:"0" desc:"maximum count of the labels of a NSE summed over its network services or of a NS summed over its matches, the NSEs and the NSs with more are rejected with INVALID_ARGUMENT, 0 disables it" split_words:"true"`
+	MaxLabelKeyLength             int                       `default:"0" desc:"maximum length of the keys of the labels of a NSE and of the selectors and the metadata labels of a NS, the NSEs and the NSs with longer ones are rejected with INVALID_ARGUMENT, 0 disables it" split_words:"true"`
+	MaxLabelValueLength           int                       `default:"0" desc:"maximum length of the values of the labels of a NSE and of the selectors and the metadata labels of a NS, the NSEs and the NSs with longer ones are rejected with INVALID_ARGUMENT, 0 disables it" split_words:"true"`
+	Storage                       string                    `default:"k8s" desc:"storage for network services and endpoints: k8s, etcd or memory" split_words:"true"`
+	MigrateFromURL                *url.URL                  `desc:"url of a running registry, e.g. cmd-registry-memory, whose network services and endpoints are copied to the storage before serving" split_words:"true"`
+	ShadowStorage                 string                    `desc:"secondary storage receiving a copy of every write for comparison with the primary one: k8s, etcd or memory" split_words:"true"`
+	FindCacheTTL                  time.Duration             `default:"0" desc:"how long Find results are served from the cache, the concurrent identical queries share one storage read, 0 disables the cache" split_words:"true"`
+	FindCacheMaxEntries           int                       `default:"1000" desc:"maximum number of cached Find queries per record kind" split_words:"true"`
+	Etcd                          etcd.Config
+	ListenOn                      []url.URL           `default:"unix:///listen.on.socket" desc:"urls to listen on: tcp://, unix:// or unix-abstract://" split_words:"true"`
+	Listeners                     listeners.Listeners `desc:"additional listeners with their own transport security as JSON, e.g. [{\"url\":\"unix:///run/registry.sock\",\"tls\":\"none\"}], tls is one of mtls, tls or none, proxyProtocol reads the PROXY protocol v1 or v2 header on tcp listeners" split_words:"true"`
+	ProxyProtocolTrustedCIDRs     listeners.CIDRs     `desc:"comma separated networks of the load balancers trusted to send the PROXY protocol header, e.g. 10.0.0.0/8, the connections from other peers to the listeners reading it are rejected, empty trusts every peer" split_words:"true"`
+	ListenIPFamily                listeners.IPFamily  `default:"dual" desc:"IP family of the tcp listeners: dual binds the unspecified hosts dual-stack, ipv4 or ipv6 resolve and bind the hosts only in the family, ipv6 binds tcp://0.0.0.0 as [::]" split_words:"true"`
+	ListenRetrySteps              int                 `default:"10" desc:"number of attempts to bind a listener failing with a transient error, e.g. the address still in use by the replaced registry" split_words:"true"`
+	ListenRetryBackoff            time.Duration       `default:"100ms" desc:"initial backoff between the attempts to bind a listener, doubled with each retry up to 5s" split_words:"true"`
+	AllowPlaintextListeners       bool                `default:"false" desc:"serve the tcp listeners as plaintext h2c without TLS for local development and tests without SPIFFE identities, never enable it in production" split_words:"true"`
+	ReusePort                     bool                `default:"false" desc:"bind the tcp listeners with SO_REUSEPORT, so a replacement registry can bind the same port" split_words:"true"`
+	HandoffEnabled                bool                `default:"false" desc:"hand off the listeners to a replacement process started by the admin service and stop serving" split_words:"true"`
+	HealthCheckInterval           time.Duration       `default:"10s" desc:"interval of checking the health of the apiserver and SPIRE reported by grpc.health.v1.Health" split_words:"true"`
+	HealthCheckTimeout            time.Duration       `default:"5s" desc:"timeout of a single health check of the apiserver or SPIRE" split_words:"true"`
+	StartupTimeout                time.Duration       `default:"5m" desc:"how long the registry waits for each of the X.509 SVID, the CRDs and the informer caches to sync before it exits, 0 waits forever" split_words:"true"`
+	HealthProbesListenOn          string              `desc:"address of the HTTP /livez, /readyz and /healthz endpoints reflecting SPIRE, the apiserver, the informer caches and the listeners for the kubelet probes and the load balancers, e.g. :8081, empty disables them" split_words:"true"`
+	MaxRecvMsgSize                int                 `default:"4194304" desc:"maximum size in bytes of a gRPC message received by the registry server and clients" split_words:"true"`
+	MaxSendMsgSize                int                 `default:"2147483647" desc:"maximum size in bytes of a gRPC message sent by the registry server and clients" split_words:"true"`
+	GzipEnabled                   bool                `default:"false" desc:"compress the responses with gzip for the clients supporting it" split_words:"true"`
+	MaxConcurrentStreams          uint32              `default:"0" desc:"maximum number of concurrent streams of a client connection, 0 means the gRPC default" split_words:"true"`
+	MaxConnections                int                 `default:"0" desc:"maximum number of simultaneous client connections, 0 means unlimited" split_words:"true"`
+	RequestLogSuccessRate         float64             `default:"0" desc:"fraction of the successful requests logged with their method, peer, resource, duration and status" split_words:"true"`
+	RequestLogErrorRate           float64             `default:"1" desc:"fraction of the failed requests logged with their method, peer, resource, duration and status" split_words:"true"`
+	RequestLogSampling            map[string]int      `desc:"log only every n-th successful request of the methods instead of the success rate, e.g. find:100,register:10" split_words:"true"`
+	SlowRequestThreshold          time.Duration       `default:"0" desc:"log the requests taking longer than the threshold with their timing breakdown, 0 disables it" split_words:"true"`
+	Audit                         audit.Config
+	Traffic                       traffic.Config
+	DefaultRequestTimeout         time.Duration  `default:"30s" desc:"deadline applied to the unary requests arriving without one, 0 disables it" split_words:"true"`
+	DrainTimeout                  time.Duration  `default:"15s" desc:"how long the in-flight requests and watches are given to finish on shutdown, the servers stop accepting new ones and send GOAWAY meanwhile" split_words:"true"`
+	MaxTokenLifetime              time.Duration  `default:"10m" desc:"maximum lifetime of tokens" split_words:"true"`
+	RegistryServerPolicies        []string       `default:"etc/nsm/opa/common/.*.rego,etc/nsm/opa/registry/.*.rego,etc/nsm/opa/server/.*.rego" desc:"paths to files and directories that contain registry server policies" split_words:"true"`
+	RegistryClientPolicies        []string       `default:"etc/nsm/opa/common/.*.rego,etc/nsm/opa/registry/.*.rego,etc/nsm/opa/client/.*.rego" desc:"paths to files and directories that contain registry client policies" split_words:"true"`
+	LogLevel                      string         `default:"INFO" desc:"Log level" split_words:"true"`
+	LogLevels                     logging.Levels `desc:"log levels of the components overriding the log level, comma separated component=level pairs of the authorize, k8s, grpc and expire components, e.g. authorize=debug,k8s=warn" split_words:"true"`
+	LogFormat                     string         `default:"nested" desc:"log format: nested for humans, json for log pipelines extracting the fields, or text" split_words:"true"`
+	LogBackend                    string         `default:"logrus" desc:"logger backend: logrus, or zap formatting with less CPU under trace-heavy load, zap writes the nested and text formats in its own console format" split_words:"true"`
+	TracingEnabled                bool           `default:"true" desc:"log the requests passing through the registry chain elements, SIGTTOU toggles it at runtime" split_words:"true"`
+	LogFile                       string         `desc:"file the logs are written to in addition to stderr, empty disables it" split_words:"true"`
+	LogFileMaxSize                int64          `default:"104857600" desc:"size in bytes at which the log file is rotated, 0 disables the size based rotation" split_words:"true"`
+	LogFileMaxAge                 time.Duration  `default:"24h" desc:"age at which the log file is rotated, 0 disables the age based rotation" split_words:"true"`
+	LogFileMaxBackups             int            `default:"5" desc:"number of the rotated log files kept next to the current one" split_words:"true"`
+	OpenTelemetryEndpoint         string         `default:"otel-collector.observability.svc.cluster.local:4317" desc:"OpenTelemetry Collector Endpoint" split_words:"true"`
+	TraceSampleRatio              float64        `default:"1" desc:"fraction of the traces started by the registry that are sampled, the traces started by the clients follow their sampling decision" split_words:"true"`
+	OTLP                          otlp.Config
+	MetricsExportInterval         time.Duration `default:"10s" desc:"interval between mertics exports" split_words:"true"`
+	MetricsListenOn               string        `desc:"address of the Prometheus /metrics endpoint exporting the same instruments as the OpenTelemetry pipeline, e.g. :9090, empty disables it" split_words:"true"`
+	PprofEnabled                  bool          `default:"false" desc:"is pprof enabled" split_words:"true"`
+	PprofListenOn                 string        `default:"localhost:6060" desc:"pprof URL to ListenAndServe" split_words:"true"`
+	Profiling                     profiling.Config
+	Faults                        faults.Config
+	ReflectionEnabled             bool     `default:"false" desc:"register the gRPC server reflection service for debugging with grpcurl or evans" split_words:"true"`
+	ChannelzEnabled               bool     `default:"false" desc:"register the gRPC channelz service exposing the state of the connections and streams" split_words:"true"`
+	BulkRegistrationEnabled       bool     `default:"false" desc:"register the registryk8s.bulk.v1 service registering and unregistering many endpoints in one stream" split_words:"true"`
+	AdminAllowedSpiffeIDs         []string `desc:"SPIFFE IDs of the operators and the tooling allowed to use the admin service listing the registrations with their metadata, empty disables the service" split_words:"true"`
+	AdminHistorySize              int      `default:"1000" desc:"number of the latest changes of the NSEs written through the replica kept for the admin service, 0 disables the history" split_words:"true"`
 	// The QPS value is calculated for 40 NSEs, 40 NSCs and 5 FWDs.
 	// NSC, FWD and NSE refreshes occur every second
 	// NSE Refreshes: 1 refresh per sec. 				* 40 nses
 	// FWD Refreshes: 1 refresh per sec. 				* 5 fwds
 	// NSC Refreshes: 4 finds (in 1 refresh) per sec. 	* 40 nscs
 	// Total:											= 205
-	KubeletQPS int `default:"205" desc:"kubelet config settings" split_words:"true"`
+	// The registry_k8s_client_throttled_total and registry_k8s_client_rate_limiter_wait_seconds metrics show whether
+	// it needs tuning.
+	KubeletQPS          int  `default:"205" desc:"kubelet config settings" split_words:"true"`
+	KubeletQPSAdaptive  bool `default:"false" desc:"adjust the QPS of the custom resource client from the kubelet QPS within the bounds by the observed load and the apiserver throttling" split_words:"true"`
+	KubeletQPSMin       int  `default:"50" desc:"lower bound of the adaptive QPS" split_words:"true"`
+	KubeletQPSMax       int  `default:"1000" desc:"upper bound of the adaptive QPS" split_words:"true"`
+	MemoryLimitHeadroom int  `default:"10" desc:"percent of the cgroup memory limit left out of GOMEMLIMIT for the memory not managed by the Go runtime, 0 leaves GOMEMLIMIT unset" split_words:"true"`
 }
 
 func main() {
@@ -91,7 +297,6 @@ func main() {
 	defer cancel()
 
 	// Setup logging
-	log.EnableTracing(true)
 	logrus.SetFormatter(&nested.Formatter{})
 	ctx = log.WithLog(ctx, logruslogger.New(ctx, map[string]interface{}{"cmd": os.Args[0]}))
 
@@ -110,96 +315,1148 @@ func main() {
 		logrus.Fatalf("error processing config from env: %+v", err)
 	}
 
-	l, err := logrus.ParseLevel(config.LogLevel)
-	if err != nil {
-		logrus.Fatalf("invalid log level %s", config.LogLevel)
+	setupLogging(ctx, config)
+	log.FromContext(ctx).Infof("Config: %#v", redact.Struct(config))
+	if config.K8sFake {
+		log.FromContext(ctx).Warn("the registry is backed by fake Kubernetes clients, it must be used only for local development")
+		if config.K8sFakeFile != "" {
+			saveFake, err := fakek8s.Persist(ctx, config.K8sFakeFile)
+			if err != nil {
+				logrus.Fatalf("error loading the custom resources: %+v", err)
+			}
+			defer saveFake()
+		}
 	}
-	logrus.SetLevel(l)
-	log.FromContext(ctx).Infof("Config: %#v", config)
-	logruslogger.SetupLevelChangeOnSignal(ctx, map[os.Signal]logrus.Level{
-		syscall.SIGUSR1: logrus.TraceLevel,
-		syscall.SIGUSR2: l,
-	})
+	if len(os.Args) > 1 {
+		runCommand(ctx, config, os.Args[1:])
+		return
+	}
+	if config.Faults.Enabled() {
+		log.FromContext(ctx).Warnf("fault injection is enabled, it must be used only for resilience testing: %+v", config.Faults)
+	}
+	maxprocs.Set(ctx)
+	memlimit.Set(ctx, config.MemoryLimitHeadroom)
 
-	// Configure Open Telemetry
-	if opentelemetry.IsEnabled() {
-		collectorAddress := config.OpenTelemetryEndpoint
-		spanExporter := opentelemetry.InitSpanExporter(ctx, collectorAddress)
-		metricExporter := opentelemetry.InitOPTLMetricExporter(ctx, collectorAddress, config.MetricsExportInterval)
-		o := opentelemetry.Init(ctx, spanExporter, metricExporter, "registry-k8s")
-		defer func() {
-			if err = o.Close(); err != nil {
-				log.FromContext(ctx).Error(err.Error())
-			}
-		}()
+	// Start the continuous profiling before the other goroutines, so they inherit its labels
+	if err := profiling.Start(ctx, &config.Profiling); err != nil {
+		logrus.Fatalf("error starting the continuous profiling: %+v", err)
 	}
 
+	// Configure Open Telemetry and Prometheus
+	closeTelemetry := initTelemetry(ctx, config)
+	defer closeTelemetry()
 	// Configure pprof
 	if config.PprofEnabled {
 		go pprofutils.ListenAndServe(ctx, config.PprofListenOn)
 	}
 
+	// The features using the Kubernetes core resources share one client, so they share its QPS
+	kubeClient, err := newKubeClient(config)
+	if err != nil {
+		logrus.Fatalf("error creating kubernetes client: %+v", err)
+	}
+	probes := newProbes(ctx, config, kubeClient)
+
 	// Get a X509Source
-	source, err := workloadapi.NewX509Source(ctx)
+	source, err := newX509Source(ctx, config)
 	if err != nil {
-		logrus.Fatalf("error getting x509 source: %+v", err)
+		logrus.WithField(errcode.LogField, errcode.SpireUnavailable).Fatalf("error getting x509 source: %+v", err)
 	}
 	svid, err := source.GetX509SVID()
 	if err != nil {
-		logrus.Fatalf("error getting x509 svid: %+v", err)
+		logrus.WithField(errcode.LogField, errcode.SpireUnavailable).Fatalf("error getting x509 svid: %+v", err)
 	}
 	logrus.Infof("SVID: %q", svid.ID)
+	probes.Set(ctx, "spire", health.SVIDCheck(source))
+
+	// NSM_LISTEN_ON listeners require mTLS, NSM_LISTENERS may choose the security of each listener
+	allListeners := append(listeners.FromURLs(config.ListenOn, listeners.MTLS), config.Listeners...)
+	inherited, handedOff, err := listeners.Activated(listeners.WithProxyProtocolTrustedCIDRs(config.ProxyProtocolTrustedCIDRs))
+	if err != nil {
+		logrus.Fatalf("error inheriting the activated sockets: %+v", err)
+	}
+	if config.AllowPlaintextListeners {
+		log.FromContext(ctx).Warn("the tcp listeners serve plaintext h2c, it must be used only for local development")
+		allListeners = allListeners.Plaintext()
+	}
+	if handedOff {
+		allListeners = nil
+	}
+	// Create GRPC Servers and register services
+	trafficRecorder, err := traffic.Open(&config.Traffic)
+	if err != nil {
+		logrus.Fatalf("error opening the traffic record file: %+v", err)
+	}
+	if trafficRecorder != nil {
+		defer func() { _ = trafficRecorder.Close() }()
+	}
+	servers := newServers(config, source, allListeners, inherited, trafficRecorder)
 
+	clientOptions := newClientOptions(config, source)
+
+	// The registry keeps serving the in-flight requests while the servers drain, so it is stopped only after the drain
+	registryCtx, cancelRegistry := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelRegistry()
+
+	recorder := newRecorder(registryCtx, config, kubeClient)
+
+	var elector *leader.Elector
+	if config.LeaderElection {
+		if elector, err = newElector(registryCtx, config, kubeClient); err != nil {
+			logrus.Fatalf("error starting leader election: %+v", err)
+		}
+	}
+
+	var registrations *admin.Registrations
+	if len(config.AdminAllowedSpiffeIDs) > 0 {
+		registrations = admin.NewRegistrations(config.AdminHistorySize)
+	}
+
+	mode := maintenance.NewMode(config.MaintenanceEnabled, config.MaintenanceRetryDelay)
+
+	if usesK8sStorage(config) {
+		probes.Pending(ctx, "informers", "the CRDs and the informer caches to sync")
+	}
+	registryStorage, annotator, err := newRegistryStorage(registryCtx, config, kubeClient, recorder, elector, registrations, mode, clientOptions)
+	if err != nil {
+		logrus.Fatalf("error creating the storage: %+v", err)
+	}
+	if usesK8sStorage(config) {
+		probes.Done(ctx, "informers")
+	}
+
+	auditLog, err := audit.Open(&config.Audit)
+	if err != nil {
+		logrus.Fatalf("error opening the audit log: %+v", err)
+	}
+	if auditLog != nil {
+		defer func() { _ = auditLog.Close() }()
+	}
+	authorizeNSServer, authorizeNSEServer := newAuthorizeServers(config, recorder, auditLog)
+
+	registryServer := registrychain.NewServer(
+		registryCtx,
+		newTokenGenerator(config, source),
+		registrychain.WithStorage(registryStorage),
+		registrychain.WithAnnotator(annotator),
+		registrychain.WithDefaultExpiration(config.ExpirePeriod),
+		registrychain.WithMaxExpiration(config.MaxExpiration),
+		registrychain.WithExpirationPolicies(newExpirationPolicies(registryCtx, config, kubeClient)),
+		registrychain.WithLivenessProbe(config.LivenessProbe, config.LivenessProbeInterval, config.LivenessProbeTimeout, config.LivenessProbeFailureThreshold),
+		registrychain.WithPodEviction(newPodEvictionClient(config, kubeClient), config.PodEvictionLabel),
+		registrychain.WithNameConflictStrategy(config.NameConflictStrategy, auditLog),
+		registrychain.WithValidation(
+			validate.WithURLSchemes(config.NSEURLSchemes...),
+			validate.WithLabelLimits(config.MaxLabels, config.MaxLabelKeyLength, config.MaxLabelValueLength),
+		),
+		registrychain.WithProxyRegistryURL(config.ProxyRegistryURL),
+		registrychain.WithAuthorizeNSERegistryServer(authorizeNSEServer),
+		registrychain.WithAuthorizeNSERegistryClient(authorize.NewNetworkServiceEndpointRegistryClient(authorize.WithPolicies(config.RegistryClientPolicies...))),
+		registrychain.WithAuthorizeNSRegistryServer(authorizeNSServer),
+		registrychain.WithAuthorizeNSRegistryClient(authorize.NewNetworkServiceRegistryClient(authorize.WithPolicies(config.RegistryClientPolicies...))),
+		registrychain.WithDialOptions(clientOptions...),
+		registrychain.WithClientPool(config.ClientIdleTimeout, config.ClientHealthCheckInterval),
+		registrychain.WithSlowRequestThreshold(config.SlowRequestThreshold),
+	)
+
+	healthServer := newHealthServer(ctx, config, probes, registryServer)
+
+	var adminServer admin.Server
+	handoff := &listenersHandoff{cancel: cancel}
+	if registrations != nil {
+		adminOptions := []admin.Option{admin.WithMaintenance(mode)}
+		if config.HandoffEnabled {
+			adminOptions = append(adminOptions, admin.WithHandoff(handoff.run))
+		}
+		adminServer = admin.NewServer(registrations, config.AdminAllowedSpiffeIDs, adminOptions...)
+	} else if config.HandoffEnabled {
+		log.FromContext(ctx).Warn("the handoff is triggered by the admin service, it is disabled without NSM_ADMIN_ALLOWED_SPIFFE_IDS")
+	}
+
+	registerServices(config, servers, registryServer, healthServer, adminServer)
+
+	probes.Pending(ctx, "listeners", "the listeners to bind")
+	bound := listenAndServe(ctx, cancel, config, servers, allListeners, inherited)
+	probes.Done(ctx, "listeners")
+	handoff.bound.Store(&bound)
+
+	log.FromContext(ctx).Infof("Startup completed in %v", time.Since(startTime))
+	<-ctx.Done()
+
+	if elector != nil {
+		// The leader releases the Lease before draining, so a follower takes over the registrations right away
+		elector.Resign()
+	}
+
+	log.FromContext(ctx).Infof("Draining the servers for up to %v", config.DrainTimeout)
+	listeners.Drain(slices.Collect(maps.Values(servers)), config.DrainTimeout)
+}
+
+// x509Source is the source of the SVID of the registry and the bundles verifying the peers
+type x509Source interface {
+	x509svid.Source
+	x509bundle.Source
+}
+
+// newX509Source returns the source of the SPIRE agent or the self-signed one with NSM_K8S_FAKE_IDENTITY
+func newX509Source(ctx context.Context, config *Config) (x509Source, error) {
+	if config.K8sFakeIdentity {
+		if !config.K8sFake {
+			return nil, errors.New("NSM_K8S_FAKE_IDENTITY requires NSM_K8S_FAKE")
+		}
+		log.FromContext(ctx).Warn("the registry serves a self-signed SVID, the peers can't verify it")
+		id, err := spiffeid.FromSegments(spiffeid.RequireTrustDomainFromString("local"), "ns", config.Namespace, "pod", "registry-k8s")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create the SPIFFE ID")
+		}
+		return localsvid.New(id, 365*24*time.Hour)
+	}
+	svidCtx, cancelSVID := withStartupTimeout(ctx, config)
+	defer cancelSVID()
+	return workloadapi.NewX509Source(svidCtx)
+}
+
+// newTokenGenerator creates the generator of the tokens signed by the SVID of the source
+func newTokenGenerator(config *Config, source x509svid.Source) token.GeneratorFunc {
+	return faults.NewTokenGenerator(&config.Faults, spiffejwt.TokenGeneratorFunc(source, config.MaxTokenLifetime))
+}
+
+// newClientOptions creates the dial options of the registry clients, e.g. of the proxy registry
+func newClientOptions(config *Config, source x509Source) []grpc.DialOption {
 	tlsClientConfig := tlsconfig.MTLSClientConfig(source, source, tlsconfig.AuthorizeAny())
 	tlsClientConfig.MinVersion = tls.VersionTLS12
-	tlsServerConfig := tlsconfig.MTLSServerConfig(source, source, tlsconfig.AuthorizeAny())
-	tlsServerConfig.MinVersion = tls.VersionTLS12
 
-	credsTLS := credentials.NewTLS(tlsServerConfig)
-	// Create GRPC Server and register services
-	serverOptions := append(tracing.WithTracing(), grpc.Creds(credsTLS))
-	server := grpc.NewServer(serverOptions...)
-
-	clientOptions := append(
+	// The clients dial without blocking, so an unreachable proxy registry fails the calls instead of wedging them
+	return append(append(
 		tracing.WithTracingDial(),
-		grpc.WithBlock(),
 		grpc.WithDefaultCallOptions(
-			grpc.WaitForReady(true),
-			grpc.PerRPCCredentials(token.NewPerRPCCredentials(spiffejwt.TokenGeneratorFunc(source, config.MaxTokenLifetime)))),
+			grpc.MaxCallRecvMsgSize(config.MaxRecvMsgSize),
+			grpc.MaxCallSendMsgSize(config.MaxSendMsgSize),
+			grpc.PerRPCCredentials(token.NewPerRPCCredentials(newTokenGenerator(config, source)))),
 		grpc.WithTransportCredentials(
 			grpcfd.TransportCredentials(credentials.NewTLS(tlsClientConfig))),
 		grpcfd.WithChainStreamInterceptor(),
 		grpcfd.WithChainUnaryInterceptor(),
-	)
+	), retry.DialOptions(config.ClientRetryCodes, config.ClientRetryMaxAttempts, config.ClientRetryBackoff)...)
+}
+
+// setupLogging applies the configured log levels, format, backend, file and tracing, SIGUSR1 switches the level to
+// TRACE and SIGUSR2 back
+func setupLogging(ctx context.Context, config *Config) {
+	l, err := logrus.ParseLevel(config.LogLevel)
+	if err != nil {
+		logrus.Fatalf("invalid log level %s", config.LogLevel)
+	}
+	logrus.SetLevel(l)
+
+	switch config.LogFormat {
+	case "nested":
+		logrus.SetFormatter(&nested.Formatter{})
+	case "json":
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	case "text":
+		logrus.SetFormatter(&logrus.TextFormatter{DisableColors: true, FullTimestamp: true})
+	default:
+		logrus.Fatalf("invalid log format %s, expected json, nested or text", config.LogFormat)
+	}
+	backend, ok := logging.Backends[config.LogBackend]
+	if !ok {
+		logrus.Fatalf("invalid log backend %s, expected logrus or zap", config.LogBackend)
+	}
+	// The sdk replaces the formatter with its own while the global logger is the default one
+	log.SetGlobalLogger(backend(logrus.StandardLogger()))
+
+	if config.LogFile != "" {
+		file, err := rotate.Open(config.LogFile,
+			rotate.WithMaxSize(config.LogFileMaxSize),
+			rotate.WithMaxAge(config.LogFileMaxAge),
+			rotate.WithMaxBackups(config.LogFileMaxBackups))
+		if err != nil {
+			logrus.Fatalf("error opening the log file: %+v", err)
+		}
+		logrus.SetOutput(io.MultiWriter(os.Stderr, file))
+	}
+	logging.SetLevels(config.LogLevels, backend)
+
+	logruslogger.SetupLevelChangeOnSignal(ctx, map[os.Signal]logrus.Level{
+		syscall.SIGUSR1: logrus.TraceLevel,
+		syscall.SIGUSR2: l,
+	})
+
+	log.EnableTracing(config.TracingEnabled)
+	go toggleTracingOnSignal(ctx)
+}
+
+// toggleTracingOnSignal turns the tracing of the registry chain elements on or off on SIGTTOU, the tracing adds
+// latency to every request, so it may be turned on only while investigating
+func toggleTracingOnSignal(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTTOU)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+		}
+		enabled := !log.IsTracingEnabled()
+		log.EnableTracing(enabled)
+		log.FromContext(ctx).Infof("tracing enabled: %v", enabled)
+	}
+}
+
+// initTelemetry configures Open Telemetry and the Prometheus endpoint, the returned function flushes and stops them
+func initTelemetry(ctx context.Context, config *Config) func() {
+	var closers []io.Closer
+	var metricReaders []sdkmetric.Reader
+	if config.MetricsListenOn != "" {
+		reader, handler, err := metrics.NewPrometheusReader()
+		if err != nil {
+			logrus.Fatalf("error creating Prometheus metrics: %+v", err)
+		}
+		go metrics.ListenAndServe(ctx, config.MetricsListenOn, handler)
+		metricReaders = append(metricReaders, reader)
+	}
+	res := config.OTLP.Resource(ctx, "registry-k8s", config.Namespace)
+	if opentelemetry.IsEnabled() {
+		collectorAddress := config.OpenTelemetryEndpoint
+		if spanExporter, err := otlp.NewSpanExporter(ctx, collectorAddress, &config.OTLP); err == nil {
+			closers = append(closers, traces.Init(ctx, res, spanExporter, config.TraceSampleRatio))
+		} else {
+			log.FromContext(ctx).Errorf("%+v", err)
+		}
+		if metricReader, err := otlp.NewMetricReader(ctx, collectorAddress, config.MetricsExportInterval, &config.OTLP); err == nil {
+			metricReaders = append(metricReaders, metricReader)
+		} else {
+			log.FromContext(ctx).Errorf("%+v", err)
+		}
+	}
+	if len(metricReaders) > 0 {
+		closers = append(closers, metrics.Init(ctx, res, metricReaders...))
+		if err := metrics.RegisterRuntime(); err != nil {
+			log.FromContext(ctx).Errorf("failed to export the runtime metrics: %+v", err)
+		}
+		metrics.RegisterKubernetesClient()
+	}
+	return func() {
+		for _, closer := range closers {
+			if err := closer.Close(); err != nil {
+				log.FromContext(ctx).Error(err.Error())
+			}
+		}
+	}
+}
+
+// newServers creates a server for every security used by the listeners
+func newServers(config *Config, source x509Source, configured listeners.Listeners, inherited []listeners.Bound, trafficRecorder *traffic.Recorder) map[listeners.Security]*grpc.Server {
+	tlsServerConfig := tlsconfig.MTLSServerConfig(source, source, tlsconfig.AuthorizeAny())
+	tlsServerConfig.MinVersion = tls.VersionTLS12
+
+	tlsOnlyServerConfig := tlsconfig.TLSServerConfig(source)
+	tlsOnlyServerConfig.MinVersion = tls.VersionTLS12
+
+	serverCreds := map[listeners.Security]credentials.TransportCredentials{
+		listeners.MTLS:     credentials.NewTLS(tlsServerConfig),
+		listeners.TLS:      credentials.NewTLS(tlsOnlyServerConfig),
+		listeners.Insecure: insecure.NewCredentials(),
+	}
+	if config.MaxConnections > 0 {
+		limiter := connlimit.NewLimiter(config.MaxConnections)
+		for security, creds := range serverCreds {
+			serverCreds[security] = limiter.Credentials(creds)
+		}
+	}
+
+	serverOptions := append(tracing.WithTracing(),
+		grpc.MaxRecvMsgSize(config.MaxRecvMsgSize),
+		grpc.MaxSendMsgSize(config.MaxSendMsgSize))
+	if config.GzipEnabled {
+		serverOptions = append(serverOptions, compression.ServerOptions()...)
+	}
+	if config.MaxConcurrentStreams > 0 {
+		serverOptions = append(serverOptions, grpc.MaxConcurrentStreams(config.MaxConcurrentStreams))
+	}
+	serverOptions = append(serverOptions, requestid.ServerOptions()...)
+	if config.RequestLogSuccessRate > 0 || config.RequestLogErrorRate > 0 || len(config.RequestLogSampling) > 0 {
+		serverOptions = append(serverOptions, requestlog.ServerOptions(
+			requestlog.WithSuccessRate(config.RequestLogSuccessRate),
+			requestlog.WithErrorRate(config.RequestLogErrorRate),
+			requestlog.WithSampling(config.RequestLogSampling))...)
+	}
+	if trafficRecorder != nil {
+		serverOptions = append(serverOptions, traffic.ServerOptions(trafficRecorder)...)
+	}
+	serverOptions = append(serverOptions, errcode.ServerOptions()...)
+	serverOptions = append(serverOptions, deadline.ServerOptions(config.DefaultRequestTimeout)...)
+
+	servers := make(map[listeners.Security]*grpc.Server)
+	newServer := func(security listeners.Security) {
+		if _, ok := servers[security]; !ok {
+			servers[security] = grpc.NewServer(append(slices.Clone(serverOptions), grpc.Creds(serverCreds[security]))...)
+		}
+	}
+	for i := range configured {
+		newServer(configured[i].Security)
+	}
+	for i := range inherited {
+		newServer(inherited[i].Security)
+	}
+	return servers
+}
+
+// listenAndServe serves the inherited listeners and the configured ones, it returns all the served listeners
+func listenAndServe(ctx context.Context, cancel context.CancelFunc, config *Config, servers map[listeners.Security]*grpc.Server,
+	configured listeners.Listeners, inherited []listeners.Bound) []listeners.Bound {
+	bound := inherited
+	for i := range configured {
+		b, err := configured[i].Listen(ctx,
+			listeners.WithReusePort(config.ReusePort),
+			listeners.WithIPFamily(config.ListenIPFamily),
+			listeners.WithRetry(config.ListenRetrySteps, config.ListenRetryBackoff),
+			listeners.WithProxyProtocolTrustedCIDRs(config.ProxyProtocolTrustedCIDRs))
+		if err != nil {
+			logrus.Fatalf("error listening on %s: %+v", configured[i].URL.String(), err)
+		}
+		log.FromContext(ctx).Infof("listening on %s", configured[i].URL.String())
+		bound = append(bound, b)
+	}
+	for i := range bound {
+		srvErrCh := listeners.Serve(bound[i].Listener, servers[bound[i].Security])
+		exitOnErr(ctx, cancel, srvErrCh)
+	}
+	return bound
+}
+
+// newAuthorizeServers creates the server authorization elements, the requests are recorded in the audit log and the
+// denied ones as Events
+func newAuthorizeServers(config *Config, recorder record.EventRecorder, auditLog *audit.Logger) (registry.NetworkServiceRegistryServer, registry.NetworkServiceEndpointRegistryServer) {
+	authorizeNSServer := logging.NewNetworkServiceRegistryServer(logging.Authorize, faults.NewNetworkServiceRegistryServer(&config.Faults,
+		authorize.NewNetworkServiceRegistryServer(authorize.WithPolicies(config.RegistryServerPolicies...))))
+	authorizeNSEServer := logging.NewNetworkServiceEndpointRegistryServer(logging.Authorize, faults.NewNetworkServiceEndpointRegistryServer(&config.Faults,
+		authorize.NewNetworkServiceEndpointRegistryServer(authorize.WithPolicies(config.RegistryServerPolicies...))))
+	if recorder != nil {
+		authorizeNSServer = events.NewNetworkServiceRegistryServer(recorder, config.Namespace, authorizeNSServer)
+		authorizeNSEServer = events.NewNetworkServiceEndpointRegistryServer(recorder, config.Namespace, authorizeNSEServer)
+	}
+	if auditLog != nil {
+		authorizeNSServer = audit.NewNetworkServiceRegistryServer(auditLog, authorizeNSServer)
+		authorizeNSEServer = audit.NewNetworkServiceEndpointRegistryServer(auditLog, authorizeNSEServer)
+	}
+	return authorizeNSServer, authorizeNSEServer
+}
+
+// newRegistryStorage creates the configured storage wrapped by the churn, shadow, cache, tenancy, quota, read-only,
+// maintenance, shard and leader storages and registers the gauges of its population. It returns the backend keeping
+// the annotations of the records as well, if it does.
+func newRegistryStorage(ctx context.Context, config *Config, kubeClient kubernetes.Interface, recorder record.EventRecorder, elector *leader.Elector, registrations *admin.Registrations, mode *maintenance.Mode, clientOptions []grpc.DialOption) (storage.Storage, storage.Annotator, error) {
+	registryStorage, err := newStorage(ctx, config.Storage, config, kubeClient, recorder)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to create %s storage", config.Storage)
+	}
+	// The backend keeps the annotations of the records, e.g. the namespaces of the network services for the tenancy
+	// and the owners of the endpoints for the name conflicts
+	annotator, _ := registryStorage.(storage.Annotator)
+	registryStorage = faults.NewStorage(registryStorage, &config.Faults)
+	if config.MigrateFromURL != nil {
+		if err = migrateFrom(ctx, config.MigrateFromURL, registryStorage, clientOptions); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err = population.Register(registryStorage, config.TenancyLabel); err != nil {
+		return nil, nil, err
+	}
+	if registrations != nil {
+		registryStorage = admin.NewStorage(registryStorage, registrations)
+	}
+	registryStorage = churn.NewStorage(registryStorage,
+		churn.WithThreshold(config.ChurnWindow, config.ChurnThreshold),
+		churn.WithEventRecorder(recorder, config.Namespace))
+	// The replication, the upstream registry and the federation share the connections to the other registries
+	pool := connpool.New(ctx,
+		connpool.WithDialOptions(clientOptions...),
+		connpool.WithIdleTimeout(config.ClientIdleTimeout),
+		connpool.WithHealthCheckInterval(config.ClientHealthCheckInterval))
+	if len(config.ReplicationPeers) > 0 {
+		if registryStorage, err = newReplicateStorage(ctx, config, registryStorage, pool); err != nil {
+			return nil, nil, err
+		}
+	}
+	if config.ShadowStorage != "" {
+		shadowStorage, shadowErr := newStorage(ctx, config.ShadowStorage, config, kubeClient, nil)
+		if shadowErr != nil {
+			return nil, nil, errors.Wrapf(shadowErr, "failed to create %s shadow storage", config.ShadowStorage)
+		}
+		registryStorage = shadow.NewStorage(registryStorage, shadowStorage)
+	}
+	if config.FindCacheTTL > 0 {
+		registryStorage = cache.NewStorage(registryStorage,
+			cache.WithTTL(config.FindCacheTTL),
+			cache.WithMaxEntries(config.FindCacheMaxEntries))
+	}
+	if config.UpstreamRegistryURL != nil {
+		registryStorage = upstream.NewStorage(registryStorage, config.UpstreamRegistryURL, pool,
+			upstream.WithCacheTTL(config.UpstreamCacheTTL),
+			upstream.WithCacheMaxEntries(config.FindCacheMaxEntries))
+	}
+	if len(config.FederationPeers) > 0 {
+		registryStorage = federation.NewStorage(registryStorage, config.FederationPeers, pool,
+			federation.WithTimeout(config.FederationTimeout))
+	}
+	if config.Tenancy {
+		registryStorage = tenancy.NewStorage(registryStorage,
+			tenancy.WithLabel(config.TenancyLabel),
+			tenancy.WithSharedNamespaces(append(config.TenancySharedNamespaces, config.Namespace)...),
+			tenancy.WithProxyNamespaces(append(config.TenancyProxyNamespaces, config.Namespace)...),
+			tenancy.WithAnnotator(annotator))
+	}
+	if config.QuotaMaxEndpointsPerNamespace > 0 || config.QuotaMaxEndpointsPerIdentity > 0 {
+		registryStorage = quota.NewStorage(registryStorage,
+			quota.WithMaxPerNamespace(config.QuotaMaxEndpointsPerNamespace),
+			quota.WithMaxPerIdentity(config.QuotaMaxEndpointsPerIdentity))
+	}
+	if config.ReadOnly {
+		registryStorage = readonly.NewStorage(registryStorage)
+	}
+	registryStorage = maintenance.NewStorage(registryStorage, mode)
+	if config.ShardCount > 0 {
+		registryStorage, err = newShardStorage(ctx, config, kubeClient, registryStorage)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if elector != nil {
+		registryStorage = leader.NewStorage(registryStorage, elector)
+	}
+	return registryStorage, annotator, nil
+}
+
+// runCommand runs the command given instead of serving
+func runCommand(ctx context.Context, config *Config, args []string) {
+	switch args[0] {
+	case "export", "import":
+		runBackup(ctx, config, args)
+	case "inspect":
+		runInspect(ctx, config, args)
+	case "doctor":
+		runDoctor(ctx, config, args)
+	case "bench":
+		runBench(ctx, config, args)
+	case "replay":
+		runReplay(ctx, config, args)
+	case "maintenance":
+		runMaintenance(ctx, config, args)
+	case "handoff":
+		runHandoff(ctx, config, args)
+	default:
+		logrus.Fatalf("unknown command %s, expected export, import, inspect, doctor, bench, replay, maintenance or handoff", args[0])
+	}
+}
+
+// runBackup runs the export or the import command against the configured storage instead of serving, the registrations
+// are exported to stdout and imported from stdin unless a file is given
+func runBackup(ctx context.Context, config *Config, args []string) {
+	flags := flag.NewFlagSet(args[0], flag.ExitOnError)
+	fileName := flags.String("file", "", "file to export the registrations to or import them from")
+	formatName := flags.String("format", string(backup.JSON), "format of the exported registrations: json or yaml")
+	_ = flags.Parse(args[1:])
+
+	kubeClient, err := newKubeClient(config)
+	if err != nil {
+		logrus.Fatalf("error creating kubernetes client: %+v", err)
+	}
+	s, err := newStorage(ctx, config.Storage, config, kubeClient, nil)
+	if err != nil {
+		logrus.Fatalf("error creating the storage: %+v", err)
+	}
+
+	if args[0] == "export" {
+		var format backup.Format
+		if err = format.Decode(*formatName); err != nil {
+			logrus.Fatal(err)
+		}
+		w := os.Stdout
+		if *fileName != "" {
+			if w, err = os.Create(*fileName); err != nil {
+				logrus.Fatalf("error creating the export file: %+v", err)
+			}
+			defer func() { _ = w.Close() }()
+		}
+		if err = backup.Export(ctx, s, w, format); err != nil {
+			logrus.Fatalf("error exporting the registrations: %+v", err)
+		}
+		return
+	}
+
+	r := os.Stdin
+	if *fileName != "" {
+		if r, err = os.Open(*fileName); err != nil {
+			logrus.Fatalf("error opening the import file: %+v", err)
+		}
+		defer func() { _ = r.Close() }()
+	}
+	services, endpoints, err := backup.Import(ctx, s, r)
+	if err != nil {
+		logrus.Fatalf("error importing the registrations after %d network services and %d endpoints: %+v", services, endpoints, err)
+	}
+	log.FromContext(ctx).Infof("imported %d network services and %d endpoints", services, endpoints)
+}
+
+// migrateFrom copies the registrations of the registry at the url to the storage, so the endpoints registered there
+// are found through this registry before they refresh their registrations
+func migrateFrom(ctx context.Context, u *url.URL, s storage.Storage, clientOptions []grpc.DialOption) error {
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(u), clientOptions...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dial the registry %s to migrate from", u)
+	}
+	defer func() { _ = cc.Close() }()
+
+	services, endpoints, err := migrate.Copy(ctx, cc, s)
+	if err != nil {
+		return errors.Wrapf(err, "failed to migrate from the registry %s after %d network services and %d endpoints", u, services, endpoints)
+	}
+	log.FromContext(ctx).Infof("migrated %d network services and %d endpoints from the registry %s", services, endpoints, u)
+	return nil
+}
+
+// newReplicateStorage creates the storage replicating the registrations to the registries of the peer clusters
+func newReplicateStorage(ctx context.Context, config *Config, s storage.Storage, pool *connpool.Pool) (storage.Storage, error) {
+	if config.ReplicationCluster == "" {
+		return nil, errors.New("the cluster name is required to replicate the registrations")
+	}
+	r := replicate.New(ctx, config.ReplicationCluster, config.ReplicationPeers, pool,
+		replicate.WithSelector(config.ReplicationSelector),
+		replicate.WithQueueSize(config.ReplicationQueueSize))
+	log.FromContext(ctx).Infof("replicating the registrations to %d peers", len(config.ReplicationPeers))
+	return replicate.NewStorage(s, r), nil
+}
+
+// runInspect runs the inspect command printing the network services or the endpoints of a running registry found by
+// Find or listed by the admin service
+func runInspect(ctx context.Context, config *Config, args []string) {
+	if len(args) < 2 || (args[1] != "nse" && args[1] != "ns") {
+		logrus.Fatal("expected the records to inspect: nse or ns")
+	}
+	flags := flag.NewFlagSet(args[0]+" "+args[1], flag.ExitOnError)
+	name := flags.String("name", "", "name of the inspected record, all the records if empty")
+	service := flags.String("service", "", "network service of the inspected endpoints, all the endpoints if empty")
+	withMetadata := flags.Bool("metadata", false, "list the records with their metadata by the admin service instead of Find")
+	outputName := flags.String("output", string(inspect.Text), "output format: text or json")
+	rawURL := flags.String("url", "", "url of the registry, the first of NSM_LISTEN_ON if empty")
+	plaintext := flags.Bool("insecure", false, "connect without TLS to a plaintext listener")
+	timeout := flags.Duration("timeout", 10*time.Second, "timeout of the query")
+	_ = flags.Parse(args[2:])
+
+	var output inspect.Output
+	if err := output.Decode(*outputName); err != nil {
+		logrus.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	u, cc, closeCC := dialRegistry(ctx, config, *rawURL, *plaintext)
+	defer closeCC()
+
+	var err error
+	if args[1] == "nse" {
+		err = inspect.NetworkServiceEndpoints(ctx, cc, *name, *service, *withMetadata, os.Stdout, output)
+	} else {
+		err = inspect.NetworkServices(ctx, cc, *name, *withMetadata, os.Stdout, output)
+	}
+	if err != nil {
+		logrus.Fatalf("error inspecting the registry %s: %+v", u, err)
+	}
+}
+
+// runMaintenance runs the maintenance command turning the maintenance of a running registry on or off by the admin
+// service
+func runMaintenance(ctx context.Context, config *Config, args []string) {
+	if len(args) < 2 || (args[1] != "on" && args[1] != "off") {
+		logrus.Fatal("expected the maintenance to set: on or off")
+	}
+	flags := flag.NewFlagSet(args[0]+" "+args[1], flag.ExitOnError)
+	rawURL := flags.String("url", "", "url of the registry, the first of NSM_LISTEN_ON if empty")
+	plaintext := flags.Bool("insecure", false, "connect without TLS to a plaintext listener")
+	timeout := flags.Duration("timeout", 10*time.Second, "timeout of the request")
+	_ = flags.Parse(args[2:])
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	u, cc, closeCC := dialRegistry(ctx, config, *rawURL, *plaintext)
+	defer closeCC()
+
+	enabled, err := admin.NewClient(cc).SetMaintenance(ctx, args[1] == "on")
+	if err != nil {
+		logrus.Fatalf("error setting the maintenance of the registry %s: %+v", u, err)
+	}
+	logrus.Infof("maintenance of the registry %s enabled: %v", u, enabled)
+}
+
+// runHandoff runs the handoff command making a running registry hand off its listeners to a replacement process by the
+// admin service
+func runHandoff(ctx context.Context, config *Config, args []string) {
+	flags := flag.NewFlagSet(args[0], flag.ExitOnError)
+	rawURL := flags.String("url", "", "url of the registry, the first of NSM_LISTEN_ON if empty")
+	plaintext := flags.Bool("insecure", false, "connect without TLS to a plaintext listener")
+	timeout := flags.Duration("timeout", 10*time.Second, "timeout of the request")
+	_ = flags.Parse(args[1:])
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	u, cc, closeCC := dialRegistry(ctx, config, *rawURL, *plaintext)
+	defer closeCC()
+
+	pid, err := admin.NewClient(cc).Handoff(ctx)
+	if err != nil {
+		logrus.Fatalf("error handing off the listeners of the registry %s: %+v", u, err)
+	}
+	logrus.Infof("the registry %s handed off its listeners to the process %d", u, pid)
+}
+
+// dialRegistry dials the registry at the raw url, the first of NSM_LISTEN_ON if it is empty, with the SVID of the
+// container unless plaintext is set, the returned function closes the connection
+func dialRegistry(ctx context.Context, config *Config, rawURL string, plaintext bool) (*url.URL, *grpc.ClientConn, func()) {
+	u := &url.URL{}
+	if rawURL != "" {
+		var err error
+		if u, err = url.Parse(rawURL); err != nil {
+			logrus.Fatalf("invalid url of the registry: %+v", err)
+		}
+	} else if len(config.ListenOn) > 0 {
+		u = &config.ListenOn[0]
+	}
+
+	dialOptions := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	var source *workloadapi.X509Source
+	if !plaintext {
+		var err error
+		if source, err = workloadapi.NewX509Source(ctx); err != nil {
+			logrus.WithField(errcode.LogField, errcode.SpireUnavailable).Fatalf("error getting x509 source: %+v", err)
+		}
+		dialOptions = newClientOptions(config, source)
+	}
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(u), dialOptions...)
+	if err != nil {
+		logrus.Fatalf("error dialing the registry %s: %+v", u, err)
+	}
+	return u, cc, func() {
+		_ = cc.Close()
+		if source != nil {
+			_ = source.Close()
+		}
+	}
+}
+
+// runBench runs the bench command generating the load of the simulated endpoints and clients against a registry and
+// printing the latencies of the operations
+func runBench(ctx context.Context, config *Config, args []string) {
+	flags := flag.NewFlagSet(args[0], flag.ExitOnError)
+	endpoints := flags.Int("endpoints", 100, "number of the simulated endpoints")
+	clients := flags.Int("clients", 100, "number of the simulated clients")
+	services := flags.Int("services", 10, "number of the network services the endpoints are spread over")
+	refreshInterval := flags.Duration("refresh-interval", 10*time.Second, "interval between the refreshes of every endpoint")
+	findInterval := flags.Duration("find-interval", time.Second, "interval between the Find queries of every client")
+	duration := flags.Duration("duration", time.Minute, "how long the load is generated")
+	prefix := flags.String("prefix", "bench", "prefix of the names of the registered network services and endpoints")
+	metricsURL := flags.String("metrics-url", "", "url of the Prometheus endpoint of the registry to report the apiserver write rate, e.g. http://registry:9090/metrics")
+	rawURL := flags.String("url", "", "url of the registry, the first of NSM_LISTEN_ON if empty")
+	plaintext := flags.Bool("insecure", false, "connect without TLS to a plaintext listener")
+	_ = flags.Parse(args[1:])
+
+	u, cc, closeCC := dialRegistry(ctx, config, *rawURL, *plaintext)
+	defer closeCC()
+
+	log.FromContext(ctx).Infof("benchmarking the registry %s with %d endpoints and %d clients for %v", u, *endpoints, *clients, *duration)
+	report, err := bench.Run(ctx, cc,
+		bench.WithEndpoints(*endpoints),
+		bench.WithClients(*clients),
+		bench.WithServices(*services),
+		bench.WithRefreshInterval(*refreshInterval),
+		bench.WithFindInterval(*findInterval),
+		bench.WithDuration(*duration),
+		bench.WithPrefix(*prefix),
+		bench.WithMetricsURL(*metricsURL))
+	if err != nil {
+		logrus.Fatalf("error benchmarking the registry %s: %+v", u, err)
+	}
+	if err = report.Write(os.Stdout); err != nil {
+		logrus.Fatalf("error writing the report: %+v", err)
+	}
+}
+
+// runReplay runs the replay command sending the requests of a traffic record file to a registry
+func runReplay(ctx context.Context, config *Config, args []string) {
+	flags := flag.NewFlagSet(args[0], flag.ExitOnError)
+	fileName := flags.String("file", "", "traffic record file recorded with NSM_TRAFFIC_RECORD_FILE")
+	speed := flags.Float64("speed", 1, "speed of the replay relative to the recording, e.g. 10 replays 10 times faster")
+	rawURL := flags.String("url", "", "url of the registry, the first of NSM_LISTEN_ON if empty")
+	plaintext := flags.Bool("insecure", false, "connect without TLS to a plaintext listener")
+	_ = flags.Parse(args[1:])
+
+	if *fileName == "" {
+		logrus.Fatal("expected the traffic record file to replay: -file")
+	}
+	f, err := os.Open(*fileName)
+	if err != nil {
+		logrus.Fatalf("error opening the traffic record file: %+v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	u, cc, closeCC := dialRegistry(ctx, config, *rawURL, *plaintext)
+	defer closeCC()
+
+	stats, err := traffic.Replay(ctx, cc, f, *speed)
+	if err != nil {
+		logrus.Fatalf("error replaying the traffic to the registry %s: %+v", u, err)
+	}
+	log.FromContext(ctx).Infof("replayed %d requests to the registry %s, %d of them failed", stats.Replayed, u, stats.Failed)
+}
+
+// runDoctor runs the doctor command checking the dependencies used by the configuration and printing a report, it
+// fails if any of the checks fails
+func runDoctor(ctx context.Context, config *Config, args []string) {
+	flags := flag.NewFlagSet(args[0], flag.ExitOnError)
+	timeout := flags.Duration("timeout", 10*time.Second, "timeout of each check")
+	_ = flags.Parse(args[1:])
+
+	kubeClient, err := newKubeClient(config)
+	if err != nil {
+		logrus.Fatalf("error creating kubernetes client: %+v", err)
+	}
+	checks := []doctor.Check{doctor.SPIRE()}
+	if kubeClient != nil {
+		checks = append(checks, doctor.APIServer(kubeClient), doctor.RBAC(kubeClient, config.Namespace, requiredResources(config)))
+		if usesK8sStorage(config) {
+			checks = append(checks, doctor.CRDs(kubeClient))
+		}
+	}
+	checks = append(checks,
+		doctor.Policies("server-policies", config.RegistryServerPolicies),
+		doctor.Policies("client-policies", config.RegistryClientPolicies))
+	if opentelemetry.IsEnabled() {
+		checks = append(checks, doctor.Collector(config.OpenTelemetryEndpoint))
+	}
 
-	// Adjust config and create ClientSet
-	client, _, err := k8s.NewVersionedClient(
+	failed, err := doctor.Report(os.Stdout, doctor.Run(ctx, checks, *timeout))
+	if err != nil {
+		logrus.Fatalf("error writing the report: %+v", err)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// requiredResources returns the access to the Kubernetes resources the configuration requires
+func requiredResources(config *Config) []authorizationv1.ResourceAttributes {
+	var resources []authorizationv1.ResourceAttributes
+	add := func(group, resource string, verbs ...string) {
+		for _, verb := range verbs {
+			resources = append(resources, authorizationv1.ResourceAttributes{Group: group, Resource: resource, Verb: verb})
+		}
+	}
+	if usesK8sStorage(config) {
+		for _, resource := range []string{"networkservices", "networkserviceendpoints"} {
+			add("networkservicemesh.io", resource, "get", "list", "watch", "create", "patch", "delete")
+		}
+	}
+	if config.PodOwners {
+		add("", "pods", "get")
+	}
+	if config.PodEviction {
+		add("", "pods", "list", "watch")
+	}
+	if config.EventsEnabled {
+		add("", "events", "create", "patch")
+	}
+	if config.LeaderElection {
+		add("coordination.k8s.io", "leases", "get", "list", "watch", "create", "update")
+	}
+	if config.ShardCount > 0 {
+		add("", "configmaps", "get", "list", "watch", "create", "patch")
+	} else if config.ExpirationPoliciesConfigMap != "" {
+		add("", "configmaps", "get", "list", "watch")
+	}
+	return resources
+}
+
+// newShardStorage creates the storage serving only the registrations of the shard of this replica and advertises the
+// replica in the shard map
+func newShardStorage(ctx context.Context, config *Config, client kubernetes.Interface, s storage.Storage) (storage.Storage, error) {
+	index := config.ShardIndex
+	if index < 0 {
+		var err error
+		if index, err = shard.IndexFromHostname(); err != nil {
+			return nil, err
+		}
+	}
+	if index >= config.ShardCount {
+		return nil, errors.Errorf("shard %d is out of the %d shards", index, config.ShardCount)
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the hostname")
+	}
+	m, err := shard.NewMap(ctx, client, config.Namespace, config.ShardMapName, index, hostname)
+	if err != nil {
+		return nil, err
+	}
+	log.FromContext(ctx).Infof("serving the registrations of the shard %d of %d", index, config.ShardCount)
+	return shard.NewStorage(s, index, config.ShardCount, m), nil
+}
+
+// newExpirationPolicies creates the expiration policies configured by env and watches the ones of the ConfigMap
+func newExpirationPolicies(ctx context.Context, config *Config, kubeClient kubernetes.Interface) *expirepolicy.Store {
+	store := expirepolicy.NewStore(config.ExpirationPolicies)
+	if config.ExpirationPoliciesConfigMap == "" {
+		return store
+	}
+	if err := store.WatchConfigMap(ctx, kubeClient, config.Namespace, config.ExpirationPoliciesConfigMap); err != nil {
+		logrus.Fatalf("error watching the expiration policies: %+v", err)
+	}
+	return store
+}
+
+// newPodEvictionClient returns the client watching the pods of the NSEs or nil if the pod eviction is disabled
+func newPodEvictionClient(config *Config, kubeClient kubernetes.Interface) kubernetes.Interface {
+	if !config.PodEviction {
+		return nil
+	}
+	return kubeClient
+}
+
+// newRecorder creates the recorder of Kubernetes Events or returns nil if the events are disabled
+func newRecorder(ctx context.Context, config *Config, kubeClient kubernetes.Interface) record.EventRecorder {
+	if !config.EventsEnabled {
+		return nil
+	}
+	return events.NewRecorder(ctx, kubeClient, "registry-k8s")
+}
+
+// newProbes creates the HTTP health probes of the components, they are not ready until the registry starts them
+func newProbes(ctx context.Context, config *Config, kubeClient kubernetes.Interface) *health.Probes {
+	probes := health.NewProbes(ctx,
+		health.WithInterval(config.HealthCheckInterval),
+		health.WithTimeout(config.HealthCheckTimeout))
+	probes.Pending(ctx, "spire", "the X.509 SVID")
+	probes.Expect(ctx, "listeners")
+	if usesK8sStorage(config) {
+		probes.Expect(ctx, "informers")
+	}
+	if checksAPIServer(config) {
+		probes.Set(ctx, "apiserver", health.APIServerCheck(kubeClient))
+	}
+	if config.HealthProbesListenOn != "" {
+		go health.ListenAndServe(ctx, config.HealthProbesListenOn, probes)
+	}
+	return probes
+}
+
+// withStartupTimeout returns the context of waiting for a dependency of the registry while it starts
+func withStartupTimeout(ctx context.Context, config *Config) (context.Context, context.CancelFunc) {
+	if config.StartupTimeout > 0 {
+		return context.WithTimeout(ctx, config.StartupTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// usesK8sStorage returns true if the registrations are stored in the custom resources
+func usesK8sStorage(config *Config) bool {
+	return config.Storage == "k8s" || config.ShadowStorage == "k8s"
+}
+
+// checksAPIServer returns true if the readiness of the registry depends on the apiserver, the fake clients have none
+func checksAPIServer(config *Config) bool {
+	return usesK8sStorage(config) && !config.K8sFake
+}
+
+// newHealthServer creates the health server reporting the results of the SPIRE and the apiserver checks run by the
+// probes, so the checks run once for both
+func newHealthServer(ctx context.Context, config *Config, probes *health.Probes, registryServer registryserver.Registry) grpc_health_v1.HealthServer {
+	healthOptions := []health.Option{
+		health.WithInterval(config.HealthCheckInterval),
+		health.WithTimeout(config.HealthCheckTimeout),
+		health.WithCheck("spire", probes.Check("spire")),
+	}
+	if checksAPIServer(config) {
+		healthOptions = append(healthOptions, health.WithCheck("apiserver", probes.Check("apiserver")))
+	}
+	return health.NewServer(ctx, []interface{}{registryServer.NetworkServiceRegistryServer(), registryServer.NetworkServiceEndpointRegistryServer()}, healthOptions...)
+}
+
+// registerServices registers the registry, health and the enabled bulk, admin and debugging services on the servers
+func registerServices(config *Config, servers map[listeners.Security]*grpc.Server, registryServer registryserver.Registry, healthServer grpc_health_v1.HealthServer, adminServer admin.Server) {
+	var bulkServer bulk.Server
+	if config.BulkRegistrationEnabled {
+		bulkServer = bulk.NewServer(registryServer.NetworkServiceEndpointRegistryServer(), bulk.WithTimeout(config.DefaultRequestTimeout))
+	}
+	for _, server := range servers {
+		registry.RegisterNetworkServiceRegistryServer(server, registryServer.NetworkServiceRegistryServer())
+		registry.RegisterNetworkServiceEndpointRegistryServer(server, registryServer.NetworkServiceEndpointRegistryServer())
+		grpc_health_v1.RegisterHealthServer(server, healthServer)
+		if bulkServer != nil {
+			bulk.Register(server, bulkServer)
+		}
+		if adminServer != nil {
+			admin.Register(server, adminServer)
+		}
+		if config.ReflectionEnabled {
+			reflection.Register(server)
+		}
+		if config.ChannelzEnabled {
+			channelzservice.RegisterChannelzServiceToServer(server)
+		}
+	}
+}
+
+// newKubeClient creates the Kubernetes client shared by the features using the core resources, so they share its QPS,
+// or returns nil if none of them is enabled
+func newKubeClient(config *Config) (kubernetes.Interface, error) {
+	if len(requiredResources(config)) == 0 {
+		return nil, nil
+	}
+	if config.K8sFake {
+		return fakek8s.KubeClient(), nil
+	}
+	restConfig, err := k8s.NewClientSetConfig(
 		k8s.WithQPS(float32(config.KubeletQPS)),
 		k8s.WithBurst(config.KubeletQPS*2))
 	if err != nil {
-		logrus.Fatalf("error creating NewVersionedClient: %+v", err)
+		return nil, err
 	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	return client, errors.Wrap(err, "failed to create kubernetes client")
+}
 
-	config.ClientSet = client
-	config.ChainCtx = ctx
+// newVersionedClient creates the custom resource client, with NSM_KUBELET_QPS_ADAPTIVE its QPS follows the load. The
+// fake client serving the CRDs has no rest config.
+func newVersionedClient(ctx context.Context, config *Config) (versioned.Interface, *rest.Config, error) {
+	if config.K8sFake {
+		return fakek8s.VersionedClient(), nil, nil
+	}
+	if !config.KubeletQPSAdaptive {
+		return k8s.NewVersionedClient(
+			k8s.WithQPS(float32(config.KubeletQPS)),
+			k8s.WithBurst(config.KubeletQPS*2))
+	}
+	restConfig, err := k8s.NewClientSetConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	ratelimit.New(ctx, float64(config.KubeletQPS), float64(config.KubeletQPSMin), float64(config.KubeletQPSMax)).Configure(restConfig)
+	client, err := versioned.NewForConfig(restConfig)
+	return client, restConfig, errors.Wrap(err, "failed to create the custom resource client")
+}
 
-	registryk8s.NewServer(
-		&config.Config,
-		spiffejwt.TokenGeneratorFunc(source, config.MaxTokenLifetime),
-		registryk8s.WithAuthorizeNSERegistryServer(authorize.NewNetworkServiceEndpointRegistryServer(authorize.WithPolicies(config.RegistryServerPolicies...))),
-		registryk8s.WithAuthorizeNSERegistryClient(authorize.NewNetworkServiceEndpointRegistryClient(authorize.WithPolicies(config.RegistryClientPolicies...))),
-		registryk8s.WithAuthorizeNSRegistryServer(authorize.NewNetworkServiceRegistryServer(authorize.WithPolicies(config.RegistryServerPolicies...))),
-		registryk8s.WithAuthorizeNSRegistryClient(authorize.NewNetworkServiceRegistryClient(authorize.WithPolicies(config.RegistryClientPolicies...))),
-		registryk8s.WithDialOptions(clientOptions...),
-	).Register(server)
+func newElector(ctx context.Context, config *Config, client kubernetes.Interface) (*leader.Elector, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the hostname")
+	}
+	return leader.NewElector(ctx, client, config.Namespace, hostname,
+		leader.WithLeaseName(config.LeaderElectionLeaseName),
+		leader.WithLeaseDuration(config.LeaderElectionLeaseDuration),
+		leader.WithRenewDeadline(config.LeaderElectionRenewDeadline),
+		leader.WithRetryPeriod(config.LeaderElectionRetryPeriod))
+}
 
-	for i := 0; i < len(config.ListenOn); i++ {
-		srvErrCh := grpcutils.ListenAndServe(ctx, &config.ListenOn[i], server)
-		exitOnErr(ctx, cancel, srvErrCh)
+func newStorage(ctx context.Context, kind string, config *Config, kubeClient kubernetes.Interface, recorder record.EventRecorder) (storage.Storage, error) {
+	switch kind {
+	case "k8s":
+		ctx = logging.WithComponent(ctx, logging.K8s)
+		// Adjust config and create ClientSet
+		client, restConfig, err := newVersionedClient(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+		if config.ManageCRDs && !config.K8sFake {
+			dynamicClient, dynamicErr := dynamic.NewForConfig(restConfig)
+			if dynamicErr != nil {
+				return nil, errors.Wrap(dynamicErr, "failed to create dynamic kubernetes client")
+			}
+			if err = crds.Ensure(ctx, dynamicClient, config.FieldManager); err != nil {
+				return nil, err
+			}
+		} else {
+			waitCtx, cancelWait := withStartupTimeout(ctx, config)
+			err = crds.Wait(waitCtx, client.Discovery())
+			cancelWait()
+			if err != nil {
+				return nil, errors.Wrap(err, "the CRDs are missing, install them or set NSM_MANAGE_CRDS=true")
+			}
+		}
+		opts := []k8sstorage.Option{
+			k8sstorage.WithFieldManager(config.FieldManager),
+			k8sstorage.WithSyncTimeout(config.StartupTimeout),
+			k8sstorage.WithResyncPeriod(config.InformerResyncPeriod),
+			k8sstorage.WithResyncJitter(config.InformerResyncJitter),
+			k8sstorage.WithConflictRetry(config.ConflictRetrySteps, config.ConflictRetryBackoff, config.ConflictRetryJitter),
+			k8sstorage.WithFinalizers(config.CRFinalizers),
+			k8sstorage.WithSweeper(config.SweepInterval, config.SweepGracePeriod),
+			k8sstorage.WithSoftDelete(config.SoftDeleteExpired),
+			k8sstorage.WithSkipNoopRefreshes(config.SkipNoopRefreshes),
+			k8sstorage.WithLabels(config.CRLabels),
+			k8sstorage.WithAnnotations(config.CRAnnotations),
+			k8sstorage.WithWatchWindow(config.WatchCoalesceWindow),
+			k8sstorage.WithWatchQueue(config.WatchQueueSize, config.WatchOverflowPolicy),
+			k8sstorage.WithReadOnly(config.ReadOnly),
+		}
+		if config.CRStatusEnabled {
+			hostname, hostnameErr := os.Hostname()
+			if hostnameErr != nil {
+				return nil, errors.Wrap(hostnameErr, "failed to get the hostname")
+			}
+			opts = append(opts, k8sstorage.WithStatus(hostname))
+		}
+		if config.PodOwners {
+			opts = append(opts, k8sstorage.WithPodOwners(kubeClient))
+		}
+		if recorder != nil {
+			opts = append(opts, k8sstorage.WithEventRecorder(recorder))
+		}
+		k8sStorage, err := k8sstorage.NewStorage(ctx, config.Namespace, client, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return logging.NewStorage(logging.K8s, k8sStorage), nil
+	case "etcd":
+		client, err := etcd.NewClient(ctx, &config.Etcd)
+		if err != nil {
+			return nil, err
+		}
+		go func() {
+			<-ctx.Done()
+			_ = client.Close()
+		}()
+		return etcd.NewStorage(client, config.Etcd.Prefix, etcd.WithPageSize(config.Etcd.PageSize)), nil
+	case "memory":
+		return memory.NewStorage(), nil
+	default:
+		return nil, errors.Errorf("unknown storage: %s", kind)
 	}
+}
 
-	log.FromContext(ctx).Infof("Startup completed in %v", time.Since(startTime))
-	<-ctx.Done()
+// listenersHandoff hands off the listeners bound by listenAndServe to a replacement process for the admin service and
+// stops serving
+type listenersHandoff struct {
+	cancel context.CancelFunc
+	bound  atomic.Pointer[[]listeners.Bound]
+}
+
+// run hands off the listeners once and returns the pid of the replacement, it fails until the listeners are bound
+func (h *listenersHandoff) run(ctx context.Context) (int, error) {
+	bound := h.bound.Swap(nil)
+	if bound == nil {
+		return 0, status.Error(codes.FailedPrecondition, "the listeners are not bound or are handed off already")
+	}
+	process, err := listeners.Handoff(*bound)
+	if err != nil {
+		h.bound.Store(bound)
+		return 0, status.Errorf(codes.FailedPrecondition, "failed to hand off the listeners: %v", err)
+	}
+	log.FromContext(ctx).Infof("handed off the listeners to the process %d", process.Pid)
+	// The servers stop gracefully, so the response is sent before they do
+	h.cancel()
+	return process.Pid, nil
 }
 
 func exitOnErr(ctx context.Context, cancel context.CancelFunc, errCh <-chan error) {
@@ -0,0 +1,226 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/fakek8s"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/leader"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/maintenance"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/registrytest"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/shard"
+	"github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage"
+	k8sstorage "github.com/networkservicemesh/cmd-registry-k8s/internal/pkg/storage/k8s"
+)
+
+// newTestConfig returns the default configuration backed by the fake clients shared by the tests, each test uses its
+// own namespace
+func newTestConfig(t *testing.T, namespace string) *Config {
+	t.Helper()
+	config := new(Config)
+	if err := envconfig.Process("nsm", config); err != nil {
+		t.Fatalf("failed to process the config: %v", err)
+	}
+	config.K8sFake = true
+	config.Namespace = namespace
+	return config
+}
+
+func newTestRegistryStorage(t *testing.T, config *Config, elector *leader.Elector, mode *maintenance.Mode) storage.Storage {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if mode == nil {
+		mode = maintenance.NewMode(false, time.Second)
+	}
+	s, _, err := newRegistryStorage(ctx, config, fakek8s.KubeClient(), nil, elector, nil, mode, nil)
+	if err != nil {
+		t.Fatalf("failed to create the storage: %v", err)
+	}
+	return s
+}
+
+func registerNSE(s storage.Storage, name string, services ...string) error {
+	_, err := s.NetworkServiceEndpointRegistryServer().Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                name,
+		NetworkServiceNames: services,
+	})
+	return err
+}
+
+// waitFound waits for the endpoint to be found in the storage
+func waitFound(t *testing.T, s storage.Storage, name string) {
+	t.Helper()
+	query := &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: name}}
+	registrytest.Eventually(t, func() bool {
+		return len(registrytest.FindNSENames(context.Background(), t, s.NetworkServiceEndpointRegistryServer(), query)) > 0
+	}, "the nse %s is not found", name)
+}
+
+func TestRegistryStorageRejectsWritesInMaintenance(t *testing.T) {
+	mode := maintenance.NewMode(true, time.Second)
+	s := newTestRegistryStorage(t, newTestConfig(t, "maintenance"), nil, mode)
+
+	err := registerNSE(s, "maintenance-nse")
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("the registration in maintenance got %v, want unavailable", err)
+	}
+	retry := false
+	for _, detail := range status.Convert(err).Details() {
+		if _, ok := detail.(*errdetails.RetryInfo); ok {
+			retry = true
+		}
+	}
+	if !retry {
+		t.Error("the registration rejected in maintenance carries no retry delay")
+	}
+
+	mode.Set(false)
+	if err = registerNSE(s, "maintenance-nse"); err != nil {
+		t.Fatalf("the registration out of maintenance failed: %v", err)
+	}
+	mode.Set(true)
+	// Find queries are served in maintenance
+	waitFound(t, s, "maintenance-nse")
+}
+
+func TestRegistryStorageServesOnlyItsShard(t *testing.T) {
+	config := newTestConfig(t, "sharding")
+	config.ShardCount, config.ShardIndex = 2, 0
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if _, err := shard.NewMap(ctx, fakek8s.KubeClient(), config.Namespace, config.ShardMapName, 1, "registry-1"); err != nil {
+		t.Fatalf("failed to advertise the other shard: %v", err)
+	}
+	s := newTestRegistryStorage(t, config, nil, nil)
+
+	services := make(map[int]string)
+	for i := 0; len(services) < config.ShardCount; i++ {
+		service := fmt.Sprintf("ns-%d", i)
+		if _, ok := services[shard.Of(service, config.ShardCount)]; !ok {
+			services[shard.Of(service, config.ShardCount)] = service
+		}
+	}
+	if err := registerNSE(s, "own-nse", services[0]); err != nil {
+		t.Fatalf("the registration of the own shard failed: %v", err)
+	}
+	err := registerNSE(s, "other-nse", services[1])
+	if status.Code(err) != codes.Unavailable || !strings.Contains(status.Convert(err).Message(), "registry-1") {
+		t.Errorf("the registration of the other shard got %v, want unavailable naming its owner", err)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatalf("failed to get the hostname: %v", err)
+	}
+	configMap, err := fakek8s.KubeClient().CoreV1().ConfigMaps(config.Namespace).Get(ctx, config.ShardMapName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("the shard map is not advertised: %v", err)
+	}
+	if configMap.Data["0"] != hostname || configMap.Data["1"] != "registry-1" {
+		t.Errorf("got the shard map %v, want 0=%s and 1=registry-1", configMap.Data, hostname)
+	}
+}
+
+func TestRegistryStorageRejectsWritesOfFollowers(t *testing.T) {
+	config := newTestConfig(t, "leader-election")
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	opts := []leader.Option{
+		leader.WithLeaseDuration(2 * time.Second),
+		leader.WithRenewDeadline(time.Second),
+		leader.WithRetryPeriod(100 * time.Millisecond),
+	}
+	current, err := leader.NewElector(ctx, fakek8s.KubeClient(), config.Namespace, "current", opts...)
+	if err != nil {
+		t.Fatalf("failed to create the elector: %v", err)
+	}
+	registrytest.Eventually(t, current.IsLeader, "the first replica does not lead")
+	follower, err := leader.NewElector(ctx, fakek8s.KubeClient(), config.Namespace, "follower", opts...)
+	if err != nil {
+		t.Fatalf("failed to create the elector: %v", err)
+	}
+	s := newTestRegistryStorage(t, config, follower, nil)
+
+	if err = registerNSE(s, "leader-nse"); status.Code(err) != codes.Unavailable {
+		t.Fatalf("the registration on the follower got %v, want unavailable", err)
+	}
+
+	current.Resign()
+	registrytest.Eventually(t, follower.IsLeader, "the follower does not take over the released Lease")
+	if err = registerNSE(s, "leader-nse"); err != nil {
+		t.Fatalf("the registration on the new leader failed: %v", err)
+	}
+	waitFound(t, s, "leader-nse")
+}
+
+func TestRegistryStorageDisconnectsStalledWatches(t *testing.T) {
+	config := newTestConfig(t, "watch-overflow")
+	config.WatchQueueSize, config.WatchOverflowPolicy = 1, k8sstorage.Disconnect
+	s := newTestRegistryStorage(t, config, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	// the updates are not buffered, so the watch stalls as soon as they are not read
+	updates, errs := registrytest.WatchNSEs(ctx, s.NetworkServiceEndpointRegistryServer(), &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{NetworkServiceNames: []string{"overflow-ns"}},
+		Watch:                  true,
+	}, 0)
+	registrytest.WaitSubscribed(t, updates, func() string {
+		if err := registerNSE(s, "overflow-probe", "overflow-ns"); err != nil {
+			t.Fatalf("failed to register the probe: %v", err)
+		}
+		return "overflow-probe"
+	})
+
+	// one update is being sent, one is queued and the next one overflows the queue
+	for i := 0; i < 3; i++ {
+		if err := registerNSE(s, fmt.Sprintf("overflow-nse-%d", i), "overflow-ns"); err != nil {
+			t.Fatalf("registration failed: %v", err)
+		}
+	}
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case <-updates:
+		case err := <-errs:
+			if status.Code(err) != codes.ResourceExhausted {
+				t.Errorf("the stalled watch ended with %v, want resource exhausted", err)
+			}
+			return
+		case <-timeout:
+			t.Fatal("the stalled watch is not disconnected")
+		}
+	}
+}
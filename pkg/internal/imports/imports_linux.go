@@ -2,31 +2,146 @@
 package imports
 
 import (
+	_ "bufio"
+	_ "bytes"
+	_ "container/list"
 	_ "context"
 	_ "crypto/tls"
+	_ "crypto/x509"
+	_ "embed"
+	_ "encoding/binary"
+	_ "encoding/json"
+	_ "fmt"
 	_ "github.com/antonfisher/nested-logrus-formatter"
-	_ "github.com/bszirtes/sdk-k8s/pkg/registry/chains/registryk8s"
 	_ "github.com/bszirtes/sdk-k8s/pkg/tools/k8s"
+	_ "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/apis/networkservicemesh.io/v1"
+	_ "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/clientset/versioned"
+	_ "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/clientset/versioned/scheme"
+	_ "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/informers/externalversions"
+	_ "github.com/bszirtes/sdk-k8s/pkg/tools/k8s/client/listers/networkservicemesh.io/v1"
+	_ "github.com/edwarnicke/genericsync"
 	_ "github.com/edwarnicke/grpcfd"
+	_ "github.com/edwarnicke/serialize"
+	_ "github.com/golang-jwt/jwt/v4"
+	_ "github.com/golang/protobuf/ptypes/empty"
+	_ "github.com/google/uuid"
 	_ "github.com/kelseyhightower/envconfig"
+	_ "github.com/networkservicemesh/api/pkg/api"
+	_ "github.com/networkservicemesh/api/pkg/api/registry"
+	_ "github.com/networkservicemesh/sdk/pkg/registry"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/begin"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/clientconn"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/clienturl"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/connect"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/dial"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/expire"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/setpayload"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/setregistrationtime"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/updatepath"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/core/streamcontext"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/switchcase"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/utils/metadata"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/debug"
-	_ "github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/interdomain"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/log"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/matchutils"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/pprofutils"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/token"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/tracing"
+	_ "github.com/pkg/errors"
+	_ "github.com/prometheus/client_golang/prometheus"
+	_ "github.com/prometheus/client_golang/prometheus/promhttp"
 	_ "github.com/sirupsen/logrus"
+	_ "github.com/spiffe/go-spiffe/v2/spiffeid"
 	_ "github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	_ "github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	_ "github.com/spiffe/go-spiffe/v2/workloadapi"
+	_ "go.etcd.io/etcd/api/v3/mvccpb"
+	_ "go.etcd.io/etcd/client/pkg/v3/transport"
+	_ "go.etcd.io/etcd/client/v3"
+	_ "go.opentelemetry.io/otel"
+	_ "go.opentelemetry.io/otel/attribute"
+	_ "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	_ "go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	_ "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	_ "go.opentelemetry.io/otel/exporters/prometheus"
+	_ "go.opentelemetry.io/otel/metric"
+	_ "go.opentelemetry.io/otel/propagation"
+	_ "go.opentelemetry.io/otel/sdk/metric"
+	_ "go.opentelemetry.io/otel/sdk/resource"
+	_ "go.opentelemetry.io/otel/sdk/trace"
+	_ "go.opentelemetry.io/otel/semconv/v1.4.0"
+	_ "go.opentelemetry.io/otel/trace"
+	_ "golang.org/x/sys/unix"
+	_ "golang.org/x/time/rate"
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails"
 	_ "google.golang.org/grpc"
+	_ "google.golang.org/grpc/channelz/service"
+	_ "google.golang.org/grpc/codes"
 	_ "google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/encoding/gzip"
+	_ "google.golang.org/grpc/grpclog"
+	_ "google.golang.org/grpc/health"
+	_ "google.golang.org/grpc/health/grpc_health_v1"
+	_ "google.golang.org/grpc/metadata"
+	_ "google.golang.org/grpc/peer"
+	_ "google.golang.org/grpc/reflection"
+	_ "google.golang.org/grpc/status"
+	_ "google.golang.org/protobuf/proto"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "io"
+	_ "k8s.io/api/core/v1"
+	_ "k8s.io/apimachinery/pkg/api/errors"
+	_ "k8s.io/apimachinery/pkg/apis/meta/v1"
+	_ "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	_ "k8s.io/apimachinery/pkg/labels"
+	_ "k8s.io/apimachinery/pkg/runtime/schema"
+	_ "k8s.io/apimachinery/pkg/types"
+	_ "k8s.io/apimachinery/pkg/util/validation"
+	_ "k8s.io/apimachinery/pkg/util/wait"
+	_ "k8s.io/apimachinery/pkg/util/yaml"
+	_ "k8s.io/client-go/discovery"
+	_ "k8s.io/client-go/dynamic"
+	_ "k8s.io/client-go/kubernetes"
+	_ "k8s.io/client-go/kubernetes/typed/core/v1"
+	_ "k8s.io/client-go/rest"
+	_ "k8s.io/client-go/tools/cache"
+	_ "k8s.io/client-go/tools/leaderelection"
+	_ "k8s.io/client-go/tools/leaderelection/resourcelock"
+	_ "k8s.io/client-go/tools/metrics"
+	_ "k8s.io/client-go/tools/record"
+	_ "k8s.io/client-go/transport"
+	_ "k8s.io/client-go/util/retry"
+	_ "maps"
+	_ "math"
+	_ "math/rand"
+	_ "net"
+	_ "net/http"
 	_ "net/url"
 	_ "os"
+	_ "os/exec"
 	_ "os/signal"
+	_ "path"
+	_ "path/filepath"
+	_ "reflect"
+	_ "runtime"
+	_ "runtime/debug"
+	_ "runtime/pprof"
+	_ "slices"
+	_ "sort"
+	_ "strconv"
+	_ "strings"
+	_ "sync"
+	_ "sync/atomic"
 	_ "syscall"
 	_ "time"
 )